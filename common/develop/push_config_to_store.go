@@ -2,6 +2,7 @@ package main
 
 import (
 	store "OpenCNC_config_service/common/store-wrapper"
+	"context"
 	"fmt"
 
 	"github.com/openconfig/ygot/ygot"
@@ -162,7 +163,7 @@ var cfg = &topology_config.TopologyConfig{
 	}
 */
 
-func StoreDeviceModel(model *devicemodelregistry.DeviceModel) error {
+func StoreDeviceModel(kv store.Store, model *devicemodelregistry.DeviceModel) error {
 	if model == nil {
 		return fmt.Errorf("device model cannot be nil")
 	}
@@ -170,7 +171,7 @@ func StoreDeviceModel(model *devicemodelregistry.DeviceModel) error {
 	urn := "device-models." + model.Name
 
 	// Check if model already exists
-	if _, err := store.GetFromStore(urn); err == nil {
+	if _, err := store.GetFromStore(context.Background(), kv, urn); err == nil {
 		fmt.Printf("Device model %s exists, overwriting.\n", model.Name)
 	}
 
@@ -181,7 +182,7 @@ func StoreDeviceModel(model *devicemodelregistry.DeviceModel) error {
 	}
 
 	// Store in KV store
-	if err := store.SendToStore(rawResource, urn); err != nil {
+	if err := store.SendToStore(context.Background(), kv, rawResource, urn); err != nil {
 		return fmt.Errorf("failed to store device model: %v", err)
 	}
 
@@ -190,7 +191,7 @@ func StoreDeviceModel(model *devicemodelregistry.DeviceModel) error {
 	return nil
 }
 
-func StoreBridge_with_config() error {
+func StoreBridge_with_config(kv store.Store) error {
 	// Serialize the bridge and config to bytes
 	bridgeBytes, err := proto.Marshal(bridge)
 	if err != nil {
@@ -203,12 +204,12 @@ func StoreBridge_with_config() error {
 	}
 
 	// Store the serialized bridge and config in the store
-	err = store.SendToStore(bridgeBytes, "bridges."+bridge.Name)
+	err = store.SendToStore(context.Background(), kv, bridgeBytes, "bridges."+bridge.Name)
 	if err != nil {
 		return fmt.Errorf("failed to store bridge: %v", err)
 	}
 
-	err = store.SendToStore(configBytes, "configurations."+cfg.ConfigId)
+	err = store.SendToStore(context.Background(), kv, configBytes, "configurations."+cfg.ConfigId)
 	if err != nil {
 		return fmt.Errorf("failed to store config: %v", err)
 	}
@@ -216,9 +217,9 @@ func StoreBridge_with_config() error {
 	return nil
 }
 
-func pullBridge_config(bridgeName string) (*topology_config.TopologyConfig, error) {
+func pullBridge_config(kv store.Store, bridgeName string) (*topology_config.TopologyConfig, error) {
 	// Pull the serialized bridge and config from the store
-	bridgeBytes, err := store.GetFromStore("bridges." + bridgeName)
+	bridgeBytes, err := store.GetFromStore(context.Background(), kv, "bridges."+bridgeName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve bridge: %v", err)
 	}
@@ -232,7 +233,7 @@ func pullBridge_config(bridgeName string) (*topology_config.TopologyConfig, erro
 
 	configId := "config-2" //*retrievedBridge.ActiveConfigId
 
-	configBytes, err := store.GetFromStore("configurations." + configId)
+	configBytes, err := store.GetFromStore(context.Background(), kv, "configurations."+configId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve config: %v", err)
 	}
@@ -246,7 +247,7 @@ func pullBridge_config(bridgeName string) (*topology_config.TopologyConfig, erro
 	return &retrievedConfig, nil
 }
 
-func StoreSchedule() error {
+func StoreSchedule(kv store.Store) error {
 
 	gcl := &qbv.GateControlList{
 		ScheduleId: "sched1",
@@ -276,7 +277,7 @@ func StoreSchedule() error {
 	sched, err := proto.Marshal(gcl)
 
 	// Send serialized request to it's specific path in a store
-	err = store.SendToStore(sched, urn)
+	err = store.SendToStore(context.Background(), kv, sched, urn)
 	if err != nil {
 		//log.Errorf("Failed storing schedule: %v", err)
 		return err
@@ -285,12 +286,12 @@ func StoreSchedule() error {
 	return nil
 }
 
-func pullScheduleFromStore(scheduleId string) (*qbv.GateControlList, error) {
+func pullScheduleFromStore(kv store.Store, scheduleId string) (*qbv.GateControlList, error) {
 	// Create a URN where the serialized request will be stored
 	urn := "configurations.schedules." + scheduleId
 
 	// Pull serialized request from store
-	rawsched, err := store.GetFromStore(urn)
+	rawsched, err := store.GetFromStore(context.Background(), kv, urn)
 	if err != nil {
 		return nil, err
 	}
@@ -306,11 +307,18 @@ func pullScheduleFromStore(scheduleId string) (*qbv.GateControlList, error) {
 }
 
 func main() {
-	//StoreSchedule()
-	//pullScheduleFromStore("sched1")
-	//StoreDeviceModel(model)
-	StoreBridge_with_config()
-	config, _ := pullBridge_config("bridge-1")
+	kv, err := store.OpenFromEnv()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer kv.Close()
+
+	//StoreSchedule(kv)
+	//pullScheduleFromStore(kv, "sched1")
+	//StoreDeviceModel(kv, model)
+	StoreBridge_with_config(kv)
+	config, _ := pullBridge_config(kv, "bridge-1")
 	fmt.Println(config.ConfigId)
 
 }
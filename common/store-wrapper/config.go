@@ -0,0 +1,144 @@
+package storewrapper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config locates the etcd cluster and authenticates against it.
+type Config struct {
+	Endpoints   []string
+	DialTimeout time.Duration
+
+	// RequestTimeout bounds each operation, on top of the caller's context.
+	RequestTimeout time.Duration
+
+	// Username and Password enable etcd authentication when set.
+	Username string
+	Password string
+
+	// TLS secures the connections, nil for plain http endpoints.
+	TLS *tls.Config
+}
+
+// DefaultConfig is a local etcd without authentication.
+func DefaultConfig() Config {
+	return Config{
+		Endpoints:      []string{"http://127.0.0.1:2379"},
+		DialTimeout:    10 * time.Second,
+		RequestTimeout: 5 * time.Second,
+	}
+}
+
+// ConfigFromEnv completes DefaultConfig with the ETCD_* environment variables:
+// ETCD_ENDPOINTS (comma separated), ETCD_DIAL_TIMEOUT and ETCD_REQUEST_TIMEOUT
+// (e.g. "5s"), ETCD_USERNAME and ETCD_PASSWORD, ETCD_CA_FILE to verify the
+// servers and ETCD_CERT_FILE/ETCD_KEY_FILE to authenticate with a client
+// certificate.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if raw := strings.TrimSpace(os.Getenv("ETCD_ENDPOINTS")); raw != "" {
+		config.Endpoints = nil
+		for _, endpoint := range strings.Split(raw, ",") {
+			if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+				config.Endpoints = append(config.Endpoints, endpoint)
+			}
+		}
+		if len(config.Endpoints) == 0 {
+			return Config{}, fmt.Errorf("ETCD_ENDPOINTS %q holds no endpoint", raw)
+		}
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"ETCD_DIAL_TIMEOUT":    &config.DialTimeout,
+		"ETCD_REQUEST_TIMEOUT": &config.RequestTimeout,
+	} {
+		raw := os.Getenv(key)
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return Config{}, fmt.Errorf("invalid %s %q", key, raw)
+		}
+		*dst = d
+	}
+
+	config.Username = os.Getenv("ETCD_USERNAME")
+	config.Password = os.Getenv("ETCD_PASSWORD")
+	if config.Password != "" && config.Username == "" {
+		return Config{}, fmt.Errorf("ETCD_PASSWORD is set without ETCD_USERNAME")
+	}
+
+	tlsConfig, err := tlsConfigFromFiles(
+		os.Getenv("ETCD_CA_FILE"),
+		os.Getenv("ETCD_CERT_FILE"),
+		os.Getenv("ETCD_KEY_FILE"),
+	)
+	if err != nil {
+		return Config{}, err
+	}
+	config.TLS = tlsConfig
+
+	return config, nil
+}
+
+// OpenFromEnv opens the store selected by STORE_BACKEND: "etcd" (default),
+// configured by ConfigFromEnv, "dir" for a DirStore in STORE_DIR, or "memory"
+// for a MemoryStore lost on exit.
+func OpenFromEnv() (Store, error) {
+	switch backend := strings.ToLower(strings.TrimSpace(os.Getenv("STORE_BACKEND"))); backend {
+	case "", "etcd":
+		config, err := ConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewEtcdStore(config)
+	case "dir":
+		return NewDirStore(os.Getenv("STORE_DIR"))
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown STORE_BACKEND %q", backend)
+	}
+}
+
+// tlsConfigFromFiles returns the TLS configuration for the files set, nil if
+// none is. Servers are verified against the system roots without CA file.
+func tlsConfigFromFiles(caFile, certFile, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading etcd CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("etcd CA file %s holds no PEM encoded certificate", caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("ETCD_CERT_FILE and ETCD_KEY_FILE must be set together")
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading etcd client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,61 @@
+package storewrapper
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	for _, key := range []string{"ETCD_ENDPOINTS", "ETCD_DIAL_TIMEOUT", "ETCD_REQUEST_TIMEOUT", "ETCD_USERNAME", "ETCD_PASSWORD", "ETCD_CA_FILE", "ETCD_CERT_FILE", "ETCD_KEY_FILE"} {
+		t.Setenv(key, "")
+	}
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+
+	if !slices.Equal(config.Endpoints, DefaultConfig().Endpoints) || config.TLS != nil {
+		t.Fatalf("expected the local etcd without TLS, got %+v", config)
+	}
+}
+
+func TestConfigFromEnv_ClusterWithAuth(t *testing.T) {
+	t.Setenv("ETCD_ENDPOINTS", "https://etcd-0:2379, https://etcd-1:2379,")
+	t.Setenv("ETCD_REQUEST_TIMEOUT", "2s")
+	t.Setenv("ETCD_USERNAME", "opencnc")
+	t.Setenv("ETCD_PASSWORD", "secret")
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+
+	if !slices.Equal(config.Endpoints, []string{"https://etcd-0:2379", "https://etcd-1:2379"}) {
+		t.Fatalf("unexpected endpoints %v", config.Endpoints)
+	}
+	if config.RequestTimeout != 2*time.Second || config.Username != "opencnc" || config.Password != "secret" {
+		t.Fatalf("unexpected config %+v", config)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]map[string]string{
+		"timeout":          {"ETCD_DIAL_TIMEOUT": "soon"},
+		"password only":    {"ETCD_PASSWORD": "secret"},
+		"cert without key": {"ETCD_CERT_FILE": "/certs/etcd.crt"},
+		"missing CA":       {"ETCD_CA_FILE": "/nonexistent/ca.crt"},
+	}
+
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+			if _, err := ConfigFromEnv(); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package storewrapper
+
+import (
+	"context"
+	"fmt"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var _ Store = (*EtcdStore)(nil)
+
+// EtcdStore is a Store backed by an etcd cluster.
+type EtcdStore struct {
+	client *clientv3.Client
+	config Config
+}
+
+// NewEtcdStore connects to the etcd cluster of the configuration.
+func NewEtcdStore(config Config) (*EtcdStore, error) {
+	if len(config.Endpoints) == 0 {
+		return nil, fmt.Errorf("no etcd endpoint")
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   config.Endpoints,
+		DialTimeout: config.DialTimeout,
+		Username:    config.Username,
+		Password:    config.Password,
+		TLS:         config.TLS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	return &EtcdStore{client: client, config: config}, nil
+}
+
+// Close closes the connection to the cluster.
+func (s *EtcdStore) Close() error {
+	return s.client.Close()
+}
+
+// withTimeout bounds an operation by the request timeout of the store.
+func (s *EtcdStore) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.config.RequestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.config.RequestTimeout)
+}
+
+func (s *EtcdStore) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no value is found, return an error
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	return resp.Kvs[0].Value, nil
+}
+
+func (s *EtcdStore) Put(ctx context.Context, key string, value []byte) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.client.Put(ctx, key, string(value))
+	return err
+}
+
+func (s *EtcdStore) List(ctx context.Context, prefix string) ([]KeyValue, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := s.client.Get(
+		ctx,
+		prefix,
+		clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := make([]KeyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs = append(kvs, KeyValue{Key: string(kv.Key), Value: kv.Value})
+	}
+
+	return kvs, nil
+}
+
+func (s *EtcdStore) Delete(ctx context.Context, key string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.client.Delete(ctx, key)
+	return err
+}
+
+// Watch is bounded by ctx only; the request timeout does not apply.
+func (s *EtcdStore) Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error) {
+	watchChan := s.client.Watch(ctx, prefix, clientv3.WithPrefix())
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		for resp := range watchChan {
+			for _, ev := range resp.Events {
+				event := WatchEvent{
+					Key:     string(ev.Kv.Key),
+					Deleted: ev.Type == clientv3.EventTypeDelete,
+				}
+				if !event.Deleted {
+					event.Value = ev.Kv.Value
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (s *EtcdStore) CompareAndSwap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	cmp := clientv3.Compare(clientv3.Value(key), "=", string(old))
+	if old == nil {
+		cmp = clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	}
+
+	resp, err := s.client.Txn(ctx).
+		If(cmp).
+		Then(clientv3.OpPut(key, string(value))).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Succeeded, nil
+}
@@ -0,0 +1,198 @@
+package storewrapper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var _ Store = (*DirStore)(nil)
+
+// DirStore is a Store keeping each entry in a file of a directory, for
+// deployments without etcd. Writes are atomic, but the directory must not be
+// shared by several processes: compare-and-swap and watches only cover the
+// changes made through the DirStore.
+type DirStore struct {
+	dir string
+
+	mu       sync.Mutex // serializes writes, so compare-and-swap is atomic
+	watchers watchers
+}
+
+// entrySuffix marks the entry files; temporary files of pending writes end
+// in .tmp and are ignored.
+const entrySuffix = ".kv"
+
+// NewDirStore returns a store in dir, created if missing.
+func NewDirStore(dir string) (*DirStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("no store directory")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating store directory: %w", err)
+	}
+
+	return &DirStore{dir: dir}, nil
+}
+
+// path returns the file of the key; keys are escaped into a single file name.
+func (s *DirStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+entrySuffix)
+}
+
+func (s *DirStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (s *DirStore) Put(ctx context.Context, key string, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.put(key, value)
+}
+
+// put writes the key through a renamed temporary file and notifies the
+// watchers, s.mu held.
+func (s *DirStore) put(key string, value []byte) error {
+	tmp, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return err
+	}
+
+	s.watchers.notify(WatchEvent{Key: key, Value: bytes.Clone(value)})
+
+	return nil
+}
+
+func (s *DirStore) List(ctx context.Context, prefix string) ([]KeyValue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var kvs []KeyValue
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), entrySuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		key, err := url.PathUnescape(name)
+		if err != nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		value, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // deleted meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		kvs = append(kvs, KeyValue{Key: key, Value: value})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+
+	return kvs, nil
+}
+
+func (s *DirStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.watchers.notify(WatchEvent{Key: key, Deleted: true})
+
+	return nil
+}
+
+func (s *DirStore) Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error) {
+	return s.watchers.add(ctx, prefix), nil
+}
+
+func (s *DirStore) CompareAndSwap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := os.ReadFile(s.path(key))
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	if (old == nil && exists) || (old != nil && (!exists || !bytes.Equal(current, old))) {
+		return false, nil
+	}
+
+	if err := s.put(key, value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *DirStore) Close() error {
+	return nil
+}
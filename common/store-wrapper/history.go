@@ -0,0 +1,325 @@
+package storewrapper
+
+import (
+	"OpenCNC_config_service/common/structures/config_history"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Layout of the configuration history in the store:
+//
+//	configuration-history/version             last version allocated
+//	configuration-history/versions/<version>  ConfigurationRecord, zero padded so keys sort by version
+//	configuration-history/latest              version of the latest succeeded configuration
+//	configuration-history/nodes/<node>        version of the latest succeeded configuration of the node
+//	configuration-history/snapshots/<version>/<node>  device snapshot of the node at a succeeded version
+//	configuration-history/baselines/<node>    device snapshot of the node before its first configuration
+const (
+	historyVersionUrn   = "configuration-history.version"
+	historyVersionsUrn  = "configuration-history.versions."
+	historyLatestUrn    = "configuration-history.latest"
+	historyNodesUrn     = "configuration-history.nodes."
+	historySnapshotsUrn = "configuration-history.snapshots."
+	historyBaselinesUrn = "configuration-history.baselines."
+)
+
+// ErrNoConfiguration is returned when the history has no succeeded
+// configuration to look up.
+var ErrNoConfiguration = errors.New("no configuration applied")
+
+func historyVersionKey(version uint64) string {
+	return fmt.Sprintf("%s%020d", urnKey(historyVersionsUrn), version)
+}
+
+func historySnapshotKey(version uint64, node string) string {
+	return fmt.Sprintf("%s%020d/%s", urnKey(historySnapshotsUrn), version, urnKey(node))
+}
+
+// RecordConfiguration stores record under the next version of the history
+// and returns that version. A succeeded record becomes the latest
+// configuration of the network and of each of its nodes.
+func RecordConfiguration(ctx context.Context, s Store, record *config_history.ConfigurationRecord) (uint64, error) {
+	if record == nil {
+		return 0, fmt.Errorf("cannot record nil configuration")
+	}
+
+	version, err := nextHistoryVersion(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+
+	record = proto.Clone(record).(*config_history.ConfigurationRecord)
+	record.Version = version
+
+	recordBytes, err := proto.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize configuration record: %w", err)
+	}
+
+	if err := s.Put(ctx, historyVersionKey(version), recordBytes); err != nil {
+		return 0, fmt.Errorf("failed to store configuration version %d: %w", version, err)
+	}
+
+	if record.GetResult() != config_history.ApplyResult_APPLY_RESULT_SUCCEEDED {
+		return version, nil
+	}
+
+	if err := advanceHistoryIndex(ctx, s, urnKey(historyLatestUrn), version); err != nil {
+		return version, err
+	}
+	for _, node := range record.GetNodeIds() {
+		if err := advanceHistoryIndex(ctx, s, urnKey(historyNodesUrn+node), version); err != nil {
+			return version, err
+		}
+	}
+
+	return version, nil
+}
+
+// ListConfigurationHistory returns every recorded configuration, oldest first.
+func ListConfigurationHistory(ctx context.Context, s Store) ([]*config_history.ConfigurationRecord, error) {
+	rawData, err := getFromStoreWithPrefix(ctx, s, historyVersionsUrn)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*config_history.ConfigurationRecord, 0, len(rawData))
+	for _, raw := range rawData {
+		record := &config_history.ConfigurationRecord{}
+		if err := proto.Unmarshal(raw.Value, record); err != nil {
+			return nil, fmt.Errorf("failed unmarshaling configuration record %s: %w", raw.Key, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// GetConfigurationVersion returns the record of a version of the history.
+func GetConfigurationVersion(ctx context.Context, s Store, version uint64) (*config_history.ConfigurationRecord, error) {
+	rawData, err := s.Get(ctx, historyVersionKey(version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve configuration version %d: %w", version, err)
+	}
+
+	record := &config_history.ConfigurationRecord{}
+	if err := proto.Unmarshal(rawData, record); err != nil {
+		return nil, fmt.Errorf("failed to deserialize configuration version %d: %w", version, err)
+	}
+
+	return record, nil
+}
+
+// GetLastConfiguration returns the record of the latest succeeded
+// configuration or rollback, ErrNoConfiguration if none succeeded yet. A
+// rollback carries the configuration it restored, none if it restored the
+// configuration before any.
+func GetLastConfiguration(ctx context.Context, s Store) (*config_history.ConfigurationRecord, error) {
+	return getIndexedConfiguration(ctx, s, urnKey(historyLatestUrn))
+}
+
+// GetLastNodeConfiguration returns the record of the latest succeeded
+// configuration of a node, ErrNoConfiguration if none configured it yet.
+func GetLastNodeConfiguration(ctx context.Context, s Store, node string) (*config_history.ConfigurationRecord, error) {
+	return getIndexedConfiguration(ctx, s, urnKey(historyNodesUrn+node))
+}
+
+func getIndexedConfiguration(ctx context.Context, s Store, key string) (*config_history.ConfigurationRecord, error) {
+	version, _, err := getHistoryVersion(ctx, s, key)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		return nil, ErrNoConfiguration
+	}
+
+	return GetConfigurationVersion(ctx, s, version)
+}
+
+// PreviousConfigurationVersion returns the succeeded version applied steps
+// configurations before the one the network runs. Rollbacks are not steps:
+// after a rollback the network runs the version it restored, so going back
+// again continues from there.
+func PreviousConfigurationVersion(ctx context.Context, s Store, steps int) (uint64, error) {
+	records, err := ListConfigurationHistory(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+
+	effective := effectiveVersion(records, math.MaxUint64)
+	if effective == 0 {
+		return 0, ErrNoConfiguration
+	}
+
+	var applied []uint64 // succeeded versions applying a configuration, oldest first
+	for _, record := range records {
+		if record.GetResult() == config_history.ApplyResult_APPLY_RESULT_SUCCEEDED && !isRollback(record) {
+			applied = append(applied, record.GetVersion())
+		}
+	}
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i] != effective {
+			continue
+		}
+		if steps < 1 || steps > i {
+			return 0, fmt.Errorf("cannot go back %d step(s) from version %d: %d earlier configuration(s) recorded", steps, effective, i)
+		}
+		return applied[i-steps], nil
+	}
+
+	return 0, fmt.Errorf("version %d the network runs is not in the history", effective)
+}
+
+// ConfigurationVersionBefore returns the version whose configuration the
+// network ran before version was applied, following rollbacks; 0 if it ran
+// none yet.
+func ConfigurationVersionBefore(ctx context.Context, s Store, version uint64) (uint64, error) {
+	records, err := ListConfigurationHistory(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+
+	return effectiveVersion(records, version-1), nil
+}
+
+// effectiveVersion returns the version whose configuration the network runs
+// once the records up to head are applied: the latest succeeded one, or the
+// version it restored if that is a rollback. 0 if it runs none.
+func effectiveVersion(records []*config_history.ConfigurationRecord, head uint64) uint64 {
+	succeeded := make(map[uint64]*config_history.ConfigurationRecord)
+
+	var latest uint64
+	for _, record := range records {
+		if record.GetResult() != config_history.ApplyResult_APPLY_RESULT_SUCCEEDED || record.GetVersion() > head {
+			continue
+		}
+		succeeded[record.GetVersion()] = record
+		latest = record.GetVersion()
+	}
+
+	for latest != 0 {
+		record, ok := succeeded[latest]
+		if !ok || !isRollback(record) {
+			return latest
+		}
+		latest = record.GetRolledBackTo()
+	}
+
+	return 0
+}
+
+// isRollback reports whether record restored an earlier configuration
+// instead of applying one.
+func isRollback(record *config_history.ConfigurationRecord) bool {
+	return record.GetRolledBackTo() != 0 || record.GetUndone() != 0
+}
+
+// StoreVersionSnapshot stores the snapshot of the device configuration a node
+// ran once a version was applied, the target of a rollback to that version.
+func StoreVersionSnapshot(ctx context.Context, s Store, version uint64, node string, snapshot []byte) error {
+	if err := s.Put(ctx, historySnapshotKey(version, node), snapshot); err != nil {
+		return fmt.Errorf("failed to store snapshot of %s at version %d: %w", node, version, err)
+	}
+
+	return nil
+}
+
+// GetVersionSnapshot returns the snapshot a node ran at a version.
+func GetVersionSnapshot(ctx context.Context, s Store, version uint64, node string) ([]byte, error) {
+	snapshot, err := s.Get(ctx, historySnapshotKey(version, node))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve snapshot of %s at version %d: %w", node, version, err)
+	}
+
+	return snapshot, nil
+}
+
+// StoreBaselineSnapshot stores the snapshot a node ran before the config
+// service first configured it. The first baseline of a node is kept.
+func StoreBaselineSnapshot(ctx context.Context, s Store, node string, snapshot []byte) error {
+	if _, err := s.CompareAndSwap(ctx, urnKey(historyBaselinesUrn+node), nil, snapshot); err != nil {
+		return fmt.Errorf("failed to store baseline snapshot of %s: %w", node, err)
+	}
+
+	return nil
+}
+
+// GetBaselineSnapshot returns the snapshot a node ran before its first configuration.
+func GetBaselineSnapshot(ctx context.Context, s Store, node string) ([]byte, error) {
+	snapshot, err := s.Get(ctx, urnKey(historyBaselinesUrn+node))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve baseline snapshot of %s: %w", node, err)
+	}
+
+	return snapshot, nil
+}
+
+// nextHistoryVersion allocates the next version of the history. Versions are
+// allocated with compare-and-swap, so concurrent writers never share one.
+func nextHistoryVersion(ctx context.Context, s Store) (uint64, error) {
+	key := urnKey(historyVersionUrn)
+
+	for {
+		current, raw, err := getHistoryVersion(ctx, s, key)
+		if err != nil {
+			return 0, err
+		}
+
+		next := current + 1
+
+		swapped, err := s.CompareAndSwap(ctx, key, raw, []byte(strconv.FormatUint(next, 10)))
+		if err != nil {
+			return 0, fmt.Errorf("failed to allocate configuration version: %w", err)
+		}
+		if swapped {
+			return next, nil
+		}
+	}
+}
+
+// advanceHistoryIndex points the index at version unless it already points
+// at a later one, recorded concurrently.
+func advanceHistoryIndex(ctx context.Context, s Store, key string, version uint64) error {
+	for {
+		current, raw, err := getHistoryVersion(ctx, s, key)
+		if err != nil {
+			return err
+		}
+		if current >= version {
+			return nil
+		}
+
+		swapped, err := s.CompareAndSwap(ctx, key, raw, []byte(strconv.FormatUint(version, 10)))
+		if err != nil {
+			return fmt.Errorf("failed to update configuration index %s: %w", key, err)
+		}
+		if swapped {
+			return nil
+		}
+	}
+}
+
+// getHistoryVersion reads a version stored at key, 0 and a nil raw value if
+// the key does not exist.
+func getHistoryVersion(ctx context.Context, s Store, key string) (uint64, []byte, error) {
+	raw, err := s.Get(ctx, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	version, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid version in %s: %w", key, err)
+	}
+
+	return version, raw, nil
+}
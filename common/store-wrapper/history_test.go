@@ -0,0 +1,101 @@
+package storewrapper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"OpenCNC_config_service/common/structures/config_history"
+)
+
+func TestRecordConfiguration_VersionsAndIndexes(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := GetLastConfiguration(ctx, s); !errors.Is(err, ErrNoConfiguration) {
+				t.Fatalf("expected ErrNoConfiguration, got %v", err)
+			}
+
+			records := []*config_history.ConfigurationRecord{
+				{Result: config_history.ApplyResult_APPLY_RESULT_SUCCEEDED, NodeIds: []string{"sw1", "sw2"}},
+				{Result: config_history.ApplyResult_APPLY_RESULT_SUCCEEDED, NodeIds: []string{"sw1"}},
+				{Result: config_history.ApplyResult_APPLY_RESULT_FAILED, NodeIds: []string{"sw1", "sw2"}},
+			}
+			for i, record := range records {
+				version, err := RecordConfiguration(ctx, s, record)
+				if err != nil {
+					t.Fatalf("RecordConfiguration failed: %v", err)
+				}
+				if version != uint64(i+1) {
+					t.Fatalf("expected version %d, got %d", i+1, version)
+				}
+			}
+
+			history, err := ListConfigurationHistory(ctx, s)
+			if err != nil || len(history) != 3 {
+				t.Fatalf("expected 3 records, got %v, %v", history, err)
+			}
+			for i, record := range history {
+				if record.GetVersion() != uint64(i+1) {
+					t.Fatalf("expected the history ordered by version, got %v", history)
+				}
+			}
+
+			last, err := GetLastConfiguration(ctx, s)
+			if err != nil || last.GetVersion() != 2 {
+				t.Fatalf("expected the latest succeeded version 2, got %v, %v", last, err)
+			}
+
+			sw2, err := GetLastNodeConfiguration(ctx, s, "sw2")
+			if err != nil || sw2.GetVersion() != 1 {
+				t.Fatalf("expected version 1 as the latest of sw2, got %v, %v", sw2, err)
+			}
+
+			if _, err := GetConfigurationVersion(ctx, s, 4); !errors.Is(err, ErrKeyNotFound) {
+				t.Fatalf("expected ErrKeyNotFound for an unknown version, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRecordConfiguration_ConcurrentVersionsAreUnique(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	const writers = 16
+
+	versions := make([]uint64, writers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			version, err := RecordConfiguration(ctx, s, &config_history.ConfigurationRecord{
+				Result:  config_history.ApplyResult_APPLY_RESULT_SUCCEEDED,
+				NodeIds: []string{"sw1"},
+			})
+			if err != nil {
+				t.Errorf("RecordConfiguration failed: %v", err)
+			}
+			versions[i] = version
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]bool)
+	for _, version := range versions {
+		if version == 0 || seen[version] {
+			t.Fatalf("expected unique versions, got %v", versions)
+		}
+		seen[version] = true
+	}
+
+	last, err := GetLastNodeConfiguration(ctx, s, "sw1")
+	if err != nil || last.GetVersion() != writers {
+		t.Fatalf("expected the index at version %d, got %v, %v", writers, last, err)
+	}
+}
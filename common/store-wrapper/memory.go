@@ -0,0 +1,175 @@
+package storewrapper
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is a Store holding its entries for the lifetime of the process,
+// for tests and single instance deployments.
+type MemoryStore struct {
+	mu       sync.Mutex
+	entries  map[string][]byte
+	watchers watchers
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	return bytes.Clone(value), nil
+}
+
+func (s *MemoryStore) Put(ctx context.Context, key string, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(key, value)
+
+	return nil
+}
+
+// put sets the key and notifies the watchers, s.mu held.
+func (s *MemoryStore) put(key string, value []byte) {
+	s.entries[key] = bytes.Clone(value)
+	s.watchers.notify(WatchEvent{Key: key, Value: bytes.Clone(value)})
+}
+
+func (s *MemoryStore) List(ctx context.Context, prefix string) ([]KeyValue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kvs []KeyValue
+	for key, value := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			kvs = append(kvs, KeyValue{Key: key, Value: bytes.Clone(value)})
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+
+	return kvs, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[key]; ok {
+		delete(s.entries, key)
+		s.watchers.notify(WatchEvent{Key: key, Deleted: true})
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error) {
+	return s.watchers.add(ctx, prefix), nil
+}
+
+func (s *MemoryStore) CompareAndSwap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.entries[key]
+	if (old == nil && ok) || (old != nil && (!ok || !bytes.Equal(current, old))) {
+		return false, nil
+	}
+
+	s.put(key, value)
+
+	return true, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// watchers fans the changes of an in-process store out to its watchers.
+type watchers struct {
+	mu   sync.Mutex
+	subs []*watcher
+}
+
+type watcher struct {
+	prefix string
+	ctx    context.Context
+	events chan WatchEvent
+}
+
+// add registers a watcher, removed and closed when ctx is done.
+func (w *watchers) add(ctx context.Context, prefix string) <-chan WatchEvent {
+	sub := &watcher{prefix: prefix, ctx: ctx, events: make(chan WatchEvent, 64)}
+
+	w.mu.Lock()
+	w.subs = append(w.subs, sub)
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		for i, s := range w.subs {
+			if s == sub {
+				w.subs = append(w.subs[:i], w.subs[i+1:]...)
+				break
+			}
+		}
+		close(sub.events)
+	}()
+
+	return sub.events
+}
+
+// notify delivers the event to the watchers of its key. A watcher that does
+// not keep up blocks the store until it does or its context is done.
+func (w *watchers) notify(event WatchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sub := range w.subs {
+		if !strings.HasPrefix(event.Key, sub.prefix) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		case <-sub.ctx.Done():
+		}
+	}
+}
@@ -23,7 +23,10 @@ import (
 	moduleregistry "OpenCNC_config_service/common/structures/module-registry"
 	"OpenCNC_config_service/common/structures/topology"
 	"OpenCNC_config_service/common/structures/topology_config"
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 	"google.golang.org/protobuf/proto"
@@ -32,12 +35,12 @@ import (
 var log = logger.GetLogger()
 
 // Changed from model plugin to device model registry
-func GetDeviceModelRegistry() (*devicemodelregistry.DeviceModelRegistry, error) {
+func GetDeviceModelRegistry(ctx context.Context, s Store) (*devicemodelregistry.DeviceModelRegistry, error) {
 
 	// Build the prefix for the request data
 	prefix := "device-models."
 
-	rawData, err := getFromStoreWithPrefix(prefix)
+	rawData, err := getFromStoreWithPrefix(ctx, s, prefix)
 	if err != nil {
 		log.Errorf("Failed getting device model from store: %v", err)
 		return &devicemodelregistry.DeviceModelRegistry{}, err
@@ -45,9 +48,9 @@ func GetDeviceModelRegistry() (*devicemodelregistry.DeviceModelRegistry, error)
 
 	var dregistry = &devicemodelregistry.DeviceModelRegistry{}
 
-	for _, model := range rawData.Kvs {
+	for _, model := range rawData {
 		var dmodel = &devicemodelregistry.DeviceModel{}
-		if err = proto.Unmarshal([]byte(model.Value), dmodel); err != nil {
+		if err = proto.Unmarshal(model.Value, dmodel); err != nil {
 			log.Errorf("Failed unmarshaling device model: %v", err)
 			return &devicemodelregistry.DeviceModelRegistry{}, err
 		}
@@ -57,12 +60,12 @@ func GetDeviceModelRegistry() (*devicemodelregistry.DeviceModelRegistry, error)
 	return dregistry, nil
 }
 
-func GetDeviceModel(name string) (*devicemodelregistry.DeviceModel, error) {
+func GetDeviceModel(ctx context.Context, s Store, name string) (*devicemodelregistry.DeviceModel, error) {
 	// Build the URN for the request data
 	urn := "device-models." + name
 
 	// Send request to specific path in k/v store "device-models"
-	rawData, err := GetFromStore(urn)
+	rawData, err := GetFromStore(ctx, s, urn)
 	if err != nil {
 		log.Errorf("Failed getting request data from store: %v", err)
 		return &devicemodelregistry.DeviceModel{}, err
@@ -76,26 +79,44 @@ func GetDeviceModel(name string) (*devicemodelregistry.DeviceModel, error) {
 	return model, nil
 }
 
-func GetTopology() (*topology.Topology, error) {
+func GetTopology(ctx context.Context, s Store) (*topology.Topology, error) {
 	var topo = &topology.Topology{}
 
-	endnodes := getNodes("endnodes")
-	bridges := getNodes("bridges")
+	endnodes, err := getNodes(ctx, s, "endnodes")
+	if err != nil {
+		return nil, err
+	}
+	bridges, err := getNodes(ctx, s, "bridges")
+	if err != nil {
+		return nil, err
+	}
 
 	topo.Nodes = append(endnodes, bridges...)
 
-	links := getLinks("links")
+	links, err := getLinks(ctx, s, "links")
+	if err != nil {
+		return nil, err
+	}
 
 	topo.Links = append(topo.Links, links...)
 
 	return topo, nil
 }
 
-func GetModuleRegistry() (*moduleregistry.ModuleRegistry, error) {
+// TopologyNodes lists the nodes of the stored topology.
+func TopologyNodes(ctx context.Context, s Store) ([]*topology.Node, error) {
+	topo, err := GetTopology(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return topo.GetNodes(), nil
+}
+
+func GetModuleRegistry(ctx context.Context, s Store) (*moduleregistry.ModuleRegistry, error) {
 	// Build the URN for the request data
 	urn := "yang-modules."
 
-	rawData, err := GetFromStore(urn)
+	rawData, err := GetFromStore(ctx, s, urn)
 	if err != nil {
 		log.Errorf("Failed getting request data from store: %v", err)
 		return &moduleregistry.ModuleRegistry{}, err
@@ -109,12 +130,12 @@ func GetModuleRegistry() (*moduleregistry.ModuleRegistry, error) {
 	return mregistry, nil
 }
 
-func GetConfiguration(confId string) (*topology_config.TopologyConfig, error) {
+func GetConfiguration(ctx context.Context, s Store, confId string) (*topology_config.TopologyConfig, error) {
 	// this requires all configurations in the store to be normilized to topology_config.TopologyConfig,
 	//  otherwise it will fail to unmarshal
 	urn := "configurations." + confId
 
-	rawConf, err := GetFromStore(urn)
+	rawConf, err := GetFromStore(ctx, s, urn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve configuration %s: %v", confId, err)
 	}
@@ -129,32 +150,7 @@ func GetConfiguration(confId string) (*topology_config.TopologyConfig, error) {
 	return &config, nil
 }
 
-/*
-	func GetLastConfiguration() (*schedule.GclConfiguration, string, error) {
-		const prefix = "configurations.tsn-configuration"
-
-		resp, err := getLastFromStoreWithPrefix(prefix)
-		if err != nil {
-			return nil, "", err
-		}
-		if len(resp.Kvs) == 0 {
-			return nil, "", fmt.Errorf("no configurations found")
-		}
-
-		kv := resp.Kvs[0]
-
-		// Convert the key back to your confId (if needed)
-		confId := path.Base(string(kv.Key)) // e.g. "4d8f7c72-f9e3-4d33-8210-3a5437b8a821"
-
-		var config schedule.GclConfiguration
-		if err := proto.Unmarshal(kv.Value, &config); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal last config: %v", err)
-		}
-
-		return &config, confId, nil
-	}
-*/
-func StoreConfiguration(cfg *topology_config.TopologyConfig) error {
+func StoreConfiguration(ctx context.Context, s Store, cfg *topology_config.TopologyConfig) error {
 	if cfg == nil {
 		return fmt.Errorf("cannot store nil configuration")
 	}
@@ -165,6 +161,8 @@ func StoreConfiguration(cfg *topology_config.TopologyConfig) error {
 	}
 
 	err = SendToStore(
+		ctx,
+		s,
 		configBytes,
 		"configurations."+cfg.GetConfigId(),
 	)
@@ -174,3 +172,35 @@ func StoreConfiguration(cfg *topology_config.TopologyConfig) error {
 
 	return nil
 }
+
+// GetHostKeys returns the SSH host key fingerprints pinned for a node, or
+// none if the node has no pinned key yet.
+func GetHostKeys(ctx context.Context, s Store, node string) ([]string, error) {
+	urn := "known-hosts." + node
+
+	rawData, err := GetFromStore(ctx, s, urn)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve host keys of %s: %w", node, err)
+	}
+
+	return strings.Fields(string(rawData)), nil
+}
+
+// StoreHostKeys pins the SSH host key fingerprints of a node, replacing the
+// previous ones.
+func StoreHostKeys(ctx context.Context, s Store, node string, fingerprints []string) error {
+	err := SendToStore(
+		ctx,
+		s,
+		[]byte(strings.Join(fingerprints, "\n")),
+		"known-hosts."+node,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store host keys of %s: %w", node, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package storewrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"OpenCNC_config_service/common/structures/topology"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// stores returns the implementations that run without external services.
+func stores(t *testing.T) map[string]Store {
+	dir, err := NewDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirStore failed: %v", err)
+	}
+
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"dir":    dir,
+	}
+}
+
+func TestStore_GetPutListDelete(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.Get(ctx, "bridges/sw1"); !errors.Is(err, ErrKeyNotFound) {
+				t.Fatalf("expected ErrKeyNotFound, got %v", err)
+			}
+
+			for _, key := range []string{"bridges/sw2", "bridges/sw1", "links/l1"} {
+				if err := s.Put(ctx, key, []byte(key)); err != nil {
+					t.Fatalf("Put %s failed: %v", key, err)
+				}
+			}
+
+			value, err := s.Get(ctx, "bridges/sw1")
+			if err != nil || string(value) != "bridges/sw1" {
+				t.Fatalf("Get returned %q, %v", value, err)
+			}
+
+			kvs, err := s.List(ctx, "bridges/")
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(kvs) != 2 || kvs[0].Key != "bridges/sw1" || kvs[1].Key != "bridges/sw2" {
+				t.Fatalf("expected the bridges sorted by key, got %v", kvs)
+			}
+
+			if err := s.Delete(ctx, "bridges/sw1"); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if err := s.Delete(ctx, "bridges/sw1"); err != nil {
+				t.Fatalf("Delete of a missing key failed: %v", err)
+			}
+			if _, err := s.Get(ctx, "bridges/sw1"); !errors.Is(err, ErrKeyNotFound) {
+				t.Fatalf("expected the key deleted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_CompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			swapped, err := s.CompareAndSwap(ctx, "lock", nil, []byte("a"))
+			if err != nil || !swapped {
+				t.Fatalf("expected the missing key created, got %t, %v", swapped, err)
+			}
+
+			if swapped, _ := s.CompareAndSwap(ctx, "lock", nil, []byte("b")); swapped {
+				t.Fatalf("created a key that exists")
+			}
+			if swapped, _ := s.CompareAndSwap(ctx, "lock", []byte("b"), []byte("c")); swapped {
+				t.Fatalf("swapped a key holding another value")
+			}
+
+			swapped, err = s.CompareAndSwap(ctx, "lock", []byte("a"), []byte("b"))
+			if err != nil || !swapped {
+				t.Fatalf("expected the key swapped, got %t, %v", swapped, err)
+			}
+
+			if value, _ := s.Get(ctx, "lock"); string(value) != "b" {
+				t.Fatalf("expected b, got %q", value)
+			}
+		})
+	}
+}
+
+func TestStore_Watch(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			events, err := s.Watch(ctx, "bridges/")
+			if err != nil {
+				t.Fatalf("Watch failed: %v", err)
+			}
+
+			_ = s.Put(ctx, "links/l1", []byte("ignored"))
+			_ = s.Put(ctx, "bridges/sw1", []byte("v1"))
+			_ = s.Delete(ctx, "bridges/sw1")
+
+			want := []WatchEvent{{Key: "bridges/sw1", Value: []byte("v1")}, {Key: "bridges/sw1", Deleted: true}}
+			for _, w := range want {
+				select {
+				case ev := <-events:
+					if ev.Key != w.Key || ev.Deleted != w.Deleted || string(ev.Value) != string(w.Value) {
+						t.Fatalf("expected %+v, got %+v", w, ev)
+					}
+				case <-ctx.Done():
+					t.Fatalf("no event for %+v", w)
+				}
+			}
+
+			cancel()
+			for range events {
+			}
+		})
+	}
+}
+
+func TestGetTopology(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	for urn, msg := range map[string]proto.Message{
+		"bridges.sw1":  &topology.Node{Name: "sw1"},
+		"endnodes.es1": &topology.Node{Name: "es1"},
+		"links.l1":     &topology.Link{Id: "l1"},
+	} {
+		raw, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if err := SendToStore(ctx, s, raw, urn); err != nil {
+			t.Fatalf("SendToStore failed: %v", err)
+		}
+	}
+
+	topo, err := GetTopology(ctx, s)
+	if err != nil {
+		t.Fatalf("GetTopology failed: %v", err)
+	}
+
+	if len(topo.GetNodes()) != 2 || topo.GetNodes()[0].GetName() != "es1" || len(topo.GetLinks()) != 1 {
+		t.Fatalf("unexpected topology %v", topo)
+	}
+}
+
+func TestGetTopology_CorruptNode(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	_ = SendToStore(ctx, s, []byte{0xff}, "bridges.sw1")
+
+	if _, err := GetTopology(ctx, s); err == nil {
+		t.Fatalf("expected an error for an undecodable node")
+	}
+}
@@ -0,0 +1,93 @@
+package storewrapper
+
+import (
+	"OpenCNC_config_service/common/structures/transaction"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const lastTransactionUrn = "transactions.last"
+
+// StoreLastTransaction persists the state of the last configuration
+// transaction, replacing the previous one.
+func StoreLastTransaction(ctx context.Context, s Store, state *transaction.TransactionState) error {
+	if state == nil {
+		return fmt.Errorf("cannot store nil transaction state")
+	}
+
+	stateBytes, err := proto.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize transaction state: %w", err)
+	}
+
+	if err := SendToStore(ctx, s, stateBytes, lastTransactionUrn); err != nil {
+		return fmt.Errorf("failed to store transaction %s: %w", state.GetConfigId(), err)
+	}
+
+	return nil
+}
+
+// GetLastTransaction returns the state of the last configuration
+// transaction, nil if none was stored yet.
+func GetLastTransaction(ctx context.Context, s Store) (*transaction.TransactionState, error) {
+	rawData, err := GetFromStore(ctx, s, lastTransactionUrn)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve last transaction: %w", err)
+	}
+
+	state := &transaction.TransactionState{}
+	if err := proto.Unmarshal(rawData, state); err != nil {
+		return nil, fmt.Errorf("failed to deserialize last transaction: %w", err)
+	}
+
+	return state, nil
+}
+
+// StoreNodeSnapshots persists the snapshots a backend keeps of a node.
+func StoreNodeSnapshots(ctx context.Context, s Store, backend string, snapshots *transaction.NodeSnapshots) error {
+	if snapshots == nil {
+		return fmt.Errorf("cannot store nil snapshots")
+	}
+
+	snapshotBytes, err := proto.Marshal(snapshots)
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshots of %s: %w", snapshots.GetNodeId(), err)
+	}
+
+	err = SendToStore(
+		ctx,
+		s,
+		snapshotBytes,
+		"snapshots."+backend+"."+snapshots.GetNodeId(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store snapshots of %s: %w", snapshots.GetNodeId(), err)
+	}
+
+	return nil
+}
+
+// GetNodeSnapshots returns the snapshots a backend persisted for all its nodes.
+func GetNodeSnapshots(ctx context.Context, s Store, backend string) ([]*transaction.NodeSnapshots, error) {
+	rawData, err := getFromStoreWithPrefix(ctx, s, "snapshots."+backend+".")
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]*transaction.NodeSnapshots, 0, len(rawData))
+	for _, raw := range rawData {
+		snapshots := &transaction.NodeSnapshots{}
+		if err := proto.Unmarshal(raw.Value, snapshots); err != nil {
+			return nil, fmt.Errorf("failed unmarshaling snapshots %s: %w", raw.Key, err)
+		}
+		all = append(all, snapshots)
+	}
+
+	return all, nil
+}
@@ -3,62 +3,63 @@ package storewrapper
 import (
 	"OpenCNC_config_service/common/structures/topology"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
-	"time"
 
-	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/protobuf/proto"
 )
 
-// createEtcdClient creates and returns an etcd client
-func createEtcdClient() (*clientv3.Client, error) {
-	// Initialize the etcd client with provided configuration
-	client, err := clientv3.New(clientv3.Config{
-		Endpoints:   []string{"http://127.0.0.1:2379"}, // List of etcd endpoints
-		DialTimeout: 10 * time.Second,                  // Timeout for the dial
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create etcd client: %v", err)
-	}
+// ErrKeyNotFound is returned by Get when the key does not exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+// Store is a k/v store holding the topology, device models and
+// configurations shared by the services. Keys are '/' separated paths.
+// Implementations are safe for concurrent use.
+type Store interface {
+	// Get returns the value of the key, ErrKeyNotFound if it does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, value []byte) error
+	// List returns the entries whose key starts with prefix, sorted by key.
+	List(ctx context.Context, prefix string) ([]KeyValue, error)
+	// Delete removes the key; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Watch reports the changes of the keys starting with prefix until ctx
+	// is done, when the channel is closed.
+	Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error)
+	// CompareAndSwap sets the key to value if it holds old, or does not
+	// exist for a nil old, and reports whether it did.
+	CompareAndSwap(ctx context.Context, key string, old, value []byte) (bool, error)
+	Close() error
+}
 
-	// Return the created etcd client
-	return client, nil
+// KeyValue is an entry of a Store.
+type KeyValue struct {
+	Key   string
+	Value []byte
 }
 
-// Takes in an object as a byte slice, a URN in the format of "storeName.Resource",
-// //and stores the structure at the URN
-func SendToStore(obj []byte, urn string) error {
-	// Connect to ETCD
-	client, err := createEtcdClient()
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer client.Close()
+// WatchEvent is a change of a watched key. Value is nil for deletions.
+type WatchEvent struct {
+	Key     string
+	Value   []byte
+	Deleted bool
+}
 
+// urnKey turns a URN in the format of "storeName.Resource" into a store key.
+func urnKey(urn string) string {
 	// Replace all dots with slashes
-	urn = strings.ReplaceAll(urn, ".", "/")
-
-	// Put the object into etcd
-	_, err = client.Put(context.Background(), urn, string(obj))
-	if err != nil {
-		log.Infof("Failed storing resource \"%s\": %v", urn, err)
-		return err
-	}
-
-	return nil
+	return strings.ReplaceAll(urn, ".", "/")
 }
 
-// Used when storing repeatedly like links and nodes
-func sendToStoreRepeated(client *clientv3.Client, obj []byte, urn string) error {
-	// Replace all dots with slashes
-	urn = strings.ReplaceAll(urn, ".", "/")
+// Takes in an object as a byte slice, a URN in the format of "storeName.Resource",
+// //and stores the structure at the URN
+func SendToStore(ctx context.Context, s Store, obj []byte, urn string) error {
+	key := urnKey(urn)
 
-	// Put the object into etcd
-	_, err := client.Put(context.Background(), urn, string(obj))
-	if err != nil {
-		log.Infof("Failed storing resource \"%s\": %v", urn, err)
+	// Put the object into the store
+	if err := s.Put(ctx, key, obj); err != nil {
+		log.Infof("Failed storing resource \"%s\": %v", key, err)
 		return err
 	}
 
@@ -66,151 +67,95 @@ func sendToStoreRepeated(client *clientv3.Client, obj []byte, urn string) error
 }
 
 // Get any data from a k/v store
-func GetFromStore(urn string) ([]byte, error) {
-	// Connect to ETCD
-	client, err := createEtcdClient()
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer client.Close()
-
-	// Create a context with a timeout to prevent indefinite blocking
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Replace all dots with slashes
-	urn = strings.ReplaceAll(urn, ".", "/")
+func GetFromStore(ctx context.Context, s Store, urn string) ([]byte, error) {
+	key := urnKey(urn)
 
-	// Get the object from etcd store
-	resp, err := client.Get(ctx, urn)
-	if err != nil {
-		log.Infof("Failed getting resource \"%s\": %v", urn, err)
-		return nil, err
-	}
-
-	// If no value is found, return an error
-	if len(resp.Kvs) == 0 {
-		return nil, fmt.Errorf("key not found: %s", urn)
+	// Get the object from the store
+	value, err := s.Get(ctx, key)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		log.Infof("Failed getting resource \"%s\": %v", key, err)
 	}
 
-	// Return the value of the key
-	return resp.Kvs[0].Value, nil
+	return value, err
 }
 
 // Get any data from a k/v store
-func getFromStoreWithPrefix(prefix string) (*clientv3.GetResponse, error) {
-	// Connect to ETCD
-	client, err := createEtcdClient()
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer client.Close()
-
-	// Replace all dots with slashes
-	prefix = strings.ReplaceAll(prefix, ".", "/")
-
-	resp, err := client.Get(context.Background(), prefix, clientv3.WithPrefix())
+func getFromStoreWithPrefix(ctx context.Context, s Store, prefix string) ([]KeyValue, error) {
+	prefix = urnKey(prefix)
 
+	kvs, err := s.List(ctx, prefix)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get data with prefix %s: %v", prefix, err)
 	}
 
-	// Return the value of the key
-	return resp, nil
-}
-
-// Get the most recently created entry under a prefix
-func getLastFromStoreWithPrefix(prefix string) (*clientv3.GetResponse, error) {
-	// Connect to ETCD
-	client, err := createEtcdClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create etcd client: %v", err)
-	}
-	defer client.Close()
-
-	// Replace all dots with slashes to match your storage scheme
-	prefix = strings.ReplaceAll(prefix, ".", "/")
-
-	// Query etcd: sort by creation revision, descending, limit to 1
-	resp, err := client.Get(
-		context.Background(),
-		prefix,
-		clientv3.WithPrefix(),
-		clientv3.WithSort(clientv3.SortByCreateRevision, clientv3.SortDescend),
-		clientv3.WithLimit(1),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get last entry for prefix %s: %v", prefix, err)
-	}
-
-	return resp, nil
+	return kvs, nil
 }
 
-func getLinks(prefix string) []*topology.Link {
+func getLinks(ctx context.Context, s Store, prefix string) ([]*topology.Link, error) {
 	var links []*topology.Link
 
-	rawData, err := getFromStoreWithPrefix(prefix)
+	rawData, err := getFromStoreWithPrefix(ctx, s, prefix)
 	if err != nil {
-		log.Errorf("Failed getting links from store: %v", err)
-		return links
+		return nil, fmt.Errorf("failed getting links from store: %w", err)
 	}
 
-	for _, rawLink := range rawData.Kvs {
+	for _, rawLink := range rawData {
 		link := &topology.Link{}
 
-		if err = proto.Unmarshal([]byte(rawLink.Value), link); err != nil {
-			log.Errorf("Failed unmarshaling link: %v", err)
-			return links
+		if err = proto.Unmarshal(rawLink.Value, link); err != nil {
+			return nil, fmt.Errorf("failed unmarshaling link %s: %w", rawLink.Key, err)
 		}
 		links = append(links, link)
 	}
-	return links
+	return links, nil
 }
 
-func getNodes(prefix string) []*topology.Node {
+func getNodes(ctx context.Context, s Store, prefix string) ([]*topology.Node, error) {
 	var nodes []*topology.Node
 
-	rawData, err := getFromStoreWithPrefix(prefix)
+	rawData, err := getFromStoreWithPrefix(ctx, s, prefix)
 	if err != nil {
-		log.Errorf("Failed getting nodes from store: %v", err)
-		return nodes
+		return nil, fmt.Errorf("failed getting nodes from store: %w", err)
 	}
 
-	for _, rawNode := range rawData.Kvs {
+	for _, rawNode := range rawData {
 		node := &topology.Node{}
 
-		if err = proto.Unmarshal([]byte(rawNode.Value), node); err != nil {
-			log.Errorf("Failed unmarshaling node: %v", err)
-			return nodes
+		if err = proto.Unmarshal(rawNode.Value, node); err != nil {
+			return nil, fmt.Errorf("failed unmarshaling node %s: %w", rawNode.Key, err)
 		}
 		nodes = append(nodes, node)
 	}
-	return nodes
+	return nodes, nil
 }
 
-// WatchKeys watches for changes on keys with a given prefix and calls the callback on new keys
-func WatchKeys(prefix string, callback func(outerKey, innerKey, value string), client *clientv3.Client) {
-	watcher := clientv3.NewWatcher(client)
-	defer watcher.Close()
-
+// WatchKeys watches for changes on keys with a given prefix and calls the
+// callback on new keys, until ctx is done.
+func WatchKeys(ctx context.Context, s Store, prefix string, callback func(outerKey, innerKey, value string)) error {
 	// Watch for changes to keys with the provided prefix
-	watchChan := watcher.Watch(context.Background(), prefix, clientv3.WithPrefix())
+	events, err := s.Watch(ctx, prefix)
+	if err != nil {
+		return err
+	}
 
 	// Handle incoming watch events
-	for resp := range watchChan {
-		for _, ev := range resp.Events {
-			// Process only EventTypePut (added or updated keys)
-			if ev.Type == clientv3.EventTypePut {
-				// Split the key into outer and inner parts (this assumes keys are in a "outer/inner" format)
-				keyParts := splitKey(string(ev.Kv.Key))
-				outerKey := keyParts[0]
-				innerKey := keyParts[1]
-
-				// Call the callback function when a new key is added
-				callback(outerKey, innerKey, string(ev.Kv.Value))
-			}
+	for ev := range events {
+		// Process only puts (added or updated keys)
+		if ev.Deleted {
+			continue
+		}
+
+		// Split the key into outer and inner parts (this assumes keys are in a "outer/inner" format)
+		keyParts := splitKey(ev.Key)
+		if len(keyParts) < 2 {
+			continue
 		}
+
+		// Call the callback function when a new key is added
+		callback(keyParts[0], keyParts[1], string(ev.Value))
 	}
+
+	return nil
 }
 
 // Helper function to split the key (assuming it's in "outer/inner" format)
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/ats/ats.proto
+
+package ats
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Group of schedulers sharing a maximum residence time (8.6.11.3)
+type AtsSchedulerGroup struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	GroupId            uint32                 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                                      // scheduler-group-instance-id, unique per bridge
+	MaxResidenceTimeNs uint64                 `protobuf:"varint,2,opt,name=max_residence_time_ns,json=maxResidenceTimeNs,proto3" json:"max_residence_time_ns,omitempty"` // Frames held longer are discarded
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                                              // Optional notes or comments
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AtsSchedulerGroup) Reset() {
+	*x = AtsSchedulerGroup{}
+	mi := &file_common_structures_ats_ats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AtsSchedulerGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtsSchedulerGroup) ProtoMessage() {}
+
+func (x *AtsSchedulerGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_ats_ats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtsSchedulerGroup.ProtoReflect.Descriptor instead.
+func (*AtsSchedulerGroup) Descriptor() ([]byte, []int) {
+	return file_common_structures_ats_ats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AtsSchedulerGroup) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *AtsSchedulerGroup) GetMaxResidenceTimeNs() uint64 {
+	if x != nil {
+		return x.MaxResidenceTimeNs
+	}
+	return 0
+}
+
+func (x *AtsSchedulerGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Token-bucket shaper of a stream (8.6.11.2)
+type AtsScheduler struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	SchedulerId              uint32                 `protobuf:"varint,1,opt,name=scheduler_id,json=schedulerId,proto3" json:"scheduler_id,omitempty"`                                          // scheduler-instance-id, unique per bridge
+	GroupId                  uint32                 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                                                      // Reference to AtsSchedulerGroup
+	CommittedInformationRate uint64                 `protobuf:"varint,3,opt,name=committed_information_rate,json=committedInformationRate,proto3" json:"committed_information_rate,omitempty"` // CIR, token rate (bps)
+	CommittedBurstSize       uint32                 `protobuf:"varint,4,opt,name=committed_burst_size,json=committedBurstSize,proto3" json:"committed_burst_size,omitempty"`                   // CBS, bucket size (bits)
+	Description              string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                                                              // Optional notes or comments
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AtsScheduler) Reset() {
+	*x = AtsScheduler{}
+	mi := &file_common_structures_ats_ats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AtsScheduler) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtsScheduler) ProtoMessage() {}
+
+func (x *AtsScheduler) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_ats_ats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtsScheduler.ProtoReflect.Descriptor instead.
+func (*AtsScheduler) Descriptor() ([]byte, []int) {
+	return file_common_structures_ats_ats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AtsScheduler) GetSchedulerId() uint32 {
+	if x != nil {
+		return x.SchedulerId
+	}
+	return 0
+}
+
+func (x *AtsScheduler) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *AtsScheduler) GetCommittedInformationRate() uint64 {
+	if x != nil {
+		return x.CommittedInformationRate
+	}
+	return 0
+}
+
+func (x *AtsScheduler) GetCommittedBurstSize() uint32 {
+	if x != nil {
+		return x.CommittedBurstSize
+	}
+	return 0
+}
+
+func (x *AtsScheduler) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ATS configuration of a bridge: the schedulers with the groups they
+// reference by id.
+type AtsConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SchedulerGroups []*AtsSchedulerGroup   `protobuf:"bytes,1,rep,name=scheduler_groups,json=schedulerGroups,proto3" json:"scheduler_groups,omitempty"`
+	Schedulers      []*AtsScheduler        `protobuf:"bytes,2,rep,name=schedulers,proto3" json:"schedulers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AtsConfig) Reset() {
+	*x = AtsConfig{}
+	mi := &file_common_structures_ats_ats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AtsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtsConfig) ProtoMessage() {}
+
+func (x *AtsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_ats_ats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtsConfig.ProtoReflect.Descriptor instead.
+func (*AtsConfig) Descriptor() ([]byte, []int) {
+	return file_common_structures_ats_ats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AtsConfig) GetSchedulerGroups() []*AtsSchedulerGroup {
+	if x != nil {
+		return x.SchedulerGroups
+	}
+	return nil
+}
+
+func (x *AtsConfig) GetSchedulers() []*AtsScheduler {
+	if x != nil {
+		return x.Schedulers
+	}
+	return nil
+}
+
+var File_common_structures_ats_ats_proto protoreflect.FileDescriptor
+
+const file_common_structures_ats_ats_proto_rawDesc = "" +
+	"\n" +
+	"\x1fcommon/structures/ats/ats.proto\x12\x03ats\"\x83\x01\n" +
+	"\x11AtsSchedulerGroup\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\rR\agroupId\x121\n" +
+	"\x15max_residence_time_ns\x18\x02 \x01(\x04R\x12maxResidenceTimeNs\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xde\x01\n" +
+	"\fAtsScheduler\x12!\n" +
+	"\fscheduler_id\x18\x01 \x01(\rR\vschedulerId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\rR\agroupId\x12<\n" +
+	"\x1acommitted_information_rate\x18\x03 \x01(\x04R\x18committedInformationRate\x120\n" +
+	"\x14committed_burst_size\x18\x04 \x01(\rR\x12committedBurstSize\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\x81\x01\n" +
+	"\tAtsConfig\x12A\n" +
+	"\x10scheduler_groups\x18\x01 \x03(\v2\x16.ats.AtsSchedulerGroupR\x0fschedulerGroups\x121\n" +
+	"\n" +
+	"schedulers\x18\x02 \x03(\v2\x11.ats.AtsSchedulerR\n" +
+	"schedulersB2Z0OpenCNC_config_service/common/structures/ats;atsb\x06proto3"
+
+var (
+	file_common_structures_ats_ats_proto_rawDescOnce sync.Once
+	file_common_structures_ats_ats_proto_rawDescData []byte
+)
+
+func file_common_structures_ats_ats_proto_rawDescGZIP() []byte {
+	file_common_structures_ats_ats_proto_rawDescOnce.Do(func() {
+		file_common_structures_ats_ats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_ats_ats_proto_rawDesc), len(file_common_structures_ats_ats_proto_rawDesc)))
+	})
+	return file_common_structures_ats_ats_proto_rawDescData
+}
+
+var file_common_structures_ats_ats_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_structures_ats_ats_proto_goTypes = []any{
+	(*AtsSchedulerGroup)(nil), // 0: ats.AtsSchedulerGroup
+	(*AtsScheduler)(nil),      // 1: ats.AtsScheduler
+	(*AtsConfig)(nil),         // 2: ats.AtsConfig
+}
+var file_common_structures_ats_ats_proto_depIdxs = []int32{
+	0, // 0: ats.AtsConfig.scheduler_groups:type_name -> ats.AtsSchedulerGroup
+	1, // 1: ats.AtsConfig.schedulers:type_name -> ats.AtsScheduler
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_ats_ats_proto_init() }
+func file_common_structures_ats_ats_proto_init() {
+	if File_common_structures_ats_ats_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_ats_ats_proto_rawDesc), len(file_common_structures_ats_ats_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_ats_ats_proto_goTypes,
+		DependencyIndexes: file_common_structures_ats_ats_proto_depIdxs,
+		MessageInfos:      file_common_structures_ats_ats_proto_msgTypes,
+	}.Build()
+	File_common_structures_ats_ats_proto = out.File
+	file_common_structures_ats_ats_proto_goTypes = nil
+	file_common_structures_ats_ats_proto_depIdxs = nil
+}
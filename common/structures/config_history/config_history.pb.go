@@ -0,0 +1,346 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/config_history/config_history.proto
+
+package config_history
+
+import (
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApplyResult int32
+
+const (
+	ApplyResult_APPLY_RESULT_UNSPECIFIED ApplyResult = 0
+	ApplyResult_APPLY_RESULT_SUCCEEDED   ApplyResult = 1
+	ApplyResult_APPLY_RESULT_FAILED      ApplyResult = 2 // aborted before any node committed
+	ApplyResult_APPLY_RESULT_ROLLED_BACK ApplyResult = 3 // failed after some nodes committed, those were rolled back
+)
+
+// Enum value maps for ApplyResult.
+var (
+	ApplyResult_name = map[int32]string{
+		0: "APPLY_RESULT_UNSPECIFIED",
+		1: "APPLY_RESULT_SUCCEEDED",
+		2: "APPLY_RESULT_FAILED",
+		3: "APPLY_RESULT_ROLLED_BACK",
+	}
+	ApplyResult_value = map[string]int32{
+		"APPLY_RESULT_UNSPECIFIED": 0,
+		"APPLY_RESULT_SUCCEEDED":   1,
+		"APPLY_RESULT_FAILED":      2,
+		"APPLY_RESULT_ROLLED_BACK": 3,
+	}
+)
+
+func (x ApplyResult) Enum() *ApplyResult {
+	p := new(ApplyResult)
+	*p = x
+	return p
+}
+
+func (x ApplyResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApplyResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_config_history_config_history_proto_enumTypes[0].Descriptor()
+}
+
+func (ApplyResult) Type() protoreflect.EnumType {
+	return &file_common_structures_config_history_config_history_proto_enumTypes[0]
+}
+
+func (x ApplyResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApplyResult.Descriptor instead.
+func (ApplyResult) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_config_history_config_history_proto_rawDescGZIP(), []int{0}
+}
+
+type NodeApplyResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // transaction phase: health, lock, prepare, commit, confirm, rollback, unlock
+	DurationNs    uint64                 `protobuf:"varint,3,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // empty when the phase succeeded on the node
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeApplyResult) Reset() {
+	*x = NodeApplyResult{}
+	mi := &file_common_structures_config_history_config_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeApplyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeApplyResult) ProtoMessage() {}
+
+func (x *NodeApplyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_config_history_config_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeApplyResult.ProtoReflect.Descriptor instead.
+func (*NodeApplyResult) Descriptor() ([]byte, []int) {
+	return file_common_structures_config_history_config_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NodeApplyResult) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeApplyResult) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *NodeApplyResult) GetDurationNs() uint64 {
+	if x != nil {
+		return x.DurationNs
+	}
+	return 0
+}
+
+func (x *NodeApplyResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ConfigurationRecord struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Version       uint64                          `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	TimestampNs   uint64                          `protobuf:"varint,2,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"` // time the transaction finished (nanoseconds since epoch)
+	Actor         string                          `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`                                 // who requested the configuration
+	Result        ApplyResult                     `protobuf:"varint,4,opt,name=result,proto3,enum=config_history.ApplyResult" json:"result,omitempty"`
+	Message       string                          `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                  // error of a failed transaction
+	NodeIds       []string                        `protobuf:"bytes,6,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`                   // nodes the configuration was pushed to
+	NodeResults   []*NodeApplyResult              `protobuf:"bytes,7,rep,name=node_results,json=nodeResults,proto3" json:"node_results,omitempty"`       // per-node outcome of every phase
+	Configuration *topology_config.TopologyConfig `protobuf:"bytes,8,opt,name=configuration,proto3" json:"configuration,omitempty"`                      // configuration as requested, before compilation
+	RolledBackTo  uint64                          `protobuf:"varint,9,opt,name=rolled_back_to,json=rolledBackTo,proto3" json:"rolled_back_to,omitempty"` // version restored by a rollback; 0 when the configuration was applied
+	// Version undone by a rollback of the last transaction, which restored
+	// rolled_back_to, or the configuration before any if that is 0.
+	Undone        uint64 `protobuf:"varint,10,opt,name=undone,proto3" json:"undone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurationRecord) Reset() {
+	*x = ConfigurationRecord{}
+	mi := &file_common_structures_config_history_config_history_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationRecord) ProtoMessage() {}
+
+func (x *ConfigurationRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_config_history_config_history_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationRecord.ProtoReflect.Descriptor instead.
+func (*ConfigurationRecord) Descriptor() ([]byte, []int) {
+	return file_common_structures_config_history_config_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigurationRecord) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigurationRecord) GetTimestampNs() uint64 {
+	if x != nil {
+		return x.TimestampNs
+	}
+	return 0
+}
+
+func (x *ConfigurationRecord) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ConfigurationRecord) GetResult() ApplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return ApplyResult_APPLY_RESULT_UNSPECIFIED
+}
+
+func (x *ConfigurationRecord) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfigurationRecord) GetNodeIds() []string {
+	if x != nil {
+		return x.NodeIds
+	}
+	return nil
+}
+
+func (x *ConfigurationRecord) GetNodeResults() []*NodeApplyResult {
+	if x != nil {
+		return x.NodeResults
+	}
+	return nil
+}
+
+func (x *ConfigurationRecord) GetConfiguration() *topology_config.TopologyConfig {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+func (x *ConfigurationRecord) GetRolledBackTo() uint64 {
+	if x != nil {
+		return x.RolledBackTo
+	}
+	return 0
+}
+
+func (x *ConfigurationRecord) GetUndone() uint64 {
+	if x != nil {
+		return x.Undone
+	}
+	return 0
+}
+
+var File_common_structures_config_history_config_history_proto protoreflect.FileDescriptor
+
+const file_common_structures_config_history_config_history_proto_rawDesc = "" +
+	"\n" +
+	"5common/structures/config_history/config_history.proto\x12\x0econfig_history\x1a7common/structures/topology_config/topology_config.proto\"w\n" +
+	"\x0fNodeApplyResult\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x1f\n" +
+	"\vduration_ns\x18\x03 \x01(\x04R\n" +
+	"durationNs\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x9b\x03\n" +
+	"\x13ConfigurationRecord\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x12!\n" +
+	"\ftimestamp_ns\x18\x02 \x01(\x04R\vtimestampNs\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x123\n" +
+	"\x06result\x18\x04 \x01(\x0e2\x1b.config_history.ApplyResultR\x06result\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x19\n" +
+	"\bnode_ids\x18\x06 \x03(\tR\anodeIds\x12B\n" +
+	"\fnode_results\x18\a \x03(\v2\x1f.config_history.NodeApplyResultR\vnodeResults\x12E\n" +
+	"\rconfiguration\x18\b \x01(\v2\x1f.topology_config.TopologyConfigR\rconfiguration\x12$\n" +
+	"\x0erolled_back_to\x18\t \x01(\x04R\frolledBackTo\x12\x16\n" +
+	"\x06undone\x18\n" +
+	" \x01(\x04R\x06undone*~\n" +
+	"\vApplyResult\x12\x1c\n" +
+	"\x18APPLY_RESULT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16APPLY_RESULT_SUCCEEDED\x10\x01\x12\x17\n" +
+	"\x13APPLY_RESULT_FAILED\x10\x02\x12\x1c\n" +
+	"\x18APPLY_RESULT_ROLLED_BACK\x10\x03BHZFOpenCNC_config_service/common/structures/config_history;config_historyb\x06proto3"
+
+var (
+	file_common_structures_config_history_config_history_proto_rawDescOnce sync.Once
+	file_common_structures_config_history_config_history_proto_rawDescData []byte
+)
+
+func file_common_structures_config_history_config_history_proto_rawDescGZIP() []byte {
+	file_common_structures_config_history_config_history_proto_rawDescOnce.Do(func() {
+		file_common_structures_config_history_config_history_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_config_history_config_history_proto_rawDesc), len(file_common_structures_config_history_config_history_proto_rawDesc)))
+	})
+	return file_common_structures_config_history_config_history_proto_rawDescData
+}
+
+var file_common_structures_config_history_config_history_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_common_structures_config_history_config_history_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_common_structures_config_history_config_history_proto_goTypes = []any{
+	(ApplyResult)(0),                       // 0: config_history.ApplyResult
+	(*NodeApplyResult)(nil),                // 1: config_history.NodeApplyResult
+	(*ConfigurationRecord)(nil),            // 2: config_history.ConfigurationRecord
+	(*topology_config.TopologyConfig)(nil), // 3: topology_config.TopologyConfig
+}
+var file_common_structures_config_history_config_history_proto_depIdxs = []int32{
+	0, // 0: config_history.ConfigurationRecord.result:type_name -> config_history.ApplyResult
+	1, // 1: config_history.ConfigurationRecord.node_results:type_name -> config_history.NodeApplyResult
+	3, // 2: config_history.ConfigurationRecord.configuration:type_name -> topology_config.TopologyConfig
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_config_history_config_history_proto_init() }
+func file_common_structures_config_history_config_history_proto_init() {
+	if File_common_structures_config_history_config_history_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_config_history_config_history_proto_rawDesc), len(file_common_structures_config_history_config_history_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_config_history_config_history_proto_goTypes,
+		DependencyIndexes: file_common_structures_config_history_config_history_proto_depIdxs,
+		EnumInfos:         file_common_structures_config_history_config_history_proto_enumTypes,
+		MessageInfos:      file_common_structures_config_history_config_history_proto_msgTypes,
+	}.Build()
+	File_common_structures_config_history_config_history_proto = out.File
+	file_common_structures_config_history_config_history_proto_goTypes = nil
+	file_common_structures_config_history_config_history_proto_depIdxs = nil
+}
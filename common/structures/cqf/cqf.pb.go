@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/cqf/cqf.proto
+
+package cqf
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CqfStreamGate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // stream gate instance created, referenced by the stream filters of the CQF streams
+	InstanceId    uint32                 `protobuf:"varint,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // stream-gate-instance-id, unique per bridge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CqfStreamGate) Reset() {
+	*x = CqfStreamGate{}
+	mi := &file_common_structures_cqf_cqf_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CqfStreamGate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CqfStreamGate) ProtoMessage() {}
+
+func (x *CqfStreamGate) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_cqf_cqf_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CqfStreamGate.ProtoReflect.Descriptor instead.
+func (*CqfStreamGate) Descriptor() ([]byte, []int) {
+	return file_common_structures_cqf_cqf_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CqfStreamGate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CqfStreamGate) GetInstanceId() uint32 {
+	if x != nil {
+		return x.InstanceId
+	}
+	return 0
+}
+
+type CqfConfig struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CycleTimeNs          uint64                 `protobuf:"varint,1,opt,name=cycle_time_ns,json=cycleTimeNs,proto3" json:"cycle_time_ns,omitempty"`                            // duration of one cycle, the schedules repeat every two cycles
+	BaseTimeNs           uint64                 `protobuf:"varint,2,opt,name=base_time_ns,json=baseTimeNs,proto3" json:"base_time_ns,omitempty"`                               // start of an even cycle
+	EvenQueueId          uint32                 `protobuf:"varint,3,opt,name=even_queue_id,json=evenQueueId,proto3" json:"even_queue_id,omitempty"`                            // traffic class transmitting in even cycles (0–7)
+	OddQueueId           uint32                 `protobuf:"varint,4,opt,name=odd_queue_id,json=oddQueueId,proto3" json:"odd_queue_id,omitempty"`                               // traffic class transmitting in odd cycles (0–7)
+	EgressPortIds        []string               `protobuf:"bytes,5,rep,name=egress_port_ids,json=egressPortIds,proto3" json:"egress_port_ids,omitempty"`                       // ports scheduling the queue pair
+	BackgroundGateStates uint32                 `protobuf:"varint,6,opt,name=background_gate_states,json=backgroundGateStates,proto3" json:"background_gate_states,omitempty"` // bitmap of the traffic classes outside the pair, open in every cycle
+	StreamGates          []*CqfStreamGate       `protobuf:"bytes,7,rep,name=stream_gates,json=streamGates,proto3" json:"stream_gates,omitempty"`                               // ingress gates of the CQF streams, aligned with the cycles
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CqfConfig) Reset() {
+	*x = CqfConfig{}
+	mi := &file_common_structures_cqf_cqf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CqfConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CqfConfig) ProtoMessage() {}
+
+func (x *CqfConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_cqf_cqf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CqfConfig.ProtoReflect.Descriptor instead.
+func (*CqfConfig) Descriptor() ([]byte, []int) {
+	return file_common_structures_cqf_cqf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CqfConfig) GetCycleTimeNs() uint64 {
+	if x != nil {
+		return x.CycleTimeNs
+	}
+	return 0
+}
+
+func (x *CqfConfig) GetBaseTimeNs() uint64 {
+	if x != nil {
+		return x.BaseTimeNs
+	}
+	return 0
+}
+
+func (x *CqfConfig) GetEvenQueueId() uint32 {
+	if x != nil {
+		return x.EvenQueueId
+	}
+	return 0
+}
+
+func (x *CqfConfig) GetOddQueueId() uint32 {
+	if x != nil {
+		return x.OddQueueId
+	}
+	return 0
+}
+
+func (x *CqfConfig) GetEgressPortIds() []string {
+	if x != nil {
+		return x.EgressPortIds
+	}
+	return nil
+}
+
+func (x *CqfConfig) GetBackgroundGateStates() uint32 {
+	if x != nil {
+		return x.BackgroundGateStates
+	}
+	return 0
+}
+
+func (x *CqfConfig) GetStreamGates() []*CqfStreamGate {
+	if x != nil {
+		return x.StreamGates
+	}
+	return nil
+}
+
+var File_common_structures_cqf_cqf_proto protoreflect.FileDescriptor
+
+const file_common_structures_cqf_cqf_proto_rawDesc = "" +
+	"\n" +
+	"\x1fcommon/structures/cqf/cqf.proto\x12\x03cqf\"D\n" +
+	"\rCqfStreamGate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\rR\n" +
+	"instanceId\"\xac\x02\n" +
+	"\tCqfConfig\x12\"\n" +
+	"\rcycle_time_ns\x18\x01 \x01(\x04R\vcycleTimeNs\x12 \n" +
+	"\fbase_time_ns\x18\x02 \x01(\x04R\n" +
+	"baseTimeNs\x12\"\n" +
+	"\reven_queue_id\x18\x03 \x01(\rR\vevenQueueId\x12 \n" +
+	"\fodd_queue_id\x18\x04 \x01(\rR\n" +
+	"oddQueueId\x12&\n" +
+	"\x0fegress_port_ids\x18\x05 \x03(\tR\regressPortIds\x124\n" +
+	"\x16background_gate_states\x18\x06 \x01(\rR\x14backgroundGateStates\x125\n" +
+	"\fstream_gates\x18\a \x03(\v2\x12.cqf.CqfStreamGateR\vstreamGatesB2Z0OpenCNC_config_service/common/structures/cqf;cqfb\x06proto3"
+
+var (
+	file_common_structures_cqf_cqf_proto_rawDescOnce sync.Once
+	file_common_structures_cqf_cqf_proto_rawDescData []byte
+)
+
+func file_common_structures_cqf_cqf_proto_rawDescGZIP() []byte {
+	file_common_structures_cqf_cqf_proto_rawDescOnce.Do(func() {
+		file_common_structures_cqf_cqf_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_cqf_cqf_proto_rawDesc), len(file_common_structures_cqf_cqf_proto_rawDesc)))
+	})
+	return file_common_structures_cqf_cqf_proto_rawDescData
+}
+
+var file_common_structures_cqf_cqf_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_common_structures_cqf_cqf_proto_goTypes = []any{
+	(*CqfStreamGate)(nil), // 0: cqf.CqfStreamGate
+	(*CqfConfig)(nil),     // 1: cqf.CqfConfig
+}
+var file_common_structures_cqf_cqf_proto_depIdxs = []int32{
+	0, // 0: cqf.CqfConfig.stream_gates:type_name -> cqf.CqfStreamGate
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_cqf_cqf_proto_init() }
+func file_common_structures_cqf_cqf_proto_init() {
+	if File_common_structures_cqf_cqf_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_cqf_cqf_proto_rawDesc), len(file_common_structures_cqf_cqf_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_cqf_cqf_proto_goTypes,
+		DependencyIndexes: file_common_structures_cqf_cqf_proto_depIdxs,
+		MessageInfos:      file_common_structures_cqf_cqf_proto_msgTypes,
+	}.Build()
+	File_common_structures_cqf_cqf_proto = out.File
+	file_common_structures_cqf_cqf_proto_goTypes = nil
+	file_common_structures_cqf_cqf_proto_depIdxs = nil
+}
@@ -0,0 +1,1411 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/frer/frer.proto
+
+package frer
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FrerMode int32
+
+const (
+	FrerMode_MODE_UNSPECIFIED  FrerMode = 0 // Default stream ID selection
+	FrerMode_MAC_DA_SELECTION  FrerMode = 1 // Use MAC DA + VLAN ID (Clause 6.4.3)
+	FrerMode_VLAN_ID_SELECTION FrerMode = 2 // Use VLAN ID only
+	FrerMode_EXPLICIT_PATH     FrerMode = 3 // Custom CNC-controlled identification (Clause 6.6.2)
+)
+
+// Enum value maps for FrerMode.
+var (
+	FrerMode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "MAC_DA_SELECTION",
+		2: "VLAN_ID_SELECTION",
+		3: "EXPLICIT_PATH",
+	}
+	FrerMode_value = map[string]int32{
+		"MODE_UNSPECIFIED":  0,
+		"MAC_DA_SELECTION":  1,
+		"VLAN_ID_SELECTION": 2,
+		"EXPLICIT_PATH":     3,
+	}
+)
+
+func (x FrerMode) Enum() *FrerMode {
+	p := new(FrerMode)
+	*p = x
+	return p
+}
+
+func (x FrerMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FrerMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_frer_frer_proto_enumTypes[0].Descriptor()
+}
+
+func (FrerMode) Type() protoreflect.EnumType {
+	return &file_common_structures_frer_frer_proto_enumTypes[0]
+}
+
+func (x FrerMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FrerMode.Descriptor instead.
+func (FrerMode) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{0}
+}
+
+type StreamIdentificationType int32
+
+const (
+	StreamIdentificationType_STREAM_IDENTIFICATION_UNSPECIFIED StreamIdentificationType = 0
+	StreamIdentificationType_NULL_STREAM                       StreamIdentificationType = 1 // Destination MAC + VLAN (Clause 6.4)
+	StreamIdentificationType_SOURCE_MAC_VLAN                   StreamIdentificationType = 2 // Source MAC + VLAN (Clause 6.5)
+)
+
+// Enum value maps for StreamIdentificationType.
+var (
+	StreamIdentificationType_name = map[int32]string{
+		0: "STREAM_IDENTIFICATION_UNSPECIFIED",
+		1: "NULL_STREAM",
+		2: "SOURCE_MAC_VLAN",
+	}
+	StreamIdentificationType_value = map[string]int32{
+		"STREAM_IDENTIFICATION_UNSPECIFIED": 0,
+		"NULL_STREAM":                       1,
+		"SOURCE_MAC_VLAN":                   2,
+	}
+)
+
+func (x StreamIdentificationType) Enum() *StreamIdentificationType {
+	p := new(StreamIdentificationType)
+	*p = x
+	return p
+}
+
+func (x StreamIdentificationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamIdentificationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_frer_frer_proto_enumTypes[1].Descriptor()
+}
+
+func (StreamIdentificationType) Type() protoreflect.EnumType {
+	return &file_common_structures_frer_frer_proto_enumTypes[1]
+}
+
+func (x StreamIdentificationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamIdentificationType.Descriptor instead.
+func (StreamIdentificationType) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{1}
+}
+
+type VlanTagging int32
+
+const (
+	VlanTagging_VLAN_TAGGING_UNSPECIFIED VlanTagging = 0
+	VlanTagging_TAGGED                   VlanTagging = 1 // Only frames with a VLAN tag
+	VlanTagging_PRIORITY                 VlanTagging = 2 // Only untagged or priority-tagged frames
+	VlanTagging_ALL                      VlanTagging = 3 // Any frame
+)
+
+// Enum value maps for VlanTagging.
+var (
+	VlanTagging_name = map[int32]string{
+		0: "VLAN_TAGGING_UNSPECIFIED",
+		1: "TAGGED",
+		2: "PRIORITY",
+		3: "ALL",
+	}
+	VlanTagging_value = map[string]int32{
+		"VLAN_TAGGING_UNSPECIFIED": 0,
+		"TAGGED":                   1,
+		"PRIORITY":                 2,
+		"ALL":                      3,
+	}
+)
+
+func (x VlanTagging) Enum() *VlanTagging {
+	p := new(VlanTagging)
+	*p = x
+	return p
+}
+
+func (x VlanTagging) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VlanTagging) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_frer_frer_proto_enumTypes[2].Descriptor()
+}
+
+func (VlanTagging) Type() protoreflect.EnumType {
+	return &file_common_structures_frer_frer_proto_enumTypes[2]
+}
+
+func (x VlanTagging) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VlanTagging.Descriptor instead.
+func (VlanTagging) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{2}
+}
+
+type RecoveryAlgorithm int32
+
+const (
+	RecoveryAlgorithm_RECOVERY_ALGORITHM_UNSPECIFIED RecoveryAlgorithm = 0 // Vector
+	RecoveryAlgorithm_VECTOR                         RecoveryAlgorithm = 1 // Clause 7.4.3.4
+	RecoveryAlgorithm_MATCH                          RecoveryAlgorithm = 2 // Clause 7.4.3.5
+)
+
+// Enum value maps for RecoveryAlgorithm.
+var (
+	RecoveryAlgorithm_name = map[int32]string{
+		0: "RECOVERY_ALGORITHM_UNSPECIFIED",
+		1: "VECTOR",
+		2: "MATCH",
+	}
+	RecoveryAlgorithm_value = map[string]int32{
+		"RECOVERY_ALGORITHM_UNSPECIFIED": 0,
+		"VECTOR":                         1,
+		"MATCH":                          2,
+	}
+)
+
+func (x RecoveryAlgorithm) Enum() *RecoveryAlgorithm {
+	p := new(RecoveryAlgorithm)
+	*p = x
+	return p
+}
+
+func (x RecoveryAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecoveryAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_frer_frer_proto_enumTypes[3].Descriptor()
+}
+
+func (RecoveryAlgorithm) Type() protoreflect.EnumType {
+	return &file_common_structures_frer_frer_proto_enumTypes[3]
+}
+
+func (x RecoveryAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecoveryAlgorithm.Descriptor instead.
+func (RecoveryAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{3}
+}
+
+type SequenceEncapsulation int32
+
+const (
+	SequenceEncapsulation_SEQUENCE_ENCAPSULATION_UNSPECIFIED SequenceEncapsulation = 0 // R-TAG
+	SequenceEncapsulation_R_TAG                              SequenceEncapsulation = 1 // Clause 7.8
+	SequenceEncapsulation_HSR_SEQUENCE_TAG                   SequenceEncapsulation = 2 // Clause 7.9
+	SequenceEncapsulation_PRP_SEQUENCE_TRAILER               SequenceEncapsulation = 3 // Clause 7.10
+)
+
+// Enum value maps for SequenceEncapsulation.
+var (
+	SequenceEncapsulation_name = map[int32]string{
+		0: "SEQUENCE_ENCAPSULATION_UNSPECIFIED",
+		1: "R_TAG",
+		2: "HSR_SEQUENCE_TAG",
+		3: "PRP_SEQUENCE_TRAILER",
+	}
+	SequenceEncapsulation_value = map[string]int32{
+		"SEQUENCE_ENCAPSULATION_UNSPECIFIED": 0,
+		"R_TAG":                              1,
+		"HSR_SEQUENCE_TAG":                   2,
+		"PRP_SEQUENCE_TRAILER":               3,
+	}
+)
+
+func (x SequenceEncapsulation) Enum() *SequenceEncapsulation {
+	p := new(SequenceEncapsulation)
+	*p = x
+	return p
+}
+
+func (x SequenceEncapsulation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SequenceEncapsulation) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_frer_frer_proto_enumTypes[4].Descriptor()
+}
+
+func (SequenceEncapsulation) Type() protoreflect.EnumType {
+	return &file_common_structures_frer_frer_proto_enumTypes[4]
+}
+
+func (x SequenceEncapsulation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SequenceEncapsulation.Descriptor instead.
+func (SequenceEncapsulation) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{4}
+}
+
+type StreamID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint32                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"` // tsnStreamIdHandle shared by stream identification and the FRER functions
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`      // Optional human-readable stream name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamID) Reset() {
+	*x = StreamID{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamID) ProtoMessage() {}
+
+func (x *StreamID) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamID.ProtoReflect.Descriptor instead.
+func (*StreamID) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamID) GetHandle() uint32 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *StreamID) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FrerRecoveryAlgorithm struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SequenceHistoryLength uint32                 `protobuf:"varint,1,opt,name=sequence_history_length,json=sequenceHistoryLength,proto3" json:"sequence_history_length,omitempty"` // Clause 8.6 — number of sequence IDs to track for elimination
+	ReorderWindowSize     uint32                 `protobuf:"varint,2,opt,name=reorder_window_size,json=reorderWindowSize,proto3" json:"reorder_window_size,omitempty"`             // Clause 8.4.4.4 — maximum tolerated reordering range
+	HoldOffTimeNs         uint64                 `protobuf:"varint,3,opt,name=hold_off_time_ns,json=holdOffTimeNs,proto3" json:"hold_off_time_ns,omitempty"`                       // Clause 8.4.4.4 — time to wait before aging entries
+	DiscardOldFrames      bool                   `protobuf:"varint,4,opt,name=discard_old_frames,json=discardOldFrames,proto3" json:"discard_old_frames,omitempty"`                // Clause 8.4.4.4 — whether to drop frames outside window
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FrerRecoveryAlgorithm) Reset() {
+	*x = FrerRecoveryAlgorithm{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerRecoveryAlgorithm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerRecoveryAlgorithm) ProtoMessage() {}
+
+func (x *FrerRecoveryAlgorithm) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerRecoveryAlgorithm.ProtoReflect.Descriptor instead.
+func (*FrerRecoveryAlgorithm) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FrerRecoveryAlgorithm) GetSequenceHistoryLength() uint32 {
+	if x != nil {
+		return x.SequenceHistoryLength
+	}
+	return 0
+}
+
+func (x *FrerRecoveryAlgorithm) GetReorderWindowSize() uint32 {
+	if x != nil {
+		return x.ReorderWindowSize
+	}
+	return 0
+}
+
+func (x *FrerRecoveryAlgorithm) GetHoldOffTimeNs() uint64 {
+	if x != nil {
+		return x.HoldOffTimeNs
+	}
+	return 0
+}
+
+func (x *FrerRecoveryAlgorithm) GetDiscardOldFrames() bool {
+	if x != nil {
+		return x.DiscardOldFrames
+	}
+	return false
+}
+
+type RedundancyGroup struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                               // Unique Redundancy Group ID, can be referenced by stream configs
+	MemberStreamIds   []*StreamID            `protobuf:"bytes,2,rep,name=member_stream_ids,json=memberStreamIds,proto3" json:"member_stream_ids,omitempty"`     // Streams participating in this group
+	RecoveryAlgorithm *FrerRecoveryAlgorithm `protobuf:"bytes,3,opt,name=recovery_algorithm,json=recoveryAlgorithm,proto3" json:"recovery_algorithm,omitempty"` // Shared recovery settings
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RedundancyGroup) Reset() {
+	*x = RedundancyGroup{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedundancyGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedundancyGroup) ProtoMessage() {}
+
+func (x *RedundancyGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedundancyGroup.ProtoReflect.Descriptor instead.
+func (*RedundancyGroup) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RedundancyGroup) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RedundancyGroup) GetMemberStreamIds() []*StreamID {
+	if x != nil {
+		return x.MemberStreamIds
+	}
+	return nil
+}
+
+func (x *RedundancyGroup) GetRecoveryAlgorithm() *FrerRecoveryAlgorithm {
+	if x != nil {
+		return x.RecoveryAlgorithm
+	}
+	return nil
+}
+
+type FrerStreamConfig struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	RedundancyGroupId         string                 `protobuf:"bytes,1,opt,name=redundancy_group_id,json=redundancyGroupId,proto3" json:"redundancy_group_id,omitempty"`                          // Reference to RedundancyGroup
+	EnableRecovery            bool                   `protobuf:"varint,2,opt,name=enable_recovery,json=enableRecovery,proto3" json:"enable_recovery,omitempty"`                                    // Enable duplicate elimination at listener (Clause 6.7)
+	EnableTransmitDuplication bool                   `protobuf:"varint,3,opt,name=enable_transmit_duplication,json=enableTransmitDuplication,proto3" json:"enable_transmit_duplication,omitempty"` // Enable duplication at talker (Clause 6.6)
+	EnableReceiveCombining    bool                   `protobuf:"varint,4,opt,name=enable_receive_combining,json=enableReceiveCombining,proto3" json:"enable_receive_combining,omitempty"`          // Enable frame combining at receiver (Clause 6.8)
+	PathSelections            []*FrerPathSelection   `protobuf:"bytes,5,rep,name=path_selections,json=pathSelections,proto3" json:"path_selections,omitempty"`                                     // Explicit per-path configuration (Clause 6.6.2)
+	Mode                      FrerMode               `protobuf:"varint,6,opt,name=mode,proto3,enum=tsn.frer.FrerMode" json:"mode,omitempty"`                                                       // Selection mode for stream identity (Clause 6.4.2)
+	ProfileId                 string                 `protobuf:"bytes,7,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`                                                    // Optional profile alignment (e.g., TSN automotive)
+	// Optional sequence generation control for talker (Clause 8.4.4.1)
+	EnableSequenceGeneration bool   `protobuf:"varint,8,opt,name=enable_sequence_generation,json=enableSequenceGeneration,proto3" json:"enable_sequence_generation,omitempty"` // Whether this stream should generate sequence IDs
+	SequenceIdStart          uint64 `protobuf:"varint,9,opt,name=sequence_id_start,json=sequenceIdStart,proto3" json:"sequence_id_start,omitempty"`                            // Starting value (usually 0)
+	SequenceModulus          uint32 `protobuf:"varint,10,opt,name=sequence_modulus,json=sequenceModulus,proto3" json:"sequence_modulus,omitempty"`                             // Sequence wrap-around modulus (e.g., 256, 4096)
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *FrerStreamConfig) Reset() {
+	*x = FrerStreamConfig{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerStreamConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerStreamConfig) ProtoMessage() {}
+
+func (x *FrerStreamConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerStreamConfig.ProtoReflect.Descriptor instead.
+func (*FrerStreamConfig) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FrerStreamConfig) GetRedundancyGroupId() string {
+	if x != nil {
+		return x.RedundancyGroupId
+	}
+	return ""
+}
+
+func (x *FrerStreamConfig) GetEnableRecovery() bool {
+	if x != nil {
+		return x.EnableRecovery
+	}
+	return false
+}
+
+func (x *FrerStreamConfig) GetEnableTransmitDuplication() bool {
+	if x != nil {
+		return x.EnableTransmitDuplication
+	}
+	return false
+}
+
+func (x *FrerStreamConfig) GetEnableReceiveCombining() bool {
+	if x != nil {
+		return x.EnableReceiveCombining
+	}
+	return false
+}
+
+func (x *FrerStreamConfig) GetPathSelections() []*FrerPathSelection {
+	if x != nil {
+		return x.PathSelections
+	}
+	return nil
+}
+
+func (x *FrerStreamConfig) GetMode() FrerMode {
+	if x != nil {
+		return x.Mode
+	}
+	return FrerMode_MODE_UNSPECIFIED
+}
+
+func (x *FrerStreamConfig) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *FrerStreamConfig) GetEnableSequenceGeneration() bool {
+	if x != nil {
+		return x.EnableSequenceGeneration
+	}
+	return false
+}
+
+func (x *FrerStreamConfig) GetSequenceIdStart() uint64 {
+	if x != nil {
+		return x.SequenceIdStart
+	}
+	return 0
+}
+
+func (x *FrerStreamConfig) GetSequenceModulus() uint32 {
+	if x != nil {
+		return x.SequenceModulus
+	}
+	return 0
+}
+
+type FrerPathSelection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EgressPortId  string                 `protobuf:"bytes,1,opt,name=egress_port_id,json=egressPortId,proto3" json:"egress_port_id,omitempty"` // Port used to replicate frame
+	NexthopMac    string                 `protobuf:"bytes,2,opt,name=nexthop_mac,json=nexthopMac,proto3" json:"nexthop_mac,omitempty"`         // Next-hop MAC address for replication
+	VlanId        uint32                 `protobuf:"varint,3,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`                    // VLAN to apply on this path
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrerPathSelection) Reset() {
+	*x = FrerPathSelection{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerPathSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerPathSelection) ProtoMessage() {}
+
+func (x *FrerPathSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerPathSelection.ProtoReflect.Descriptor instead.
+func (*FrerPathSelection) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FrerPathSelection) GetEgressPortId() string {
+	if x != nil {
+		return x.EgressPortId
+	}
+	return ""
+}
+
+func (x *FrerPathSelection) GetNexthopMac() string {
+	if x != nil {
+		return x.NexthopMac
+	}
+	return ""
+}
+
+func (x *FrerPathSelection) GetVlanId() uint32 {
+	if x != nil {
+		return x.VlanId
+	}
+	return 0
+}
+
+type FrerProfilePolicy struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId                string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`                                                 // Matches tsn.Profile.id if profiles exist
+	AllowPartialStreams      bool                   `protobuf:"varint,2,opt,name=allow_partial_streams,json=allowPartialStreams,proto3" json:"allow_partial_streams,omitempty"`                // Whether some listeners can skip FRER
+	MaxPathDivergence        uint32                 `protobuf:"varint,3,opt,name=max_path_divergence,json=maxPathDivergence,proto3" json:"max_path_divergence,omitempty"`                      // Max hops where paths may differ (Clause 8.3 Note)
+	RequireDisjointPaths     bool                   `protobuf:"varint,4,opt,name=require_disjoint_paths,json=requireDisjointPaths,proto3" json:"require_disjoint_paths,omitempty"`             // Whether all FRER paths must be disjoint (Clause 6.6 Note)
+	AllowMacMultiplexing     bool                   `protobuf:"varint,5,opt,name=allow_mac_multiplexing,json=allowMacMultiplexing,proto3" json:"allow_mac_multiplexing,omitempty"`             // Allow multiple stream IDs on same MAC path
+	AllowStreamFragmentation bool                   `protobuf:"varint,6,opt,name=allow_stream_fragmentation,json=allowStreamFragmentation,proto3" json:"allow_stream_fragmentation,omitempty"` // Permit stream slicing (used in some profiles)
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *FrerProfilePolicy) Reset() {
+	*x = FrerProfilePolicy{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerProfilePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerProfilePolicy) ProtoMessage() {}
+
+func (x *FrerProfilePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerProfilePolicy.ProtoReflect.Descriptor instead.
+func (*FrerProfilePolicy) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FrerProfilePolicy) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *FrerProfilePolicy) GetAllowPartialStreams() bool {
+	if x != nil {
+		return x.AllowPartialStreams
+	}
+	return false
+}
+
+func (x *FrerProfilePolicy) GetMaxPathDivergence() uint32 {
+	if x != nil {
+		return x.MaxPathDivergence
+	}
+	return 0
+}
+
+func (x *FrerProfilePolicy) GetRequireDisjointPaths() bool {
+	if x != nil {
+		return x.RequireDisjointPaths
+	}
+	return false
+}
+
+func (x *FrerProfilePolicy) GetAllowMacMultiplexing() bool {
+	if x != nil {
+		return x.AllowMacMultiplexing
+	}
+	return false
+}
+
+func (x *FrerProfilePolicy) GetAllowStreamFragmentation() bool {
+	if x != nil {
+		return x.AllowStreamFragmentation
+	}
+	return false
+}
+
+type FrerGlobalConfiguration struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Enabled          bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                          // Global FRER enable
+	DefaultAlgorithm *FrerRecoveryAlgorithm `protobuf:"bytes,2,opt,name=default_algorithm,json=defaultAlgorithm,proto3" json:"default_algorithm,omitempty"` // Default recovery fallback
+	RedundancyGroups []*RedundancyGroup     `protobuf:"bytes,3,rep,name=redundancy_groups,json=redundancyGroups,proto3" json:"redundancy_groups,omitempty"` // Configured recovery domains
+	Version          uint32                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                                          // Schema version for evolution (default: 1)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FrerGlobalConfiguration) Reset() {
+	*x = FrerGlobalConfiguration{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerGlobalConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerGlobalConfiguration) ProtoMessage() {}
+
+func (x *FrerGlobalConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerGlobalConfiguration.ProtoReflect.Descriptor instead.
+func (*FrerGlobalConfiguration) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FrerGlobalConfiguration) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FrerGlobalConfiguration) GetDefaultAlgorithm() *FrerRecoveryAlgorithm {
+	if x != nil {
+		return x.DefaultAlgorithm
+	}
+	return nil
+}
+
+func (x *FrerGlobalConfiguration) GetRedundancyGroups() []*RedundancyGroup {
+	if x != nil {
+		return x.RedundancyGroups
+	}
+	return nil
+}
+
+func (x *FrerGlobalConfiguration) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type StreamIdentity struct {
+	state                protoimpl.MessageState   `protogen:"open.v1"`
+	Index                uint32                   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`  // tsnStreamIdEntry index
+	Stream               *StreamID                `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"` // Stream handle assigned to matching frames
+	Type                 StreamIdentificationType `protobuf:"varint,3,opt,name=type,proto3,enum=tsn.frer.StreamIdentificationType" json:"type,omitempty"`
+	MacAddress           string                   `protobuf:"bytes,4,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"` // Destination (null stream) or source MAC
+	VlanId               uint32                   `protobuf:"varint,5,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`            // 0 matches untagged frames
+	Tagging              VlanTagging              `protobuf:"varint,6,opt,name=tagging,proto3,enum=tsn.frer.VlanTagging" json:"tagging,omitempty"`
+	InFacingInputPorts   []string                 `protobuf:"bytes,7,rep,name=in_facing_input_ports,json=inFacingInputPorts,proto3" json:"in_facing_input_ports,omitempty"` // Clause 9.1.1.2 — where the function is instantiated
+	InFacingOutputPorts  []string                 `protobuf:"bytes,8,rep,name=in_facing_output_ports,json=inFacingOutputPorts,proto3" json:"in_facing_output_ports,omitempty"`
+	OutFacingInputPorts  []string                 `protobuf:"bytes,9,rep,name=out_facing_input_ports,json=outFacingInputPorts,proto3" json:"out_facing_input_ports,omitempty"`
+	OutFacingOutputPorts []string                 `protobuf:"bytes,10,rep,name=out_facing_output_ports,json=outFacingOutputPorts,proto3" json:"out_facing_output_ports,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *StreamIdentity) Reset() {
+	*x = StreamIdentity{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamIdentity) ProtoMessage() {}
+
+func (x *StreamIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamIdentity.ProtoReflect.Descriptor instead.
+func (*StreamIdentity) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamIdentity) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *StreamIdentity) GetStream() *StreamID {
+	if x != nil {
+		return x.Stream
+	}
+	return nil
+}
+
+func (x *StreamIdentity) GetType() StreamIdentificationType {
+	if x != nil {
+		return x.Type
+	}
+	return StreamIdentificationType_STREAM_IDENTIFICATION_UNSPECIFIED
+}
+
+func (x *StreamIdentity) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+func (x *StreamIdentity) GetVlanId() uint32 {
+	if x != nil {
+		return x.VlanId
+	}
+	return 0
+}
+
+func (x *StreamIdentity) GetTagging() VlanTagging {
+	if x != nil {
+		return x.Tagging
+	}
+	return VlanTagging_VLAN_TAGGING_UNSPECIFIED
+}
+
+func (x *StreamIdentity) GetInFacingInputPorts() []string {
+	if x != nil {
+		return x.InFacingInputPorts
+	}
+	return nil
+}
+
+func (x *StreamIdentity) GetInFacingOutputPorts() []string {
+	if x != nil {
+		return x.InFacingOutputPorts
+	}
+	return nil
+}
+
+func (x *StreamIdentity) GetOutFacingInputPorts() []string {
+	if x != nil {
+		return x.OutFacingInputPorts
+	}
+	return nil
+}
+
+func (x *StreamIdentity) GetOutFacingOutputPorts() []string {
+	if x != nil {
+		return x.OutFacingOutputPorts
+	}
+	return nil
+}
+
+type SequenceGeneration struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Index              uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                                       // frerSeqGenEntry index
+	StreamHandles      []uint32               `protobuf:"varint,2,rep,packed,name=stream_handles,json=streamHandles,proto3" json:"stream_handles,omitempty"`           // Streams the generation function applies to
+	DirectionOutFacing bool                   `protobuf:"varint,3,opt,name=direction_out_facing,json=directionOutFacing,proto3" json:"direction_out_facing,omitempty"` // Out-facing (towards the port) or in-facing
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SequenceGeneration) Reset() {
+	*x = SequenceGeneration{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SequenceGeneration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SequenceGeneration) ProtoMessage() {}
+
+func (x *SequenceGeneration) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SequenceGeneration.ProtoReflect.Descriptor instead.
+func (*SequenceGeneration) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SequenceGeneration) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SequenceGeneration) GetStreamHandles() []uint32 {
+	if x != nil {
+		return x.StreamHandles
+	}
+	return nil
+}
+
+func (x *SequenceGeneration) GetDirectionOutFacing() bool {
+	if x != nil {
+		return x.DirectionOutFacing
+	}
+	return false
+}
+
+type SequenceRecovery struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Index                uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // frerSeqRcvyEntry index
+	StreamHandles        []uint32               `protobuf:"varint,2,rep,packed,name=stream_handles,json=streamHandles,proto3" json:"stream_handles,omitempty"`
+	PortIds              []string               `protobuf:"bytes,3,rep,name=port_ids,json=portIds,proto3" json:"port_ids,omitempty"` // Ports the recovery function is placed on
+	DirectionOutFacing   bool                   `protobuf:"varint,4,opt,name=direction_out_facing,json=directionOutFacing,proto3" json:"direction_out_facing,omitempty"`
+	Algorithm            RecoveryAlgorithm      `protobuf:"varint,5,opt,name=algorithm,proto3,enum=tsn.frer.RecoveryAlgorithm" json:"algorithm,omitempty"`
+	Parameters           *FrerRecoveryAlgorithm `protobuf:"bytes,6,opt,name=parameters,proto3" json:"parameters,omitempty"`                                            // History length and reset timeout
+	TakeNoSequence       bool                   `protobuf:"varint,7,opt,name=take_no_sequence,json=takeNoSequence,proto3" json:"take_no_sequence,omitempty"`           // Accept frames without a sequence number
+	IndividualRecovery   bool                   `protobuf:"varint,8,opt,name=individual_recovery,json=individualRecovery,proto3" json:"individual_recovery,omitempty"` // Per member stream recovery
+	LatentErrorDetection bool                   `protobuf:"varint,9,opt,name=latent_error_detection,json=latentErrorDetection,proto3" json:"latent_error_detection,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SequenceRecovery) Reset() {
+	*x = SequenceRecovery{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SequenceRecovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SequenceRecovery) ProtoMessage() {}
+
+func (x *SequenceRecovery) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SequenceRecovery.ProtoReflect.Descriptor instead.
+func (*SequenceRecovery) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SequenceRecovery) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SequenceRecovery) GetStreamHandles() []uint32 {
+	if x != nil {
+		return x.StreamHandles
+	}
+	return nil
+}
+
+func (x *SequenceRecovery) GetPortIds() []string {
+	if x != nil {
+		return x.PortIds
+	}
+	return nil
+}
+
+func (x *SequenceRecovery) GetDirectionOutFacing() bool {
+	if x != nil {
+		return x.DirectionOutFacing
+	}
+	return false
+}
+
+func (x *SequenceRecovery) GetAlgorithm() RecoveryAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return RecoveryAlgorithm_RECOVERY_ALGORITHM_UNSPECIFIED
+}
+
+func (x *SequenceRecovery) GetParameters() *FrerRecoveryAlgorithm {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *SequenceRecovery) GetTakeNoSequence() bool {
+	if x != nil {
+		return x.TakeNoSequence
+	}
+	return false
+}
+
+func (x *SequenceRecovery) GetIndividualRecovery() bool {
+	if x != nil {
+		return x.IndividualRecovery
+	}
+	return false
+}
+
+func (x *SequenceRecovery) GetLatentErrorDetection() bool {
+	if x != nil {
+		return x.LatentErrorDetection
+	}
+	return false
+}
+
+type SequenceIdentification struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PortId             string                 `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"` // Port the sequence number is encoded or decoded on
+	DirectionOutFacing bool                   `protobuf:"varint,2,opt,name=direction_out_facing,json=directionOutFacing,proto3" json:"direction_out_facing,omitempty"`
+	StreamHandles      []uint32               `protobuf:"varint,3,rep,packed,name=stream_handles,json=streamHandles,proto3" json:"stream_handles,omitempty"`
+	Active             bool                   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"` // Encode (active) or only decode (passive)
+	Encapsulation      SequenceEncapsulation  `protobuf:"varint,5,opt,name=encapsulation,proto3,enum=tsn.frer.SequenceEncapsulation" json:"encapsulation,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SequenceIdentification) Reset() {
+	*x = SequenceIdentification{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SequenceIdentification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SequenceIdentification) ProtoMessage() {}
+
+func (x *SequenceIdentification) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SequenceIdentification.ProtoReflect.Descriptor instead.
+func (*SequenceIdentification) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SequenceIdentification) GetPortId() string {
+	if x != nil {
+		return x.PortId
+	}
+	return ""
+}
+
+func (x *SequenceIdentification) GetDirectionOutFacing() bool {
+	if x != nil {
+		return x.DirectionOutFacing
+	}
+	return false
+}
+
+func (x *SequenceIdentification) GetStreamHandles() []uint32 {
+	if x != nil {
+		return x.StreamHandles
+	}
+	return nil
+}
+
+func (x *SequenceIdentification) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SequenceIdentification) GetEncapsulation() SequenceEncapsulation {
+	if x != nil {
+		return x.Encapsulation
+	}
+	return SequenceEncapsulation_SEQUENCE_ENCAPSULATION_UNSPECIFIED
+}
+
+// FRER configuration of a bridge: the stream identification and the sequence
+// functions that reference the identified streams by handle.
+type FrerBridgeConfig struct {
+	state                   protoimpl.MessageState    `protogen:"open.v1"`
+	StreamIdentities        []*StreamIdentity         `protobuf:"bytes,1,rep,name=stream_identities,json=streamIdentities,proto3" json:"stream_identities,omitempty"`
+	SequenceGenerations     []*SequenceGeneration     `protobuf:"bytes,2,rep,name=sequence_generations,json=sequenceGenerations,proto3" json:"sequence_generations,omitempty"`
+	SequenceRecoveries      []*SequenceRecovery       `protobuf:"bytes,3,rep,name=sequence_recoveries,json=sequenceRecoveries,proto3" json:"sequence_recoveries,omitempty"`
+	SequenceIdentifications []*SequenceIdentification `protobuf:"bytes,4,rep,name=sequence_identifications,json=sequenceIdentifications,proto3" json:"sequence_identifications,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *FrerBridgeConfig) Reset() {
+	*x = FrerBridgeConfig{}
+	mi := &file_common_structures_frer_frer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerBridgeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerBridgeConfig) ProtoMessage() {}
+
+func (x *FrerBridgeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerBridgeConfig.ProtoReflect.Descriptor instead.
+func (*FrerBridgeConfig) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FrerBridgeConfig) GetStreamIdentities() []*StreamIdentity {
+	if x != nil {
+		return x.StreamIdentities
+	}
+	return nil
+}
+
+func (x *FrerBridgeConfig) GetSequenceGenerations() []*SequenceGeneration {
+	if x != nil {
+		return x.SequenceGenerations
+	}
+	return nil
+}
+
+func (x *FrerBridgeConfig) GetSequenceRecoveries() []*SequenceRecovery {
+	if x != nil {
+		return x.SequenceRecoveries
+	}
+	return nil
+}
+
+func (x *FrerBridgeConfig) GetSequenceIdentifications() []*SequenceIdentification {
+	if x != nil {
+		return x.SequenceIdentifications
+	}
+	return nil
+}
+
+var File_common_structures_frer_frer_proto protoreflect.FileDescriptor
+
+const file_common_structures_frer_frer_proto_rawDesc = "" +
+	"\n" +
+	"!common/structures/frer/frer.proto\x12\btsn.frer\"6\n" +
+	"\bStreamID\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\rR\x06handle\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xd6\x01\n" +
+	"\x15FrerRecoveryAlgorithm\x126\n" +
+	"\x17sequence_history_length\x18\x01 \x01(\rR\x15sequenceHistoryLength\x12.\n" +
+	"\x13reorder_window_size\x18\x02 \x01(\rR\x11reorderWindowSize\x12'\n" +
+	"\x10hold_off_time_ns\x18\x03 \x01(\x04R\rholdOffTimeNs\x12,\n" +
+	"\x12discard_old_frames\x18\x04 \x01(\bR\x10discardOldFrames\"\xbc\x01\n" +
+	"\x0fRedundancyGroup\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12>\n" +
+	"\x11member_stream_ids\x18\x02 \x03(\v2\x12.tsn.frer.StreamIDR\x0fmemberStreamIds\x12N\n" +
+	"\x12recovery_algorithm\x18\x03 \x01(\v2\x1f.tsn.frer.FrerRecoveryAlgorithmR\x11recoveryAlgorithm\"\x87\x04\n" +
+	"\x10FrerStreamConfig\x12.\n" +
+	"\x13redundancy_group_id\x18\x01 \x01(\tR\x11redundancyGroupId\x12'\n" +
+	"\x0fenable_recovery\x18\x02 \x01(\bR\x0eenableRecovery\x12>\n" +
+	"\x1benable_transmit_duplication\x18\x03 \x01(\bR\x19enableTransmitDuplication\x128\n" +
+	"\x18enable_receive_combining\x18\x04 \x01(\bR\x16enableReceiveCombining\x12D\n" +
+	"\x0fpath_selections\x18\x05 \x03(\v2\x1b.tsn.frer.FrerPathSelectionR\x0epathSelections\x12&\n" +
+	"\x04mode\x18\x06 \x01(\x0e2\x12.tsn.frer.FrerModeR\x04mode\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\a \x01(\tR\tprofileId\x12<\n" +
+	"\x1aenable_sequence_generation\x18\b \x01(\bR\x18enableSequenceGeneration\x12*\n" +
+	"\x11sequence_id_start\x18\t \x01(\x04R\x0fsequenceIdStart\x12)\n" +
+	"\x10sequence_modulus\x18\n" +
+	" \x01(\rR\x0fsequenceModulus\"s\n" +
+	"\x11FrerPathSelection\x12$\n" +
+	"\x0eegress_port_id\x18\x01 \x01(\tR\fegressPortId\x12\x1f\n" +
+	"\vnexthop_mac\x18\x02 \x01(\tR\n" +
+	"nexthopMac\x12\x17\n" +
+	"\avlan_id\x18\x03 \x01(\rR\x06vlanId\"\xc0\x02\n" +
+	"\x11FrerProfilePolicy\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x122\n" +
+	"\x15allow_partial_streams\x18\x02 \x01(\bR\x13allowPartialStreams\x12.\n" +
+	"\x13max_path_divergence\x18\x03 \x01(\rR\x11maxPathDivergence\x124\n" +
+	"\x16require_disjoint_paths\x18\x04 \x01(\bR\x14requireDisjointPaths\x124\n" +
+	"\x16allow_mac_multiplexing\x18\x05 \x01(\bR\x14allowMacMultiplexing\x12<\n" +
+	"\x1aallow_stream_fragmentation\x18\x06 \x01(\bR\x18allowStreamFragmentation\"\xe3\x01\n" +
+	"\x17FrerGlobalConfiguration\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12L\n" +
+	"\x11default_algorithm\x18\x02 \x01(\v2\x1f.tsn.frer.FrerRecoveryAlgorithmR\x10defaultAlgorithm\x12F\n" +
+	"\x11redundancy_groups\x18\x03 \x03(\v2\x19.tsn.frer.RedundancyGroupR\x10redundancyGroups\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\rR\aversion\"\xc9\x03\n" +
+	"\x0eStreamIdentity\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12*\n" +
+	"\x06stream\x18\x02 \x01(\v2\x12.tsn.frer.StreamIDR\x06stream\x126\n" +
+	"\x04type\x18\x03 \x01(\x0e2\".tsn.frer.StreamIdentificationTypeR\x04type\x12\x1f\n" +
+	"\vmac_address\x18\x04 \x01(\tR\n" +
+	"macAddress\x12\x17\n" +
+	"\avlan_id\x18\x05 \x01(\rR\x06vlanId\x12/\n" +
+	"\atagging\x18\x06 \x01(\x0e2\x15.tsn.frer.VlanTaggingR\atagging\x121\n" +
+	"\x15in_facing_input_ports\x18\a \x03(\tR\x12inFacingInputPorts\x123\n" +
+	"\x16in_facing_output_ports\x18\b \x03(\tR\x13inFacingOutputPorts\x123\n" +
+	"\x16out_facing_input_ports\x18\t \x03(\tR\x13outFacingInputPorts\x125\n" +
+	"\x17out_facing_output_ports\x18\n" +
+	" \x03(\tR\x14outFacingOutputPorts\"\x83\x01\n" +
+	"\x12SequenceGeneration\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12%\n" +
+	"\x0estream_handles\x18\x02 \x03(\rR\rstreamHandles\x120\n" +
+	"\x14direction_out_facing\x18\x03 \x01(\bR\x12directionOutFacing\"\xa9\x03\n" +
+	"\x10SequenceRecovery\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12%\n" +
+	"\x0estream_handles\x18\x02 \x03(\rR\rstreamHandles\x12\x19\n" +
+	"\bport_ids\x18\x03 \x03(\tR\aportIds\x120\n" +
+	"\x14direction_out_facing\x18\x04 \x01(\bR\x12directionOutFacing\x129\n" +
+	"\talgorithm\x18\x05 \x01(\x0e2\x1b.tsn.frer.RecoveryAlgorithmR\talgorithm\x12?\n" +
+	"\n" +
+	"parameters\x18\x06 \x01(\v2\x1f.tsn.frer.FrerRecoveryAlgorithmR\n" +
+	"parameters\x12(\n" +
+	"\x10take_no_sequence\x18\a \x01(\bR\x0etakeNoSequence\x12/\n" +
+	"\x13individual_recovery\x18\b \x01(\bR\x12individualRecovery\x124\n" +
+	"\x16latent_error_detection\x18\t \x01(\bR\x14latentErrorDetection\"\xe9\x01\n" +
+	"\x16SequenceIdentification\x12\x17\n" +
+	"\aport_id\x18\x01 \x01(\tR\x06portId\x120\n" +
+	"\x14direction_out_facing\x18\x02 \x01(\bR\x12directionOutFacing\x12%\n" +
+	"\x0estream_handles\x18\x03 \x03(\rR\rstreamHandles\x12\x16\n" +
+	"\x06active\x18\x04 \x01(\bR\x06active\x12E\n" +
+	"\rencapsulation\x18\x05 \x01(\x0e2\x1f.tsn.frer.SequenceEncapsulationR\rencapsulation\"\xd4\x02\n" +
+	"\x10FrerBridgeConfig\x12E\n" +
+	"\x11stream_identities\x18\x01 \x03(\v2\x18.tsn.frer.StreamIdentityR\x10streamIdentities\x12O\n" +
+	"\x14sequence_generations\x18\x02 \x03(\v2\x1c.tsn.frer.SequenceGenerationR\x13sequenceGenerations\x12K\n" +
+	"\x13sequence_recoveries\x18\x03 \x03(\v2\x1a.tsn.frer.SequenceRecoveryR\x12sequenceRecoveries\x12[\n" +
+	"\x18sequence_identifications\x18\x04 \x03(\v2 .tsn.frer.SequenceIdentificationR\x17sequenceIdentifications*`\n" +
+	"\bFrerMode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10MAC_DA_SELECTION\x10\x01\x12\x15\n" +
+	"\x11VLAN_ID_SELECTION\x10\x02\x12\x11\n" +
+	"\rEXPLICIT_PATH\x10\x03*g\n" +
+	"\x18StreamIdentificationType\x12%\n" +
+	"!STREAM_IDENTIFICATION_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vNULL_STREAM\x10\x01\x12\x13\n" +
+	"\x0fSOURCE_MAC_VLAN\x10\x02*N\n" +
+	"\vVlanTagging\x12\x1c\n" +
+	"\x18VLAN_TAGGING_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06TAGGED\x10\x01\x12\f\n" +
+	"\bPRIORITY\x10\x02\x12\a\n" +
+	"\x03ALL\x10\x03*N\n" +
+	"\x11RecoveryAlgorithm\x12\"\n" +
+	"\x1eRECOVERY_ALGORITHM_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06VECTOR\x10\x01\x12\t\n" +
+	"\x05MATCH\x10\x02*z\n" +
+	"\x15SequenceEncapsulation\x12&\n" +
+	"\"SEQUENCE_ENCAPSULATION_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05R_TAG\x10\x01\x12\x14\n" +
+	"\x10HSR_SEQUENCE_TAG\x10\x02\x12\x18\n" +
+	"\x14PRP_SEQUENCE_TRAILER\x10\x03B4Z2OpenCNC_config_service/common/structures/frer;frerb\x06proto3"
+
+var (
+	file_common_structures_frer_frer_proto_rawDescOnce sync.Once
+	file_common_structures_frer_frer_proto_rawDescData []byte
+)
+
+func file_common_structures_frer_frer_proto_rawDescGZIP() []byte {
+	file_common_structures_frer_frer_proto_rawDescOnce.Do(func() {
+		file_common_structures_frer_frer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_frer_frer_proto_rawDesc), len(file_common_structures_frer_frer_proto_rawDesc)))
+	})
+	return file_common_structures_frer_frer_proto_rawDescData
+}
+
+var file_common_structures_frer_frer_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_common_structures_frer_frer_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_common_structures_frer_frer_proto_goTypes = []any{
+	(FrerMode)(0),                   // 0: tsn.frer.FrerMode
+	(StreamIdentificationType)(0),   // 1: tsn.frer.StreamIdentificationType
+	(VlanTagging)(0),                // 2: tsn.frer.VlanTagging
+	(RecoveryAlgorithm)(0),          // 3: tsn.frer.RecoveryAlgorithm
+	(SequenceEncapsulation)(0),      // 4: tsn.frer.SequenceEncapsulation
+	(*StreamID)(nil),                // 5: tsn.frer.StreamID
+	(*FrerRecoveryAlgorithm)(nil),   // 6: tsn.frer.FrerRecoveryAlgorithm
+	(*RedundancyGroup)(nil),         // 7: tsn.frer.RedundancyGroup
+	(*FrerStreamConfig)(nil),        // 8: tsn.frer.FrerStreamConfig
+	(*FrerPathSelection)(nil),       // 9: tsn.frer.FrerPathSelection
+	(*FrerProfilePolicy)(nil),       // 10: tsn.frer.FrerProfilePolicy
+	(*FrerGlobalConfiguration)(nil), // 11: tsn.frer.FrerGlobalConfiguration
+	(*StreamIdentity)(nil),          // 12: tsn.frer.StreamIdentity
+	(*SequenceGeneration)(nil),      // 13: tsn.frer.SequenceGeneration
+	(*SequenceRecovery)(nil),        // 14: tsn.frer.SequenceRecovery
+	(*SequenceIdentification)(nil),  // 15: tsn.frer.SequenceIdentification
+	(*FrerBridgeConfig)(nil),        // 16: tsn.frer.FrerBridgeConfig
+}
+var file_common_structures_frer_frer_proto_depIdxs = []int32{
+	5,  // 0: tsn.frer.RedundancyGroup.member_stream_ids:type_name -> tsn.frer.StreamID
+	6,  // 1: tsn.frer.RedundancyGroup.recovery_algorithm:type_name -> tsn.frer.FrerRecoveryAlgorithm
+	9,  // 2: tsn.frer.FrerStreamConfig.path_selections:type_name -> tsn.frer.FrerPathSelection
+	0,  // 3: tsn.frer.FrerStreamConfig.mode:type_name -> tsn.frer.FrerMode
+	6,  // 4: tsn.frer.FrerGlobalConfiguration.default_algorithm:type_name -> tsn.frer.FrerRecoveryAlgorithm
+	7,  // 5: tsn.frer.FrerGlobalConfiguration.redundancy_groups:type_name -> tsn.frer.RedundancyGroup
+	5,  // 6: tsn.frer.StreamIdentity.stream:type_name -> tsn.frer.StreamID
+	1,  // 7: tsn.frer.StreamIdentity.type:type_name -> tsn.frer.StreamIdentificationType
+	2,  // 8: tsn.frer.StreamIdentity.tagging:type_name -> tsn.frer.VlanTagging
+	3,  // 9: tsn.frer.SequenceRecovery.algorithm:type_name -> tsn.frer.RecoveryAlgorithm
+	6,  // 10: tsn.frer.SequenceRecovery.parameters:type_name -> tsn.frer.FrerRecoveryAlgorithm
+	4,  // 11: tsn.frer.SequenceIdentification.encapsulation:type_name -> tsn.frer.SequenceEncapsulation
+	12, // 12: tsn.frer.FrerBridgeConfig.stream_identities:type_name -> tsn.frer.StreamIdentity
+	13, // 13: tsn.frer.FrerBridgeConfig.sequence_generations:type_name -> tsn.frer.SequenceGeneration
+	14, // 14: tsn.frer.FrerBridgeConfig.sequence_recoveries:type_name -> tsn.frer.SequenceRecovery
+	15, // 15: tsn.frer.FrerBridgeConfig.sequence_identifications:type_name -> tsn.frer.SequenceIdentification
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_frer_frer_proto_init() }
+func file_common_structures_frer_frer_proto_init() {
+	if File_common_structures_frer_frer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_frer_frer_proto_rawDesc), len(file_common_structures_frer_frer_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_frer_frer_proto_goTypes,
+		DependencyIndexes: file_common_structures_frer_frer_proto_depIdxs,
+		EnumInfos:         file_common_structures_frer_frer_proto_enumTypes,
+		MessageInfos:      file_common_structures_frer_frer_proto_msgTypes,
+	}.Build()
+	File_common_structures_frer_frer_proto = out.File
+	file_common_structures_frer_frer_proto_goTypes = nil
+	file_common_structures_frer_frer_proto_depIdxs = nil
+}
@@ -0,0 +1,292 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/frer/frer_status.proto
+
+package frer
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Per-stream runtime state (talker, relay, or listener)
+type FrerStreamStatus struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	StreamId            *StreamID              `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`                                   // Reference to the stream
+	NodeId              string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`                                         // Node reporting the status
+	PortId              string                 `protobuf:"bytes,3,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`                                         // Port reporting the status
+	LastSequenceNumber  uint64                 `protobuf:"varint,4,opt,name=last_sequence_number,json=lastSequenceNumber,proto3" json:"last_sequence_number,omitempty"`  // Last received sequence number
+	FramesReceived      uint64                 `protobuf:"varint,5,opt,name=frames_received,json=framesReceived,proto3" json:"frames_received,omitempty"`                // Total frames received
+	FramesRecovered     uint64                 `protobuf:"varint,6,opt,name=frames_recovered,json=framesRecovered,proto3" json:"frames_recovered,omitempty"`             // Frames successfully recovered by FRER
+	DuplicatesDiscarded uint64                 `protobuf:"varint,7,opt,name=duplicates_discarded,json=duplicatesDiscarded,proto3" json:"duplicates_discarded,omitempty"` // Duplicate frames discarded
+	OutOfOrderDropped   uint64                 `protobuf:"varint,8,opt,name=out_of_order_dropped,json=outOfOrderDropped,proto3" json:"out_of_order_dropped,omitempty"`   // Out-of-order frames dropped
+	StatusTimestampNs   uint64                 `protobuf:"varint,9,opt,name=status_timestamp_ns,json=statusTimestampNs,proto3" json:"status_timestamp_ns,omitempty"`     // Timestamp of this status (nanoseconds since epoch)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FrerStreamStatus) Reset() {
+	*x = FrerStreamStatus{}
+	mi := &file_common_structures_frer_frer_status_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerStreamStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerStreamStatus) ProtoMessage() {}
+
+func (x *FrerStreamStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_status_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerStreamStatus.ProtoReflect.Descriptor instead.
+func (*FrerStreamStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FrerStreamStatus) GetStreamId() *StreamID {
+	if x != nil {
+		return x.StreamId
+	}
+	return nil
+}
+
+func (x *FrerStreamStatus) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *FrerStreamStatus) GetPortId() string {
+	if x != nil {
+		return x.PortId
+	}
+	return ""
+}
+
+func (x *FrerStreamStatus) GetLastSequenceNumber() uint64 {
+	if x != nil {
+		return x.LastSequenceNumber
+	}
+	return 0
+}
+
+func (x *FrerStreamStatus) GetFramesReceived() uint64 {
+	if x != nil {
+		return x.FramesReceived
+	}
+	return 0
+}
+
+func (x *FrerStreamStatus) GetFramesRecovered() uint64 {
+	if x != nil {
+		return x.FramesRecovered
+	}
+	return 0
+}
+
+func (x *FrerStreamStatus) GetDuplicatesDiscarded() uint64 {
+	if x != nil {
+		return x.DuplicatesDiscarded
+	}
+	return 0
+}
+
+func (x *FrerStreamStatus) GetOutOfOrderDropped() uint64 {
+	if x != nil {
+		return x.OutOfOrderDropped
+	}
+	return 0
+}
+
+func (x *FrerStreamStatus) GetStatusTimestampNs() uint64 {
+	if x != nil {
+		return x.StatusTimestampNs
+	}
+	return 0
+}
+
+// Aggregated FRER group status (optional)
+type FrerGroupStatus struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	GroupId              string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                                           // Group identifier
+	StreamStatuses       []*FrerStreamStatus    `protobuf:"bytes,2,rep,name=stream_statuses,json=streamStatuses,proto3" json:"stream_statuses,omitempty"`                      // Statuses of all streams in the group
+	TotalRecoveredFrames uint64                 `protobuf:"varint,3,opt,name=total_recovered_frames,json=totalRecoveredFrames,proto3" json:"total_recovered_frames,omitempty"` // Total recovered frames across all streams
+	TotalDuplicateFrames uint64                 `protobuf:"varint,4,opt,name=total_duplicate_frames,json=totalDuplicateFrames,proto3" json:"total_duplicate_frames,omitempty"` // Total duplicate frames discarded
+	TotalDroppedFrames   uint64                 `protobuf:"varint,5,opt,name=total_dropped_frames,json=totalDroppedFrames,proto3" json:"total_dropped_frames,omitempty"`       // Total frames dropped due to errors
+	LastUpdatedNs        uint64                 `protobuf:"varint,6,opt,name=last_updated_ns,json=lastUpdatedNs,proto3" json:"last_updated_ns,omitempty"`                      // Last update timestamp (nanoseconds since epoch)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *FrerGroupStatus) Reset() {
+	*x = FrerGroupStatus{}
+	mi := &file_common_structures_frer_frer_status_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrerGroupStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrerGroupStatus) ProtoMessage() {}
+
+func (x *FrerGroupStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_frer_frer_status_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrerGroupStatus.ProtoReflect.Descriptor instead.
+func (*FrerGroupStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_frer_frer_status_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FrerGroupStatus) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *FrerGroupStatus) GetStreamStatuses() []*FrerStreamStatus {
+	if x != nil {
+		return x.StreamStatuses
+	}
+	return nil
+}
+
+func (x *FrerGroupStatus) GetTotalRecoveredFrames() uint64 {
+	if x != nil {
+		return x.TotalRecoveredFrames
+	}
+	return 0
+}
+
+func (x *FrerGroupStatus) GetTotalDuplicateFrames() uint64 {
+	if x != nil {
+		return x.TotalDuplicateFrames
+	}
+	return 0
+}
+
+func (x *FrerGroupStatus) GetTotalDroppedFrames() uint64 {
+	if x != nil {
+		return x.TotalDroppedFrames
+	}
+	return 0
+}
+
+func (x *FrerGroupStatus) GetLastUpdatedNs() uint64 {
+	if x != nil {
+		return x.LastUpdatedNs
+	}
+	return 0
+}
+
+var File_common_structures_frer_frer_status_proto protoreflect.FileDescriptor
+
+const file_common_structures_frer_frer_status_proto_rawDesc = "" +
+	"\n" +
+	"(common/structures/frer/frer_status.proto\x12\x0ftsn.frer_status\x1a!common/structures/frer/frer.proto\"\x8f\x03\n" +
+	"\x10FrerStreamStatus\x12/\n" +
+	"\tstream_id\x18\x01 \x01(\v2\x12.tsn.frer.StreamIDR\bstreamId\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x17\n" +
+	"\aport_id\x18\x03 \x01(\tR\x06portId\x120\n" +
+	"\x14last_sequence_number\x18\x04 \x01(\x04R\x12lastSequenceNumber\x12'\n" +
+	"\x0fframes_received\x18\x05 \x01(\x04R\x0eframesReceived\x12)\n" +
+	"\x10frames_recovered\x18\x06 \x01(\x04R\x0fframesRecovered\x121\n" +
+	"\x14duplicates_discarded\x18\a \x01(\x04R\x13duplicatesDiscarded\x12/\n" +
+	"\x14out_of_order_dropped\x18\b \x01(\x04R\x11outOfOrderDropped\x12.\n" +
+	"\x13status_timestamp_ns\x18\t \x01(\x04R\x11statusTimestampNs\"\xbe\x02\n" +
+	"\x0fFrerGroupStatus\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12J\n" +
+	"\x0fstream_statuses\x18\x02 \x03(\v2!.tsn.frer_status.FrerStreamStatusR\x0estreamStatuses\x124\n" +
+	"\x16total_recovered_frames\x18\x03 \x01(\x04R\x14totalRecoveredFrames\x124\n" +
+	"\x16total_duplicate_frames\x18\x04 \x01(\x04R\x14totalDuplicateFrames\x120\n" +
+	"\x14total_dropped_frames\x18\x05 \x01(\x04R\x12totalDroppedFrames\x12&\n" +
+	"\x0flast_updated_ns\x18\x06 \x01(\x04R\rlastUpdatedNsB4Z2OpenCNC_config_service/common/structures/frer;frerb\x06proto3"
+
+var (
+	file_common_structures_frer_frer_status_proto_rawDescOnce sync.Once
+	file_common_structures_frer_frer_status_proto_rawDescData []byte
+)
+
+func file_common_structures_frer_frer_status_proto_rawDescGZIP() []byte {
+	file_common_structures_frer_frer_status_proto_rawDescOnce.Do(func() {
+		file_common_structures_frer_frer_status_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_frer_frer_status_proto_rawDesc), len(file_common_structures_frer_frer_status_proto_rawDesc)))
+	})
+	return file_common_structures_frer_frer_status_proto_rawDescData
+}
+
+var file_common_structures_frer_frer_status_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_common_structures_frer_frer_status_proto_goTypes = []any{
+	(*FrerStreamStatus)(nil), // 0: tsn.frer_status.FrerStreamStatus
+	(*FrerGroupStatus)(nil),  // 1: tsn.frer_status.FrerGroupStatus
+	(*StreamID)(nil),         // 2: tsn.frer.StreamID
+}
+var file_common_structures_frer_frer_status_proto_depIdxs = []int32{
+	2, // 0: tsn.frer_status.FrerStreamStatus.stream_id:type_name -> tsn.frer.StreamID
+	0, // 1: tsn.frer_status.FrerGroupStatus.stream_statuses:type_name -> tsn.frer_status.FrerStreamStatus
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_frer_frer_status_proto_init() }
+func file_common_structures_frer_frer_status_proto_init() {
+	if File_common_structures_frer_frer_status_proto != nil {
+		return
+	}
+	file_common_structures_frer_frer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_frer_frer_status_proto_rawDesc), len(file_common_structures_frer_frer_status_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_frer_frer_status_proto_goTypes,
+		DependencyIndexes: file_common_structures_frer_frer_status_proto_depIdxs,
+		MessageInfos:      file_common_structures_frer_frer_status_proto_msgTypes,
+	}.Build()
+	File_common_structures_frer_frer_status_proto = out.File
+	file_common_structures_frer_frer_status_proto_goTypes = nil
+	file_common_structures_frer_frer_status_proto_depIdxs = nil
+}
@@ -0,0 +1,863 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/psfp/psfp.proto
+
+package psfp
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Gate slot state (Clause 8.6.5.1)
+type GateState int32
+
+const (
+	GateState_GATE_STATE_UNSPECIFIED GateState = 0
+	GateState_OPEN                   GateState = 1
+	GateState_CLOSED                 GateState = 2
+)
+
+// Enum value maps for GateState.
+var (
+	GateState_name = map[int32]string{
+		0: "GATE_STATE_UNSPECIFIED",
+		1: "OPEN",
+		2: "CLOSED",
+	}
+	GateState_value = map[string]int32{
+		"GATE_STATE_UNSPECIFIED": 0,
+		"OPEN":                   1,
+		"CLOSED":                 2,
+	}
+)
+
+func (x GateState) Enum() *GateState {
+	p := new(GateState)
+	*p = x
+	return p
+}
+
+func (x GateState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GateState) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_psfp_psfp_proto_enumTypes[0].Descriptor()
+}
+
+func (GateState) Type() protoreflect.EnumType {
+	return &file_common_structures_psfp_psfp_proto_enumTypes[0]
+}
+
+func (x GateState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GateState.Descriptor instead.
+func (GateState) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{0}
+}
+
+type ExceededAction int32
+
+const (
+	ExceededAction_ACTION_UNSPECIFIED ExceededAction = 0
+	ExceededAction_DROP               ExceededAction = 1 // Drop packets
+	ExceededAction_MARK               ExceededAction = 2 // Mark packets as exceeded (e.g., color marking)
+	ExceededAction_POLICE             ExceededAction = 3 // Apply further shaping/policing
+)
+
+// Enum value maps for ExceededAction.
+var (
+	ExceededAction_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "DROP",
+		2: "MARK",
+		3: "POLICE",
+	}
+	ExceededAction_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"DROP":               1,
+		"MARK":               2,
+		"POLICE":             3,
+	}
+)
+
+func (x ExceededAction) Enum() *ExceededAction {
+	p := new(ExceededAction)
+	*p = x
+	return p
+}
+
+func (x ExceededAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExceededAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_psfp_psfp_proto_enumTypes[1].Descriptor()
+}
+
+func (ExceededAction) Type() protoreflect.EnumType {
+	return &file_common_structures_psfp_psfp_proto_enumTypes[1]
+}
+
+func (x ExceededAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExceededAction.Descriptor instead.
+func (ExceededAction) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{1}
+}
+
+// Match criteria for identifying frames (Clause 8.6.5.1)
+type MatchCriteria struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VlanId        *uint32                `protobuf:"varint,1,opt,name=vlan_id,json=vlanId,proto3,oneof" json:"vlan_id,omitempty"`                       // VLAN ID from 802.1Q tag
+	Priority      *uint32                `protobuf:"varint,2,opt,name=priority,proto3,oneof" json:"priority,omitempty"`                                 // PCP: Priority Code Point (0–7)
+	DstMac        *string                `protobuf:"bytes,3,opt,name=dst_mac,json=dstMac,proto3,oneof" json:"dst_mac,omitempty"`                        // Destination MAC address
+	SrcMac        *string                `protobuf:"bytes,4,opt,name=src_mac,json=srcMac,proto3,oneof" json:"src_mac,omitempty"`                        // Source MAC address (future expansion)
+	Ethertype     *string                `protobuf:"bytes,5,opt,name=ethertype,proto3,oneof" json:"ethertype,omitempty"`                                // Ethertype match (e.g., 0x0800 for IPv4)
+	IngressPortId *string                `protobuf:"bytes,6,opt,name=ingress_port_id,json=ingressPortId,proto3,oneof" json:"ingress_port_id,omitempty"` // Optional: ingress port match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchCriteria) Reset() {
+	*x = MatchCriteria{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchCriteria) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchCriteria) ProtoMessage() {}
+
+func (x *MatchCriteria) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchCriteria.ProtoReflect.Descriptor instead.
+func (*MatchCriteria) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MatchCriteria) GetVlanId() uint32 {
+	if x != nil && x.VlanId != nil {
+		return *x.VlanId
+	}
+	return 0
+}
+
+func (x *MatchCriteria) GetPriority() uint32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *MatchCriteria) GetDstMac() string {
+	if x != nil && x.DstMac != nil {
+		return *x.DstMac
+	}
+	return ""
+}
+
+func (x *MatchCriteria) GetSrcMac() string {
+	if x != nil && x.SrcMac != nil {
+		return *x.SrcMac
+	}
+	return ""
+}
+
+func (x *MatchCriteria) GetEthertype() string {
+	if x != nil && x.Ethertype != nil {
+		return *x.Ethertype
+	}
+	return ""
+}
+
+func (x *MatchCriteria) GetIngressPortId() string {
+	if x != nil && x.IngressPortId != nil {
+		return *x.IngressPortId
+	}
+	return ""
+}
+
+// Defines rules for frame validation and exceed behavior
+type FilterSpecification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         *MatchCriteria         `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	MaxSduSize    uint32                 `protobuf:"varint,2,opt,name=max_sdu_size,json=maxSduSize,proto3" json:"max_sdu_size,omitempty"`          // Max allowed frame size (bytes)
+	BlockOnExceed bool                   `protobuf:"varint,3,opt,name=block_on_exceed,json=blockOnExceed,proto3" json:"block_on_exceed,omitempty"` // If true, block entire stream after violation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterSpecification) Reset() {
+	*x = FilterSpecification{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterSpecification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterSpecification) ProtoMessage() {}
+
+func (x *FilterSpecification) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterSpecification.ProtoReflect.Descriptor instead.
+func (*FilterSpecification) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterSpecification) GetMatch() *MatchCriteria {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *FilterSpecification) GetMaxSduSize() uint32 {
+	if x != nil {
+		return x.MaxSduSize
+	}
+	return 0
+}
+
+func (x *FilterSpecification) GetBlockOnExceed() bool {
+	if x != nil {
+		return x.BlockOnExceed
+	}
+	return false
+}
+
+// Describes a single gate control entry for stream gating
+type GateControlEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TimeIntervalNs uint64                 `protobuf:"varint,1,opt,name=time_interval_ns,json=timeIntervalNs,proto3" json:"time_interval_ns,omitempty"`    // Slot duration in nanoseconds
+	GateState      GateState              `protobuf:"varint,2,opt,name=gate_state,json=gateState,proto3,enum=psfp.GateState" json:"gate_state,omitempty"` // Gate open/closed state for this slot
+	// Priority override during this slot.
+	// Matches "internal priority" from Clause 8.6.5.1 and YANG "priority-spec"
+	PriorityOverride *uint32 `protobuf:"varint,3,opt,name=priority_override,json=priorityOverride,proto3,oneof" json:"priority_override,omitempty"` // Queue priority to assign during slot (e.g., 0–7); frames keep theirs if unset
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GateControlEntry) Reset() {
+	*x = GateControlEntry{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GateControlEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GateControlEntry) ProtoMessage() {}
+
+func (x *GateControlEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GateControlEntry.ProtoReflect.Descriptor instead.
+func (*GateControlEntry) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GateControlEntry) GetTimeIntervalNs() uint64 {
+	if x != nil {
+		return x.TimeIntervalNs
+	}
+	return 0
+}
+
+func (x *GateControlEntry) GetGateState() GateState {
+	if x != nil {
+		return x.GateState
+	}
+	return GateState_GATE_STATE_UNSPECIFIED
+}
+
+func (x *GateControlEntry) GetPriorityOverride() uint32 {
+	if x != nil && x.PriorityOverride != nil {
+		return *x.PriorityOverride
+	}
+	return 0
+}
+
+// Gate control instance for a stream
+type StreamGateInstance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique identifier for this gate instance
+	// Initial gate states bitmap (LSB = TC0). Follows 802.1Qci Annex F YANG
+	AdminGateStates []byte              `protobuf:"bytes,2,opt,name=admin_gate_states,json=adminGateStates,proto3" json:"admin_gate_states,omitempty"`
+	GateControlList []*GateControlEntry `protobuf:"bytes,3,rep,name=gate_control_list,json=gateControlList,proto3" json:"gate_control_list,omitempty"`
+	// Optional scheduling parameters (aligned with QBV/Qci YANG)
+	BaseTimeNs    uint64 `protobuf:"varint,4,opt,name=base_time_ns,json=baseTimeNs,proto3" json:"base_time_ns,omitempty"`    // Reference start time of schedule
+	CycleTimeNs   uint64 `protobuf:"varint,5,opt,name=cycle_time_ns,json=cycleTimeNs,proto3" json:"cycle_time_ns,omitempty"` // Cycle duration (nanoseconds)
+	Description   string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                       // Human-readable explanation
+	InstanceId    uint32 `protobuf:"varint,7,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`      // stream-gate-instance-id, unique per bridge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamGateInstance) Reset() {
+	*x = StreamGateInstance{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamGateInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamGateInstance) ProtoMessage() {}
+
+func (x *StreamGateInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamGateInstance.ProtoReflect.Descriptor instead.
+func (*StreamGateInstance) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamGateInstance) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreamGateInstance) GetAdminGateStates() []byte {
+	if x != nil {
+		return x.AdminGateStates
+	}
+	return nil
+}
+
+func (x *StreamGateInstance) GetGateControlList() []*GateControlEntry {
+	if x != nil {
+		return x.GateControlList
+	}
+	return nil
+}
+
+func (x *StreamGateInstance) GetBaseTimeNs() uint64 {
+	if x != nil {
+		return x.BaseTimeNs
+	}
+	return 0
+}
+
+func (x *StreamGateInstance) GetCycleTimeNs() uint64 {
+	if x != nil {
+		return x.CycleTimeNs
+	}
+	return 0
+}
+
+func (x *StreamGateInstance) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StreamGateInstance) GetInstanceId() uint32 {
+	if x != nil {
+		return x.InstanceId
+	}
+	return 0
+}
+
+// Metering: defines CIR/PIR and actions when limits are exceeded
+type FlowMeterInstance struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                     // Unique meter instance name
+	Cir            uint64                 `protobuf:"varint,2,opt,name=cir,proto3" json:"cir,omitempty"`                                                                      // Committed Information Rate (bps)
+	Cbs            uint64                 `protobuf:"varint,3,opt,name=cbs,proto3" json:"cbs,omitempty"`                                                                      // Committed Burst Size (bytes)
+	ExceededAction ExceededAction         `protobuf:"varint,4,opt,name=exceeded_action,json=exceededAction,proto3,enum=psfp.ExceededAction" json:"exceeded_action,omitempty"` // Action on exceeding committed rate
+	Pir            uint64                 `protobuf:"varint,5,opt,name=pir,proto3" json:"pir,omitempty"`                                                                      // Peak Information Rate (bps)
+	Pbs            uint64                 `protobuf:"varint,6,opt,name=pbs,proto3" json:"pbs,omitempty"`                                                                      // Peak Burst Size (bytes)
+	Description    string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`                                                       // Optional notes or comments
+	InstanceId     uint32                 `protobuf:"varint,8,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`                                      // flow-meter-instance-id, unique per bridge
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FlowMeterInstance) Reset() {
+	*x = FlowMeterInstance{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowMeterInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowMeterInstance) ProtoMessage() {}
+
+func (x *FlowMeterInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowMeterInstance.ProtoReflect.Descriptor instead.
+func (*FlowMeterInstance) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FlowMeterInstance) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FlowMeterInstance) GetCir() uint64 {
+	if x != nil {
+		return x.Cir
+	}
+	return 0
+}
+
+func (x *FlowMeterInstance) GetCbs() uint64 {
+	if x != nil {
+		return x.Cbs
+	}
+	return 0
+}
+
+func (x *FlowMeterInstance) GetExceededAction() ExceededAction {
+	if x != nil {
+		return x.ExceededAction
+	}
+	return ExceededAction_ACTION_UNSPECIFIED
+}
+
+func (x *FlowMeterInstance) GetPir() uint64 {
+	if x != nil {
+		return x.Pir
+	}
+	return 0
+}
+
+func (x *FlowMeterInstance) GetPbs() uint64 {
+	if x != nil {
+		return x.Pbs
+	}
+	return 0
+}
+
+func (x *FlowMeterInstance) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FlowMeterInstance) GetInstanceId() uint32 {
+	if x != nil {
+		return x.InstanceId
+	}
+	return 0
+}
+
+// Complete stream filter instance (Clause 8.6.5.1)
+type StreamFilterInstance struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Index             uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                                   // Unique per device
+	FilterSpec        *FilterSpecification   `protobuf:"bytes,2,opt,name=filter_spec,json=filterSpec,proto3" json:"filter_spec,omitempty"`                        // Identification + validation rules
+	GateInstanceName  string                 `protobuf:"bytes,3,opt,name=gate_instance_name,json=gateInstanceName,proto3" json:"gate_instance_name,omitempty"`    // Reference to StreamGateInstance
+	MeterInstanceName string                 `protobuf:"bytes,4,opt,name=meter_instance_name,json=meterInstanceName,proto3" json:"meter_instance_name,omitempty"` // Reference to FlowMeterInstance
+	EgressQueueId     string                 `protobuf:"bytes,5,opt,name=egress_queue_id,json=egressQueueId,proto3" json:"egress_queue_id,omitempty"`             // egress queue id == traffic class
+	IngressPortId     string                 `protobuf:"bytes,6,opt,name=ingress_port_id,json=ingressPortId,proto3" json:"ingress_port_id,omitempty"`             // Apply this filter on ingress port
+	Enabled           bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`                                               // Defaults to true
+	Description       string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`                                        // Optional human-readable comment
+	StreamHandle      *uint32                `protobuf:"varint,9,opt,name=stream_handle,json=streamHandle,proto3,oneof" json:"stream_handle,omitempty"`           // Stream (802.1CB stream identification) to filter; any stream if unset
+	AtsSchedulerId    *uint32                `protobuf:"varint,10,opt,name=ats_scheduler_id,json=atsSchedulerId,proto3,oneof" json:"ats_scheduler_id,omitempty"`  // ATS scheduler (802.1Qcr) shaping the stream, see ats.proto
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StreamFilterInstance) Reset() {
+	*x = StreamFilterInstance{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFilterInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFilterInstance) ProtoMessage() {}
+
+func (x *StreamFilterInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFilterInstance.ProtoReflect.Descriptor instead.
+func (*StreamFilterInstance) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamFilterInstance) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *StreamFilterInstance) GetFilterSpec() *FilterSpecification {
+	if x != nil {
+		return x.FilterSpec
+	}
+	return nil
+}
+
+func (x *StreamFilterInstance) GetGateInstanceName() string {
+	if x != nil {
+		return x.GateInstanceName
+	}
+	return ""
+}
+
+func (x *StreamFilterInstance) GetMeterInstanceName() string {
+	if x != nil {
+		return x.MeterInstanceName
+	}
+	return ""
+}
+
+func (x *StreamFilterInstance) GetEgressQueueId() string {
+	if x != nil {
+		return x.EgressQueueId
+	}
+	return ""
+}
+
+func (x *StreamFilterInstance) GetIngressPortId() string {
+	if x != nil {
+		return x.IngressPortId
+	}
+	return ""
+}
+
+func (x *StreamFilterInstance) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *StreamFilterInstance) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StreamFilterInstance) GetStreamHandle() uint32 {
+	if x != nil && x.StreamHandle != nil {
+		return *x.StreamHandle
+	}
+	return 0
+}
+
+func (x *StreamFilterInstance) GetAtsSchedulerId() uint32 {
+	if x != nil && x.AtsSchedulerId != nil {
+		return *x.AtsSchedulerId
+	}
+	return 0
+}
+
+// PSFP configuration of a bridge: stream filters with the gates and meters
+// they reference by name.
+type PsfpConfig struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	StreamFilters []*StreamFilterInstance `protobuf:"bytes,1,rep,name=stream_filters,json=streamFilters,proto3" json:"stream_filters,omitempty"`
+	StreamGates   []*StreamGateInstance   `protobuf:"bytes,2,rep,name=stream_gates,json=streamGates,proto3" json:"stream_gates,omitempty"`
+	FlowMeters    []*FlowMeterInstance    `protobuf:"bytes,3,rep,name=flow_meters,json=flowMeters,proto3" json:"flow_meters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsfpConfig) Reset() {
+	*x = PsfpConfig{}
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsfpConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsfpConfig) ProtoMessage() {}
+
+func (x *PsfpConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsfpConfig.ProtoReflect.Descriptor instead.
+func (*PsfpConfig) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PsfpConfig) GetStreamFilters() []*StreamFilterInstance {
+	if x != nil {
+		return x.StreamFilters
+	}
+	return nil
+}
+
+func (x *PsfpConfig) GetStreamGates() []*StreamGateInstance {
+	if x != nil {
+		return x.StreamGates
+	}
+	return nil
+}
+
+func (x *PsfpConfig) GetFlowMeters() []*FlowMeterInstance {
+	if x != nil {
+		return x.FlowMeters
+	}
+	return nil
+}
+
+var File_common_structures_psfp_psfp_proto protoreflect.FileDescriptor
+
+const file_common_structures_psfp_psfp_proto_rawDesc = "" +
+	"\n" +
+	"!common/structures/psfp/psfp.proto\x12\x04psfp\"\xad\x02\n" +
+	"\rMatchCriteria\x12\x1c\n" +
+	"\avlan_id\x18\x01 \x01(\rH\x00R\x06vlanId\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x02 \x01(\rH\x01R\bpriority\x88\x01\x01\x12\x1c\n" +
+	"\adst_mac\x18\x03 \x01(\tH\x02R\x06dstMac\x88\x01\x01\x12\x1c\n" +
+	"\asrc_mac\x18\x04 \x01(\tH\x03R\x06srcMac\x88\x01\x01\x12!\n" +
+	"\tethertype\x18\x05 \x01(\tH\x04R\tethertype\x88\x01\x01\x12+\n" +
+	"\x0fingress_port_id\x18\x06 \x01(\tH\x05R\ringressPortId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_vlan_idB\v\n" +
+	"\t_priorityB\n" +
+	"\n" +
+	"\b_dst_macB\n" +
+	"\n" +
+	"\b_src_macB\f\n" +
+	"\n" +
+	"_ethertypeB\x12\n" +
+	"\x10_ingress_port_id\"\x8a\x01\n" +
+	"\x13FilterSpecification\x12)\n" +
+	"\x05match\x18\x01 \x01(\v2\x13.psfp.MatchCriteriaR\x05match\x12 \n" +
+	"\fmax_sdu_size\x18\x02 \x01(\rR\n" +
+	"maxSduSize\x12&\n" +
+	"\x0fblock_on_exceed\x18\x03 \x01(\bR\rblockOnExceed\"\xb4\x01\n" +
+	"\x10GateControlEntry\x12(\n" +
+	"\x10time_interval_ns\x18\x01 \x01(\x04R\x0etimeIntervalNs\x12.\n" +
+	"\n" +
+	"gate_state\x18\x02 \x01(\x0e2\x0f.psfp.GateStateR\tgateState\x120\n" +
+	"\x11priority_override\x18\x03 \x01(\rH\x00R\x10priorityOverride\x88\x01\x01B\x14\n" +
+	"\x12_priority_override\"\xa1\x02\n" +
+	"\x12StreamGateInstance\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x11admin_gate_states\x18\x02 \x01(\fR\x0fadminGateStates\x12B\n" +
+	"\x11gate_control_list\x18\x03 \x03(\v2\x16.psfp.GateControlEntryR\x0fgateControlList\x12 \n" +
+	"\fbase_time_ns\x18\x04 \x01(\x04R\n" +
+	"baseTimeNs\x12\"\n" +
+	"\rcycle_time_ns\x18\x05 \x01(\x04R\vcycleTimeNs\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vinstance_id\x18\a \x01(\rR\n" +
+	"instanceId\"\xf1\x01\n" +
+	"\x11FlowMeterInstance\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03cir\x18\x02 \x01(\x04R\x03cir\x12\x10\n" +
+	"\x03cbs\x18\x03 \x01(\x04R\x03cbs\x12=\n" +
+	"\x0fexceeded_action\x18\x04 \x01(\x0e2\x14.psfp.ExceededActionR\x0eexceededAction\x12\x10\n" +
+	"\x03pir\x18\x05 \x01(\x04R\x03pir\x12\x10\n" +
+	"\x03pbs\x18\x06 \x01(\x04R\x03pbs\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x1f\n" +
+	"\vinstance_id\x18\b \x01(\rR\n" +
+	"instanceId\"\xd2\x03\n" +
+	"\x14StreamFilterInstance\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12:\n" +
+	"\vfilter_spec\x18\x02 \x01(\v2\x19.psfp.FilterSpecificationR\n" +
+	"filterSpec\x12,\n" +
+	"\x12gate_instance_name\x18\x03 \x01(\tR\x10gateInstanceName\x12.\n" +
+	"\x13meter_instance_name\x18\x04 \x01(\tR\x11meterInstanceName\x12&\n" +
+	"\x0fegress_queue_id\x18\x05 \x01(\tR\regressQueueId\x12&\n" +
+	"\x0fingress_port_id\x18\x06 \x01(\tR\ringressPortId\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12(\n" +
+	"\rstream_handle\x18\t \x01(\rH\x00R\fstreamHandle\x88\x01\x01\x12-\n" +
+	"\x10ats_scheduler_id\x18\n" +
+	" \x01(\rH\x01R\x0eatsSchedulerId\x88\x01\x01B\x10\n" +
+	"\x0e_stream_handleB\x13\n" +
+	"\x11_ats_scheduler_id\"\xc6\x01\n" +
+	"\n" +
+	"PsfpConfig\x12A\n" +
+	"\x0estream_filters\x18\x01 \x03(\v2\x1a.psfp.StreamFilterInstanceR\rstreamFilters\x12;\n" +
+	"\fstream_gates\x18\x02 \x03(\v2\x18.psfp.StreamGateInstanceR\vstreamGates\x128\n" +
+	"\vflow_meters\x18\x03 \x03(\v2\x17.psfp.FlowMeterInstanceR\n" +
+	"flowMeters*=\n" +
+	"\tGateState\x12\x1a\n" +
+	"\x16GATE_STATE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04OPEN\x10\x01\x12\n" +
+	"\n" +
+	"\x06CLOSED\x10\x02*H\n" +
+	"\x0eExceededAction\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04DROP\x10\x01\x12\b\n" +
+	"\x04MARK\x10\x02\x12\n" +
+	"\n" +
+	"\x06POLICE\x10\x03B4Z2OpenCNC_config_service/common/structures/psfp;psfpb\x06proto3"
+
+var (
+	file_common_structures_psfp_psfp_proto_rawDescOnce sync.Once
+	file_common_structures_psfp_psfp_proto_rawDescData []byte
+)
+
+func file_common_structures_psfp_psfp_proto_rawDescGZIP() []byte {
+	file_common_structures_psfp_psfp_proto_rawDescOnce.Do(func() {
+		file_common_structures_psfp_psfp_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_psfp_psfp_proto_rawDesc), len(file_common_structures_psfp_psfp_proto_rawDesc)))
+	})
+	return file_common_structures_psfp_psfp_proto_rawDescData
+}
+
+var file_common_structures_psfp_psfp_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_common_structures_psfp_psfp_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_common_structures_psfp_psfp_proto_goTypes = []any{
+	(GateState)(0),               // 0: psfp.GateState
+	(ExceededAction)(0),          // 1: psfp.ExceededAction
+	(*MatchCriteria)(nil),        // 2: psfp.MatchCriteria
+	(*FilterSpecification)(nil),  // 3: psfp.FilterSpecification
+	(*GateControlEntry)(nil),     // 4: psfp.GateControlEntry
+	(*StreamGateInstance)(nil),   // 5: psfp.StreamGateInstance
+	(*FlowMeterInstance)(nil),    // 6: psfp.FlowMeterInstance
+	(*StreamFilterInstance)(nil), // 7: psfp.StreamFilterInstance
+	(*PsfpConfig)(nil),           // 8: psfp.PsfpConfig
+}
+var file_common_structures_psfp_psfp_proto_depIdxs = []int32{
+	2, // 0: psfp.FilterSpecification.match:type_name -> psfp.MatchCriteria
+	0, // 1: psfp.GateControlEntry.gate_state:type_name -> psfp.GateState
+	4, // 2: psfp.StreamGateInstance.gate_control_list:type_name -> psfp.GateControlEntry
+	1, // 3: psfp.FlowMeterInstance.exceeded_action:type_name -> psfp.ExceededAction
+	3, // 4: psfp.StreamFilterInstance.filter_spec:type_name -> psfp.FilterSpecification
+	7, // 5: psfp.PsfpConfig.stream_filters:type_name -> psfp.StreamFilterInstance
+	5, // 6: psfp.PsfpConfig.stream_gates:type_name -> psfp.StreamGateInstance
+	6, // 7: psfp.PsfpConfig.flow_meters:type_name -> psfp.FlowMeterInstance
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_psfp_psfp_proto_init() }
+func file_common_structures_psfp_psfp_proto_init() {
+	if File_common_structures_psfp_psfp_proto != nil {
+		return
+	}
+	file_common_structures_psfp_psfp_proto_msgTypes[0].OneofWrappers = []any{}
+	file_common_structures_psfp_psfp_proto_msgTypes[2].OneofWrappers = []any{}
+	file_common_structures_psfp_psfp_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_psfp_psfp_proto_rawDesc), len(file_common_structures_psfp_psfp_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_psfp_psfp_proto_goTypes,
+		DependencyIndexes: file_common_structures_psfp_psfp_proto_depIdxs,
+		EnumInfos:         file_common_structures_psfp_psfp_proto_enumTypes,
+		MessageInfos:      file_common_structures_psfp_psfp_proto_msgTypes,
+	}.Build()
+	File_common_structures_psfp_psfp_proto = out.File
+	file_common_structures_psfp_psfp_proto_goTypes = nil
+	file_common_structures_psfp_psfp_proto_depIdxs = nil
+}
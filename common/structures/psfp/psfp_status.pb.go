@@ -0,0 +1,532 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/psfp/psfp_status.proto
+
+package psfp
+
+import (
+	sync "OpenCNC_config_service/common/structures/sync"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync1 "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TimestampReference int32
+
+const (
+	TimestampReference_UNKNOWN          TimestampReference = 0
+	TimestampReference_UNIX_EPOCH       TimestampReference = 1 // Timestamp is nanoseconds since Unix epoch
+	TimestampReference_DEVICE_BOOT_TIME TimestampReference = 2 // Timestamp is nanoseconds since device boot time
+)
+
+// Enum value maps for TimestampReference.
+var (
+	TimestampReference_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "UNIX_EPOCH",
+		2: "DEVICE_BOOT_TIME",
+	}
+	TimestampReference_value = map[string]int32{
+		"UNKNOWN":          0,
+		"UNIX_EPOCH":       1,
+		"DEVICE_BOOT_TIME": 2,
+	}
+)
+
+func (x TimestampReference) Enum() *TimestampReference {
+	p := new(TimestampReference)
+	*p = x
+	return p
+}
+
+func (x TimestampReference) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimestampReference) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_psfp_psfp_status_proto_enumTypes[0].Descriptor()
+}
+
+func (TimestampReference) Type() protoreflect.EnumType {
+	return &file_common_structures_psfp_psfp_status_proto_enumTypes[0]
+}
+
+func (x TimestampReference) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimestampReference.Descriptor instead.
+func (TimestampReference) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_status_proto_rawDescGZIP(), []int{0}
+}
+
+// Status of a stream filter instance at runtime
+type StreamFilterStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FilterIndex        uint32                 `protobuf:"varint,1,opt,name=filter_index,json=filterIndex,proto3" json:"filter_index,omitempty"`                        // Matches psfp.StreamFilterInstance.index
+	CurrentlyBlocked   bool                   `protobuf:"varint,2,opt,name=currently_blocked,json=currentlyBlocked,proto3" json:"currently_blocked,omitempty"`         // Clause 8.6.5.1(e): stream blocked due to exceed
+	FramesFiltered     uint64                 `protobuf:"varint,3,opt,name=frames_filtered,json=framesFiltered,proto3" json:"frames_filtered,omitempty"`               // Annex F: frames matched before meter/gate
+	FramesPassed       uint64                 `protobuf:"varint,4,opt,name=frames_passed,json=framesPassed,proto3" json:"frames_passed,omitempty"`                     // Frames passed both gate and meter
+	FramesDroppedMeter uint64                 `protobuf:"varint,5,opt,name=frames_dropped_meter,json=framesDroppedMeter,proto3" json:"frames_dropped_meter,omitempty"` // Clause 8.6.5.1(c,d): dropped by meter
+	FramesBlockedGate  uint64                 `protobuf:"varint,6,opt,name=frames_blocked_gate,json=framesBlockedGate,proto3" json:"frames_blocked_gate,omitempty"`    // Clause 8.6.5.1(f): blocked due to closed gate
+	FramesDroppedSdu   uint64                 `protobuf:"varint,7,opt,name=frames_dropped_sdu,json=framesDroppedSdu,proto3" json:"frames_dropped_sdu,omitempty"`       // Clause 8.6.5.1(g): exceeded max SDU size
+	LastExceedTimeNs   uint64                 `protobuf:"varint,8,opt,name=last_exceed_time_ns,json=lastExceedTimeNs,proto3" json:"last_exceed_time_ns,omitempty"`     // Time of last exceed event
+	LastExceedPort     string                 `protobuf:"bytes,9,opt,name=last_exceed_port,json=lastExceedPort,proto3" json:"last_exceed_port,omitempty"`              // Port where last exceed was observed
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StreamFilterStatus) Reset() {
+	*x = StreamFilterStatus{}
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFilterStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFilterStatus) ProtoMessage() {}
+
+func (x *StreamFilterStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFilterStatus.ProtoReflect.Descriptor instead.
+func (*StreamFilterStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamFilterStatus) GetFilterIndex() uint32 {
+	if x != nil {
+		return x.FilterIndex
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetCurrentlyBlocked() bool {
+	if x != nil {
+		return x.CurrentlyBlocked
+	}
+	return false
+}
+
+func (x *StreamFilterStatus) GetFramesFiltered() uint64 {
+	if x != nil {
+		return x.FramesFiltered
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetFramesPassed() uint64 {
+	if x != nil {
+		return x.FramesPassed
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetFramesDroppedMeter() uint64 {
+	if x != nil {
+		return x.FramesDroppedMeter
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetFramesBlockedGate() uint64 {
+	if x != nil {
+		return x.FramesBlockedGate
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetFramesDroppedSdu() uint64 {
+	if x != nil {
+		return x.FramesDroppedSdu
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetLastExceedTimeNs() uint64 {
+	if x != nil {
+		return x.LastExceedTimeNs
+	}
+	return 0
+}
+
+func (x *StreamFilterStatus) GetLastExceedPort() string {
+	if x != nil {
+		return x.LastExceedPort
+	}
+	return ""
+}
+
+// Status of a flow meter instance
+type FlowMeterStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                      // Matches psfp.FlowMeterInstance.name
+	TotalBytes       uint64                 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`                       // Clause 12.30.1.1.3: total bytes metered
+	TotalPackets     uint64                 `protobuf:"varint,3,opt,name=total_packets,json=totalPackets,proto3" json:"total_packets,omitempty"`                 // Total packets metered
+	ExceedEvents     uint64                 `protobuf:"varint,4,opt,name=exceed_events,json=exceedEvents,proto3" json:"exceed_events,omitempty"`                 // Clause 8.6.5.1(c): rate exceed events
+	LastExceedTimeNs uint64                 `protobuf:"varint,5,opt,name=last_exceed_time_ns,json=lastExceedTimeNs,proto3" json:"last_exceed_time_ns,omitempty"` // Timestamp of last exceed
+	InExceedState    bool                   `protobuf:"varint,6,opt,name=in_exceed_state,json=inExceedState,proto3" json:"in_exceed_state,omitempty"`            // Meter is actively exceeding CIR/PIR
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FlowMeterStatus) Reset() {
+	*x = FlowMeterStatus{}
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowMeterStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowMeterStatus) ProtoMessage() {}
+
+func (x *FlowMeterStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowMeterStatus.ProtoReflect.Descriptor instead.
+func (*FlowMeterStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_status_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FlowMeterStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FlowMeterStatus) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *FlowMeterStatus) GetTotalPackets() uint64 {
+	if x != nil {
+		return x.TotalPackets
+	}
+	return 0
+}
+
+func (x *FlowMeterStatus) GetExceedEvents() uint64 {
+	if x != nil {
+		return x.ExceedEvents
+	}
+	return 0
+}
+
+func (x *FlowMeterStatus) GetLastExceedTimeNs() uint64 {
+	if x != nil {
+		return x.LastExceedTimeNs
+	}
+	return 0
+}
+
+func (x *FlowMeterStatus) GetInExceedState() bool {
+	if x != nil {
+		return x.InExceedState
+	}
+	return false
+}
+
+// Status of a gate instance
+type StreamGateStatus struct {
+	state                protoimpl.MessageState    `protogen:"open.v1"`
+	Name                 string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                    // Matches psfp.StreamGateInstance.name
+	LastCycleStartTimeNs uint64                    `protobuf:"varint,2,opt,name=last_cycle_start_time_ns,json=lastCycleStartTimeNs,proto3" json:"last_cycle_start_time_ns,omitempty"` // Time of last base_time + N * cycle_time
+	CurrentSlotIndex     uint32                    `protobuf:"varint,3,opt,name=current_slot_index,json=currentSlotIndex,proto3" json:"current_slot_index,omitempty"`                 // Clause 8.6.5.1(f): index of active GCL entry
+	GateOpen             bool                      `protobuf:"varint,4,opt,name=gate_open,json=gateOpen,proto3" json:"gate_open,omitempty"`                                           // Overall state (OR across active gate bits)
+	CurrentGateStates    []byte                    `protobuf:"bytes,5,opt,name=current_gate_states,json=currentGateStates,proto3" json:"current_gate_states,omitempty"`               // Current per-TC gate state bitmap (LSB = TC0)
+	FramesBlocked        uint64                    `protobuf:"varint,6,opt,name=frames_blocked,json=framesBlocked,proto3" json:"frames_blocked,omitempty"`                            // Frames blocked while gate closed
+	SyncDiag             *sync.GateSyncDiagnostics `protobuf:"bytes,10,opt,name=sync_diag,json=syncDiag,proto3" json:"sync_diag,omitempty"`                                           // Optional: current sync status
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *StreamGateStatus) Reset() {
+	*x = StreamGateStatus{}
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamGateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamGateStatus) ProtoMessage() {}
+
+func (x *StreamGateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamGateStatus.ProtoReflect.Descriptor instead.
+func (*StreamGateStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_status_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamGateStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreamGateStatus) GetLastCycleStartTimeNs() uint64 {
+	if x != nil {
+		return x.LastCycleStartTimeNs
+	}
+	return 0
+}
+
+func (x *StreamGateStatus) GetCurrentSlotIndex() uint32 {
+	if x != nil {
+		return x.CurrentSlotIndex
+	}
+	return 0
+}
+
+func (x *StreamGateStatus) GetGateOpen() bool {
+	if x != nil {
+		return x.GateOpen
+	}
+	return false
+}
+
+func (x *StreamGateStatus) GetCurrentGateStates() []byte {
+	if x != nil {
+		return x.CurrentGateStates
+	}
+	return nil
+}
+
+func (x *StreamGateStatus) GetFramesBlocked() uint64 {
+	if x != nil {
+		return x.FramesBlocked
+	}
+	return 0
+}
+
+func (x *StreamGateStatus) GetSyncDiag() *sync.GateSyncDiagnostics {
+	if x != nil {
+		return x.SyncDiag
+	}
+	return nil
+}
+
+// Aggregated status structure
+type PsfpRuntimeStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TimestampReference TimestampReference     `protobuf:"varint,1,opt,name=timestamp_reference,json=timestampReference,proto3,enum=psfp_status.TimestampReference" json:"timestamp_reference,omitempty"` // Reference time of this runtime status snapshot
+	FilterStatus       []*StreamFilterStatus  `protobuf:"bytes,2,rep,name=filter_status,json=filterStatus,proto3" json:"filter_status,omitempty"`
+	MeterStatus        []*FlowMeterStatus     `protobuf:"bytes,3,rep,name=meter_status,json=meterStatus,proto3" json:"meter_status,omitempty"`
+	GateStatus         []*StreamGateStatus    `protobuf:"bytes,4,rep,name=gate_status,json=gateStatus,proto3" json:"gate_status,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PsfpRuntimeStatus) Reset() {
+	*x = PsfpRuntimeStatus{}
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsfpRuntimeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsfpRuntimeStatus) ProtoMessage() {}
+
+func (x *PsfpRuntimeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_psfp_psfp_status_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsfpRuntimeStatus.ProtoReflect.Descriptor instead.
+func (*PsfpRuntimeStatus) Descriptor() ([]byte, []int) {
+	return file_common_structures_psfp_psfp_status_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PsfpRuntimeStatus) GetTimestampReference() TimestampReference {
+	if x != nil {
+		return x.TimestampReference
+	}
+	return TimestampReference_UNKNOWN
+}
+
+func (x *PsfpRuntimeStatus) GetFilterStatus() []*StreamFilterStatus {
+	if x != nil {
+		return x.FilterStatus
+	}
+	return nil
+}
+
+func (x *PsfpRuntimeStatus) GetMeterStatus() []*FlowMeterStatus {
+	if x != nil {
+		return x.MeterStatus
+	}
+	return nil
+}
+
+func (x *PsfpRuntimeStatus) GetGateStatus() []*StreamGateStatus {
+	if x != nil {
+		return x.GateStatus
+	}
+	return nil
+}
+
+var File_common_structures_psfp_psfp_status_proto protoreflect.FileDescriptor
+
+const file_common_structures_psfp_psfp_status_proto_rawDesc = "" +
+	"\n" +
+	"(common/structures/psfp/psfp_status.proto\x12\vpsfp_status\x1a%common/structures/sync/syncdiag.proto\"\x9b\x03\n" +
+	"\x12StreamFilterStatus\x12!\n" +
+	"\ffilter_index\x18\x01 \x01(\rR\vfilterIndex\x12+\n" +
+	"\x11currently_blocked\x18\x02 \x01(\bR\x10currentlyBlocked\x12'\n" +
+	"\x0fframes_filtered\x18\x03 \x01(\x04R\x0eframesFiltered\x12#\n" +
+	"\rframes_passed\x18\x04 \x01(\x04R\fframesPassed\x120\n" +
+	"\x14frames_dropped_meter\x18\x05 \x01(\x04R\x12framesDroppedMeter\x12.\n" +
+	"\x13frames_blocked_gate\x18\x06 \x01(\x04R\x11framesBlockedGate\x12,\n" +
+	"\x12frames_dropped_sdu\x18\a \x01(\x04R\x10framesDroppedSdu\x12-\n" +
+	"\x13last_exceed_time_ns\x18\b \x01(\x04R\x10lastExceedTimeNs\x12(\n" +
+	"\x10last_exceed_port\x18\t \x01(\tR\x0elastExceedPort\"\xe7\x01\n" +
+	"\x0fFlowMeterStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x04R\n" +
+	"totalBytes\x12#\n" +
+	"\rtotal_packets\x18\x03 \x01(\x04R\ftotalPackets\x12#\n" +
+	"\rexceed_events\x18\x04 \x01(\x04R\fexceedEvents\x12-\n" +
+	"\x13last_exceed_time_ns\x18\x05 \x01(\x04R\x10lastExceedTimeNs\x12&\n" +
+	"\x0fin_exceed_state\x18\x06 \x01(\bR\rinExceedState\"\xbc\x02\n" +
+	"\x10StreamGateStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x126\n" +
+	"\x18last_cycle_start_time_ns\x18\x02 \x01(\x04R\x14lastCycleStartTimeNs\x12,\n" +
+	"\x12current_slot_index\x18\x03 \x01(\rR\x10currentSlotIndex\x12\x1b\n" +
+	"\tgate_open\x18\x04 \x01(\bR\bgateOpen\x12.\n" +
+	"\x13current_gate_states\x18\x05 \x01(\fR\x11currentGateStates\x12%\n" +
+	"\x0eframes_blocked\x18\x06 \x01(\x04R\rframesBlocked\x12:\n" +
+	"\tsync_diag\x18\n" +
+	" \x01(\v2\x1d.syncdiag.GateSyncDiagnosticsR\bsyncDiag\"\xac\x02\n" +
+	"\x11PsfpRuntimeStatus\x12P\n" +
+	"\x13timestamp_reference\x18\x01 \x01(\x0e2\x1f.psfp_status.TimestampReferenceR\x12timestampReference\x12D\n" +
+	"\rfilter_status\x18\x02 \x03(\v2\x1f.psfp_status.StreamFilterStatusR\ffilterStatus\x12?\n" +
+	"\fmeter_status\x18\x03 \x03(\v2\x1c.psfp_status.FlowMeterStatusR\vmeterStatus\x12>\n" +
+	"\vgate_status\x18\x04 \x03(\v2\x1d.psfp_status.StreamGateStatusR\n" +
+	"gateStatus*G\n" +
+	"\x12TimestampReference\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"UNIX_EPOCH\x10\x01\x12\x14\n" +
+	"\x10DEVICE_BOOT_TIME\x10\x02B4Z2OpenCNC_config_service/common/structures/psfp;psfpb\x06proto3"
+
+var (
+	file_common_structures_psfp_psfp_status_proto_rawDescOnce sync1.Once
+	file_common_structures_psfp_psfp_status_proto_rawDescData []byte
+)
+
+func file_common_structures_psfp_psfp_status_proto_rawDescGZIP() []byte {
+	file_common_structures_psfp_psfp_status_proto_rawDescOnce.Do(func() {
+		file_common_structures_psfp_psfp_status_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_psfp_psfp_status_proto_rawDesc), len(file_common_structures_psfp_psfp_status_proto_rawDesc)))
+	})
+	return file_common_structures_psfp_psfp_status_proto_rawDescData
+}
+
+var file_common_structures_psfp_psfp_status_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_common_structures_psfp_psfp_status_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_common_structures_psfp_psfp_status_proto_goTypes = []any{
+	(TimestampReference)(0),          // 0: psfp_status.TimestampReference
+	(*StreamFilterStatus)(nil),       // 1: psfp_status.StreamFilterStatus
+	(*FlowMeterStatus)(nil),          // 2: psfp_status.FlowMeterStatus
+	(*StreamGateStatus)(nil),         // 3: psfp_status.StreamGateStatus
+	(*PsfpRuntimeStatus)(nil),        // 4: psfp_status.PsfpRuntimeStatus
+	(*sync.GateSyncDiagnostics)(nil), // 5: syncdiag.GateSyncDiagnostics
+}
+var file_common_structures_psfp_psfp_status_proto_depIdxs = []int32{
+	5, // 0: psfp_status.StreamGateStatus.sync_diag:type_name -> syncdiag.GateSyncDiagnostics
+	0, // 1: psfp_status.PsfpRuntimeStatus.timestamp_reference:type_name -> psfp_status.TimestampReference
+	1, // 2: psfp_status.PsfpRuntimeStatus.filter_status:type_name -> psfp_status.StreamFilterStatus
+	2, // 3: psfp_status.PsfpRuntimeStatus.meter_status:type_name -> psfp_status.FlowMeterStatus
+	3, // 4: psfp_status.PsfpRuntimeStatus.gate_status:type_name -> psfp_status.StreamGateStatus
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_psfp_psfp_status_proto_init() }
+func file_common_structures_psfp_psfp_status_proto_init() {
+	if File_common_structures_psfp_psfp_status_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_psfp_psfp_status_proto_rawDesc), len(file_common_structures_psfp_psfp_status_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_psfp_psfp_status_proto_goTypes,
+		DependencyIndexes: file_common_structures_psfp_psfp_status_proto_depIdxs,
+		EnumInfos:         file_common_structures_psfp_psfp_status_proto_enumTypes,
+		MessageInfos:      file_common_structures_psfp_psfp_status_proto_msgTypes,
+	}.Build()
+	File_common_structures_psfp_psfp_status_proto = out.File
+	file_common_structures_psfp_psfp_status_proto_goTypes = nil
+	file_common_structures_psfp_psfp_status_proto_depIdxs = nil
+}
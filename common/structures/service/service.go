@@ -3,13 +3,18 @@ package service
 import (
 	"context"
 	"fmt"
-	"os"
+	"slices"
 
 	"OpenCNC_config_service/common/observability"
 	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/config_history"
 	observabilityv1 "OpenCNC_config_service/common/structures/logging"
 	"OpenCNC_config_service/common/structures/topology_config"
 	"OpenCNC_config_service/config_service/pkg/engine"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
 )
 
 // ConfigServiceServer implements the generated gRPC interface.
@@ -17,11 +22,12 @@ type ConfigServiceServerImpl struct {
 	UnimplementedConfigServiceServer
 	obs    *observability.Client
 	engine *engine.MappingEngine
+	store  storewrapper.Store
 }
 
 // Constructor
-func NewConfigServiceServerImpl(obs *observability.Client, engine *engine.MappingEngine) *ConfigServiceServerImpl {
-	return &ConfigServiceServerImpl{obs: obs, engine: engine}
+func NewConfigServiceServerImpl(obs *observability.Client, engine *engine.MappingEngine, store storewrapper.Store) *ConfigServiceServerImpl {
+	return &ConfigServiceServerImpl{obs: obs, engine: engine, store: store}
 }
 
 // ApplyConfiguration receives a config request ID, retrieves it from store,
@@ -36,18 +42,19 @@ func (s *ConfigServiceServerImpl) ApplyConfiguration(ctx context.Context, req *C
 		}, fmt.Errorf("configuration is nil")
 	}
 
-	if err := s.deployConfiguration(ctx, cfg); err != nil {
+	version, err := s.deployConfiguration(ctx, cfg)
+	if err != nil {
 		return &ConfigurationResponse{
 			Success: false,
 			Message: err.Error(),
+			Version: version,
 		}, err
 	}
 
-	storewrapper.StoreConfiguration(cfg)
-
 	return &ConfigurationResponse{
 		Success: true,
 		Message: "Configuration applied successfully",
+		Version: version,
 	}, nil
 }
 
@@ -61,7 +68,7 @@ func (s *ConfigServiceServerImpl) ApplyConfigurationById(ctx context.Context, re
 		}, fmt.Errorf("configuration ID is empty")
 	}
 
-	cfg, err := storewrapper.GetConfiguration(configId)
+	cfg, err := storewrapper.GetConfiguration(ctx, s.store, configId)
 	if err != nil {
 		return &ConfigurationResponse{
 			Success: false,
@@ -69,32 +76,103 @@ func (s *ConfigServiceServerImpl) ApplyConfigurationById(ctx context.Context, re
 		}, err
 	}
 
-	if err := s.deployConfiguration(ctx, cfg); err != nil {
+	version, err := s.deployConfiguration(ctx, cfg)
+	if err != nil {
 		return &ConfigurationResponse{
 			Success: false,
 			Message: err.Error(),
+			Version: version,
 		}, err
 	}
 	return &ConfigurationResponse{
 		Success: true,
 		Message: "Configuration applied successfully",
+		Version: version,
 	}, nil
 }
 
-func (s *ConfigServiceServerImpl) deployConfiguration(ctx context.Context, cfg *topology_config.TopologyConfig) error {
+// deployConfiguration applies cfg to the stored topology and returns the
+// version of the configuration history recording it.
+func (s *ConfigServiceServerImpl) deployConfiguration(ctx context.Context, cfg *topology_config.TopologyConfig) (uint64, error) {
 
-	topo, err := storewrapper.GetTopology()
+	topo, err := storewrapper.GetTopology(ctx, s.store)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	secret := os.Getenv("NETCONF_PASSWORD")
+	if engine.ActorFromContext(ctx) == "" {
+		ctx = engine.WithActor(ctx, requestActor(ctx))
+	}
 
-	return s.engine.ApplyConfiguration(
+	result, err := s.engine.ApplyConfiguration(
+		ctx,
 		topo,
 		cfg,
-		secret,
 	)
+
+	if result == nil {
+		return 0, err
+	}
+	return result.Version, err
+}
+
+// requestActor names the caller of an RPC: the "actor" metadata set by the
+// client, or else its address.
+func requestActor(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if actors := md.Get("actor"); len(actors) > 0 && actors[0] != "" {
+			return actors[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return "unknown"
+}
+
+// ListConfigurationHistory returns the recorded configuration transactions,
+// most recent first, without their configurations.
+func (s *ConfigServiceServerImpl) ListConfigurationHistory(ctx context.Context, req *HistoryRequest) (*HistoryResponse, error) {
+
+	records, err := storewrapper.ListConfigurationHistory(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &HistoryResponse{}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if req.NodeId != nil && !slices.Contains(records[i].GetNodeIds(), req.GetNodeId()) {
+			continue
+		}
+
+		summary := proto.Clone(records[i]).(*config_history.ConfigurationRecord)
+		summary.Configuration = nil
+		resp.Records = append(resp.Records, summary)
+
+		if req.GetLimit() > 0 && len(resp.Records) == int(req.GetLimit()) {
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// GetConfigurationVersion returns a recorded configuration transaction with
+// its configuration: the requested version, or the latest succeeded one of
+// the network or of a node.
+func (s *ConfigServiceServerImpl) GetConfigurationVersion(ctx context.Context, req *ConfigurationVersionRequest) (*config_history.ConfigurationRecord, error) {
+
+	switch {
+	case req.GetVersion() > 0:
+		return storewrapper.GetConfigurationVersion(ctx, s.store, req.GetVersion())
+	case req.NodeId != nil:
+		return storewrapper.GetLastNodeConfiguration(ctx, s.store, req.GetNodeId())
+	default:
+		return storewrapper.GetLastConfiguration(ctx, s.store)
+	}
 }
 
 // Optional: simple health check RPC.
@@ -123,7 +201,13 @@ func (s *ConfigServiceServerImpl) Rollback(ctx context.Context, req *RollbackReq
 		s.obs.Println("[Config-Service] Rolling back last configuration transaction...")
 	}
 
-	err := s.engine.Rollback()
+	var result *engine.TransactionResult
+	var err error
+	if req.GetTarget() == nil {
+		result, err = s.engine.Rollback(engine.WithActor(ctx, requestActor(ctx)))
+	} else {
+		result, err = s.rollbackTo(ctx, req)
+	}
 	if err != nil {
 
 		msg := fmt.Sprintf(
@@ -158,8 +242,10 @@ func (s *ConfigServiceServerImpl) Rollback(ctx context.Context, req *RollbackReq
 		}
 
 		return &ConfigurationResponse{
-			Success: false,
-			Message: msg,
+			Success:     false,
+			Message:     msg,
+			Version:     rollbackVersionOf(result),
+			NodeResults: result.NodeApplyResults(),
 		}, err
 	}
 
@@ -190,36 +276,106 @@ func (s *ConfigServiceServerImpl) Rollback(ctx context.Context, req *RollbackReq
 	}
 
 	return &ConfigurationResponse{
-		Success: true,
-		Message: "Configuration rolled back successfully",
+		Success:     true,
+		Message:     "Configuration rolled back successfully",
+		Version:     rollbackVersionOf(result),
+		NodeResults: result.NodeApplyResults(),
 	}, nil
 }
 
-/*
-// Helper: apply last known configuration at startup
-func (s *ConfigServiceServerImpl) ApplyLastConfiguration() {
-	_, id, err := storewrapper.GetLastConfiguration()
+// rollbackTo rolls the topology back to the configuration version targeted
+// by req.
+func (s *ConfigServiceServerImpl) rollbackTo(ctx context.Context, req *RollbackRequest) (*engine.TransactionResult, error) {
+
+	version, err := s.rollbackVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	topo, err := storewrapper.GetTopology(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.obs != nil {
+		s.obs.Printf("[Config-Service] Rolling back to configuration version %d...", version)
+	}
+
+	return s.engine.RollbackTo(engine.WithActor(ctx, requestActor(ctx)), topo, version)
+}
+
+// rollbackVersion resolves the target of req to a succeeded version of the
+// configuration history.
+func (s *ConfigServiceServerImpl) rollbackVersion(ctx context.Context, req *RollbackRequest) (uint64, error) {
+
+	switch target := req.GetTarget().(type) {
+	case *RollbackRequest_Version:
+		return target.Version, nil
+	case *RollbackRequest_Steps:
+		return storewrapper.PreviousConfigurationVersion(ctx, s.store, int(target.Steps))
+	}
+
+	records, err := storewrapper.ListConfigurationHistory(ctx, s.store)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.GetResult() == config_history.ApplyResult_APPLY_RESULT_SUCCEEDED &&
+			record.GetRolledBackTo() == 0 && record.GetUndone() == 0 &&
+			record.GetConfiguration().GetConfigId() == req.GetConfigId() {
+			return record.GetVersion(), nil
+		}
+	}
+
+	return 0, fmt.Errorf("configuration %s was never applied successfully", req.GetConfigId())
+}
+
+// rollbackVersionOf returns the history version a rollback was recorded as,
+// 0 without a store.
+func rollbackVersionOf(result *engine.TransactionResult) uint64 {
+	if result == nil {
+		return 0
+	}
+	return result.Version
+}
+
+// ApplyLastConfiguration re-applies the configuration at the head of the
+// history, typically at startup to restore the devices. After a rollback
+// that is the configuration it restored; nothing is applied if it restored
+// the configuration before any.
+func (s *ConfigServiceServerImpl) ApplyLastConfiguration(ctx context.Context) error {
+	record, err := storewrapper.GetLastConfiguration(ctx, s.store)
 	if err != nil {
 		if s.obs != nil {
 			s.obs.Printf("No previous configuration found: %v", err)
 		}
-		return
+		return err
+	}
+
+	if record.GetConfiguration() == nil {
+		if s.obs != nil {
+			s.obs.Printf("Configuration version %d rolled back to before any configuration, nothing to apply", record.GetVersion())
+		}
+		return nil
 	}
 
 	if s.obs != nil {
-		s.obs.Println("Loaded last known configuration from store")
+		s.obs.Printf("Loaded configuration version %d from store", record.GetVersion())
 	}
-	req := &ConfigurationRequest{Id: &id}
-	_, err = s.ApplyConfigurationById(context.Background(), req)
-	if err != nil {
+
+	ctx = engine.WithActor(ctx, "config-service")
+
+	if _, err := s.deployConfiguration(ctx, record.GetConfiguration()); err != nil {
 		if s.obs != nil {
 			s.obs.Printf("Failed to apply last configuration: %v", err)
 		}
-	} else {
-		if s.obs != nil {
-			s.obs.Println("Successfully applied last configuration")
-		}
+		return err
 	}
 
+	if s.obs != nil {
+		s.obs.Println("Successfully applied last configuration")
+	}
+	return nil
 }
-*/
@@ -7,13 +7,13 @@
 package service
 
 import (
+	config_history "OpenCNC_config_service/common/structures/config_history"
 	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
-
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
 
 const (
@@ -76,7 +76,17 @@ func (x *ConfigurationRequest) GetConfiguration() *topology_config.TopologyConfi
 }
 
 type RollbackRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Without a target, the last configuration transaction is undone.
+	// Otherwise every node changed since the target is restored to the
+	// snapshot it ran at the target version.
+	//
+	// Types that are valid to be assigned to Target:
+	//
+	//	*RollbackRequest_Version
+	//	*RollbackRequest_ConfigId
+	//	*RollbackRequest_Steps
+	Target        isRollbackRequest_Target `protobuf_oneof:"target"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -111,10 +121,68 @@ func (*RollbackRequest) Descriptor() ([]byte, []int) {
 	return file_common_structures_service_service_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *RollbackRequest) GetTarget() isRollbackRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RollbackRequest) GetVersion() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*RollbackRequest_Version); ok {
+			return x.Version
+		}
+	}
+	return 0
+}
+
+func (x *RollbackRequest) GetConfigId() string {
+	if x != nil {
+		if x, ok := x.Target.(*RollbackRequest_ConfigId); ok {
+			return x.ConfigId
+		}
+	}
+	return ""
+}
+
+func (x *RollbackRequest) GetSteps() uint32 {
+	if x != nil {
+		if x, ok := x.Target.(*RollbackRequest_Steps); ok {
+			return x.Steps
+		}
+	}
+	return 0
+}
+
+type isRollbackRequest_Target interface {
+	isRollbackRequest_Target()
+}
+
+type RollbackRequest_Version struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3,oneof"` // succeeded configuration history version to restore
+}
+
+type RollbackRequest_ConfigId struct {
+	ConfigId string `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3,oneof"` // latest succeeded version of this configuration
+}
+
+type RollbackRequest_Steps struct {
+	Steps uint32 `protobuf:"varint,3,opt,name=steps,proto3,oneof"` // number of applied configurations to go back from the one running, rollbacks are not counted
+}
+
+func (*RollbackRequest_Version) isRollbackRequest_Target() {}
+
+func (*RollbackRequest_ConfigId) isRollbackRequest_Target() {}
+
+func (*RollbackRequest_Steps) isRollbackRequest_Target() {}
+
 type ConfigurationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	Success       bool                              `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                            `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                            `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`                           // configuration history version recorded for the transaction
+	NodeResults   []*config_history.NodeApplyResult `protobuf:"bytes,4,rep,name=node_results,json=nodeResults,proto3" json:"node_results,omitempty"` // per-node outcome of every phase of a rollback
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -163,25 +231,209 @@ func (x *ConfigurationResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ConfigurationResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigurationResponse) GetNodeResults() []*config_history.NodeApplyResult {
+	if x != nil {
+		return x.NodeResults
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        *string                `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"` // only the configurations pushed to this node
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                      // at most this many most recent records; 0 returns all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_common_structures_service_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_service_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_common_structures_service_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HistoryRequest) GetNodeId() string {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recent first. The configurations themselves are left out, they are
+	// retrieved with GetConfigurationVersion.
+	Records       []*config_history.ConfigurationRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	mi := &file_common_structures_service_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_service_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return file_common_structures_service_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HistoryResponse) GetRecords() []*config_history.ConfigurationRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type ConfigurationVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                  // 0 selects the latest succeeded configuration
+	NodeId        *string                `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"` // with version 0, the latest succeeded configuration of this node
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurationVersionRequest) Reset() {
+	*x = ConfigurationVersionRequest{}
+	mi := &file_common_structures_service_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationVersionRequest) ProtoMessage() {}
+
+func (x *ConfigurationVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_service_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationVersionRequest.ProtoReflect.Descriptor instead.
+func (*ConfigurationVersionRequest) Descriptor() ([]byte, []int) {
+	return file_common_structures_service_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConfigurationVersionRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigurationVersionRequest) GetNodeId() string {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
+	}
+	return ""
+}
+
 var File_common_structures_service_service_proto protoreflect.FileDescriptor
 
 const file_common_structures_service_service_proto_rawDesc = "" +
 	"\n" +
-	"'common/structures/service/service.proto\x12\aservice\x1a7common/structures/topology_config/topology_config.proto\"\x90\x01\n" +
+	"'common/structures/service/service.proto\x12\aservice\x1a7common/structures/topology_config/topology_config.proto\x1a5common/structures/config_history/config_history.proto\"\x90\x01\n" +
 	"\x14ConfigurationRequest\x12\x13\n" +
 	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12J\n" +
 	"\rconfiguration\x18\x02 \x01(\v2\x1f.topology_config.TopologyConfigH\x01R\rconfiguration\x88\x01\x01B\x05\n" +
 	"\x03_idB\x10\n" +
-	"\x0e_configuration\"\x11\n" +
-	"\x0fRollbackRequest\"K\n" +
+	"\x0e_configuration\"n\n" +
+	"\x0fRollbackRequest\x12\x1a\n" +
+	"\aversion\x18\x01 \x01(\x04H\x00R\aversion\x12\x1d\n" +
+	"\tconfig_id\x18\x02 \x01(\tH\x00R\bconfigId\x12\x16\n" +
+	"\x05steps\x18\x03 \x01(\rH\x00R\x05stepsB\b\n" +
+	"\x06target\"\xa9\x01\n" +
 	"\x15ConfigurationResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xca\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\x12B\n" +
+	"\fnode_results\x18\x04 \x03(\v2\x1f.config_history.NodeApplyResultR\vnodeResults\"P\n" +
+	"\x0eHistoryRequest\x12\x1c\n" +
+	"\anode_id\x18\x01 \x01(\tH\x00R\x06nodeId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limitB\n" +
+	"\n" +
+	"\b_node_id\"P\n" +
+	"\x0fHistoryResponse\x12=\n" +
+	"\arecords\x18\x01 \x03(\v2#.config_history.ConfigurationRecordR\arecords\"a\n" +
+	"\x1bConfigurationVersionRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x12\x1c\n" +
+	"\anode_id\x18\x02 \x01(\tH\x00R\x06nodeId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_node_id2\xff\x03\n" +
 	"\rConfigService\x12S\n" +
 	"\x12ApplyConfiguration\x12\x1d.service.ConfigurationRequest\x1a\x1e.service.ConfigurationResponse\x12W\n" +
 	"\x16ApplyConfigurationById\x12\x1d.service.ConfigurationRequest\x1a\x1e.service.ConfigurationResponse\x12D\n" +
 	"\bRollback\x12\x18.service.RollbackRequest\x1a\x1e.service.ConfigurationResponse\x12E\n" +
-	"\x04Ping\x12\x1d.service.ConfigurationRequest\x1a\x1e.service.ConfigurationResponseB:Z8OpenCNC_config_service/common/structures/service;serviceb\x06proto3"
+	"\x04Ping\x12\x1d.service.ConfigurationRequest\x1a\x1e.service.ConfigurationResponse\x12M\n" +
+	"\x18ListConfigurationHistory\x12\x17.service.HistoryRequest\x1a\x18.service.HistoryResponse\x12d\n" +
+	"\x17GetConfigurationVersion\x12$.service.ConfigurationVersionRequest\x1a#.config_history.ConfigurationRecordB:Z8OpenCNC_config_service/common/structures/service;serviceb\x06proto3"
 
 var (
 	file_common_structures_service_service_proto_rawDescOnce sync.Once
@@ -195,28 +447,39 @@ func file_common_structures_service_service_proto_rawDescGZIP() []byte {
 	return file_common_structures_service_service_proto_rawDescData
 }
 
-var file_common_structures_service_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_structures_service_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_common_structures_service_service_proto_goTypes = []any{
-	(*ConfigurationRequest)(nil),           // 0: service.ConfigurationRequest
-	(*RollbackRequest)(nil),                // 1: service.RollbackRequest
-	(*ConfigurationResponse)(nil),          // 2: service.ConfigurationResponse
-	(*topology_config.TopologyConfig)(nil), // 3: topology_config.TopologyConfig
+	(*ConfigurationRequest)(nil),               // 0: service.ConfigurationRequest
+	(*RollbackRequest)(nil),                    // 1: service.RollbackRequest
+	(*ConfigurationResponse)(nil),              // 2: service.ConfigurationResponse
+	(*HistoryRequest)(nil),                     // 3: service.HistoryRequest
+	(*HistoryResponse)(nil),                    // 4: service.HistoryResponse
+	(*ConfigurationVersionRequest)(nil),        // 5: service.ConfigurationVersionRequest
+	(*topology_config.TopologyConfig)(nil),     // 6: topology_config.TopologyConfig
+	(*config_history.NodeApplyResult)(nil),     // 7: config_history.NodeApplyResult
+	(*config_history.ConfigurationRecord)(nil), // 8: config_history.ConfigurationRecord
 }
 var file_common_structures_service_service_proto_depIdxs = []int32{
-	3, // 0: service.ConfigurationRequest.configuration:type_name -> topology_config.TopologyConfig
-	0, // 1: service.ConfigService.ApplyConfiguration:input_type -> service.ConfigurationRequest
-	0, // 2: service.ConfigService.ApplyConfigurationById:input_type -> service.ConfigurationRequest
-	1, // 3: service.ConfigService.Rollback:input_type -> service.RollbackRequest
-	0, // 4: service.ConfigService.Ping:input_type -> service.ConfigurationRequest
-	2, // 5: service.ConfigService.ApplyConfiguration:output_type -> service.ConfigurationResponse
-	2, // 6: service.ConfigService.ApplyConfigurationById:output_type -> service.ConfigurationResponse
-	2, // 7: service.ConfigService.Rollback:output_type -> service.ConfigurationResponse
-	2, // 8: service.ConfigService.Ping:output_type -> service.ConfigurationResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6, // 0: service.ConfigurationRequest.configuration:type_name -> topology_config.TopologyConfig
+	7, // 1: service.ConfigurationResponse.node_results:type_name -> config_history.NodeApplyResult
+	8, // 2: service.HistoryResponse.records:type_name -> config_history.ConfigurationRecord
+	0, // 3: service.ConfigService.ApplyConfiguration:input_type -> service.ConfigurationRequest
+	0, // 4: service.ConfigService.ApplyConfigurationById:input_type -> service.ConfigurationRequest
+	1, // 5: service.ConfigService.Rollback:input_type -> service.RollbackRequest
+	0, // 6: service.ConfigService.Ping:input_type -> service.ConfigurationRequest
+	3, // 7: service.ConfigService.ListConfigurationHistory:input_type -> service.HistoryRequest
+	5, // 8: service.ConfigService.GetConfigurationVersion:input_type -> service.ConfigurationVersionRequest
+	2, // 9: service.ConfigService.ApplyConfiguration:output_type -> service.ConfigurationResponse
+	2, // 10: service.ConfigService.ApplyConfigurationById:output_type -> service.ConfigurationResponse
+	2, // 11: service.ConfigService.Rollback:output_type -> service.ConfigurationResponse
+	2, // 12: service.ConfigService.Ping:output_type -> service.ConfigurationResponse
+	4, // 13: service.ConfigService.ListConfigurationHistory:output_type -> service.HistoryResponse
+	8, // 14: service.ConfigService.GetConfigurationVersion:output_type -> config_history.ConfigurationRecord
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_common_structures_service_service_proto_init() }
@@ -225,13 +488,20 @@ func file_common_structures_service_service_proto_init() {
 		return
 	}
 	file_common_structures_service_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_common_structures_service_service_proto_msgTypes[1].OneofWrappers = []any{
+		(*RollbackRequest_Version)(nil),
+		(*RollbackRequest_ConfigId)(nil),
+		(*RollbackRequest_Steps)(nil),
+	}
+	file_common_structures_service_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_common_structures_service_service_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_service_service_proto_rawDesc), len(file_common_structures_service_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
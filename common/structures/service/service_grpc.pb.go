@@ -7,6 +7,7 @@
 package service
 
 import (
+	config_history "OpenCNC_config_service/common/structures/config_history"
 	context "context"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -19,10 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ConfigService_ApplyConfiguration_FullMethodName     = "/service.ConfigService/ApplyConfiguration"
-	ConfigService_ApplyConfigurationById_FullMethodName = "/service.ConfigService/ApplyConfigurationById"
-	ConfigService_Rollback_FullMethodName               = "/service.ConfigService/Rollback"
-	ConfigService_Ping_FullMethodName                   = "/service.ConfigService/Ping"
+	ConfigService_ApplyConfiguration_FullMethodName       = "/service.ConfigService/ApplyConfiguration"
+	ConfigService_ApplyConfigurationById_FullMethodName   = "/service.ConfigService/ApplyConfigurationById"
+	ConfigService_Rollback_FullMethodName                 = "/service.ConfigService/Rollback"
+	ConfigService_Ping_FullMethodName                     = "/service.ConfigService/Ping"
+	ConfigService_ListConfigurationHistory_FullMethodName = "/service.ConfigService/ListConfigurationHistory"
+	ConfigService_GetConfigurationVersion_FullMethodName  = "/service.ConfigService/GetConfigurationVersion"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -33,6 +36,8 @@ type ConfigServiceClient interface {
 	ApplyConfigurationById(ctx context.Context, in *ConfigurationRequest, opts ...grpc.CallOption) (*ConfigurationResponse, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*ConfigurationResponse, error)
 	Ping(ctx context.Context, in *ConfigurationRequest, opts ...grpc.CallOption) (*ConfigurationResponse, error)
+	ListConfigurationHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	GetConfigurationVersion(ctx context.Context, in *ConfigurationVersionRequest, opts ...grpc.CallOption) (*config_history.ConfigurationRecord, error)
 }
 
 type configServiceClient struct {
@@ -83,6 +88,26 @@ func (c *configServiceClient) Ping(ctx context.Context, in *ConfigurationRequest
 	return out, nil
 }
 
+func (c *configServiceClient) ListConfigurationHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, ConfigService_ListConfigurationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) GetConfigurationVersion(ctx context.Context, in *ConfigurationVersionRequest, opts ...grpc.CallOption) (*config_history.ConfigurationRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(config_history.ConfigurationRecord)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfigurationVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations must embed UnimplementedConfigServiceServer
 // for forward compatibility.
@@ -91,6 +116,8 @@ type ConfigServiceServer interface {
 	ApplyConfigurationById(context.Context, *ConfigurationRequest) (*ConfigurationResponse, error)
 	Rollback(context.Context, *RollbackRequest) (*ConfigurationResponse, error)
 	Ping(context.Context, *ConfigurationRequest) (*ConfigurationResponse, error)
+	ListConfigurationHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	GetConfigurationVersion(context.Context, *ConfigurationVersionRequest) (*config_history.ConfigurationRecord, error)
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -113,6 +140,12 @@ func (UnimplementedConfigServiceServer) Rollback(context.Context, *RollbackReque
 func (UnimplementedConfigServiceServer) Ping(context.Context, *ConfigurationRequest) (*ConfigurationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedConfigServiceServer) ListConfigurationHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConfigurationHistory not implemented")
+}
+func (UnimplementedConfigServiceServer) GetConfigurationVersion(context.Context, *ConfigurationVersionRequest) (*config_history.ConfigurationRecord, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfigurationVersion not implemented")
+}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 func (UnimplementedConfigServiceServer) testEmbeddedByValue()                       {}
 
@@ -206,6 +239,42 @@ func _ConfigService_Ping_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_ListConfigurationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).ListConfigurationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_ListConfigurationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).ListConfigurationHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_GetConfigurationVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigurationVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetConfigurationVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetConfigurationVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetConfigurationVersion(ctx, req.(*ConfigurationVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +298,14 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _ConfigService_Ping_Handler,
 		},
+		{
+			MethodName: "ListConfigurationHistory",
+			Handler:    _ConfigService_ListConfigurationHistory_Handler,
+		},
+		{
+			MethodName: "GetConfigurationVersion",
+			Handler:    _ConfigService_GetConfigurationVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "common/structures/service/service.proto",
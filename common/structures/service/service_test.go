@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/common/structures/topology_config"
+	"OpenCNC_config_service/config_service/pkg/engine"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeBackend accepts every configuration.
+type fakeBackend struct{}
+
+func (fakeBackend) Name() string { return "fake" }
+func (fakeBackend) Protocol() topology.ManagementProtocol {
+	return topology.ManagementProtocol_NETCONF
+}
+func (fakeBackend) AddPlugin(plugins.Plugin)  {}
+func (fakeBackend) Plugins() []plugins.Plugin { return nil }
+func (fakeBackend) PrepareSnapshot(context.Context, *topology_config.NodeConfig, *topology.Node) error {
+	return nil
+}
+func (fakeBackend) Commit(context.Context, *topology.Node) error   { return nil }
+func (fakeBackend) Rollback(context.Context, *topology.Node) error { return nil }
+
+func newTestService(t *testing.T) (*ConfigServiceServerImpl, storewrapper.Store) {
+	t.Helper()
+
+	ctx := context.Background()
+	store := storewrapper.NewMemoryStore()
+
+	raw, err := proto.Marshal(&topology.Node{
+		Name:           "a",
+		ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+	})
+	if err != nil {
+		t.Fatalf("marshal node: %v", err)
+	}
+	if err := store.Put(ctx, "bridges/a", raw); err != nil {
+		t.Fatalf("store node: %v", err)
+	}
+
+	m := engine.NewMappingEngine(nil, engine.WithStore(store))
+	m.RegisterBackend(fakeBackend{})
+
+	return NewConfigServiceServerImpl(nil, m, store), store
+}
+
+func apply(t *testing.T, s *ConfigServiceServerImpl, configId string) {
+	t.Helper()
+
+	cfg := &topology_config.TopologyConfig{
+		ConfigId:    configId,
+		NodeConfigs: []*topology_config.NodeConfig{{NodeId: "a"}},
+	}
+	if _, err := s.ApplyConfiguration(context.Background(), &ConfigurationRequest{Configuration: cfg}); err != nil {
+		t.Fatalf("ApplyConfiguration %s failed: %v", configId, err)
+	}
+}
+
+func TestApplyLastConfiguration_FollowsRollback(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t)
+
+	apply(t, s, "cfg-1") // version 1
+	apply(t, s, "cfg-2") // version 2
+
+	if _, err := s.Rollback(ctx, &RollbackRequest{}); err != nil { // version 3
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if err := s.ApplyLastConfiguration(ctx); err != nil {
+		t.Fatalf("ApplyLastConfiguration failed: %v", err)
+	}
+
+	last, err := storewrapper.GetLastConfiguration(ctx, store)
+	if err != nil || last.GetVersion() != 4 || last.GetConfiguration().GetConfigId() != "cfg-1" {
+		t.Fatalf("expected cfg-1 re-applied as version 4, got %v, %v", last, err)
+	}
+}
+
+func TestApplyLastConfiguration_NothingAfterRollbackOfFirst(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t)
+
+	apply(t, s, "cfg-1") // version 1
+
+	if _, err := s.Rollback(ctx, &RollbackRequest{}); err != nil { // version 2
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if err := s.ApplyLastConfiguration(ctx); err != nil {
+		t.Fatalf("ApplyLastConfiguration failed: %v", err)
+	}
+
+	last, err := storewrapper.GetLastConfiguration(ctx, store)
+	if err != nil || last.GetVersion() != 2 {
+		t.Fatalf("expected nothing applied after the rollback, got %v, %v", last, err)
+	}
+}
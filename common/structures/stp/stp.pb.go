@@ -366,9 +366,11 @@ type SpanningTreeInstance struct {
 	InstanceId   string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`         // e.g., "cist", "mst0", "mst1"
 	RootBridgeId string                 `protobuf:"bytes,2,opt,name=root_bridge_id,json=rootBridgeId,proto3" json:"root_bridge_id,omitempty"` // Bridge ID for this MSTI root
 	// Per-port state/configuration within this instance
-	PortStates    []*StpPortState `protobuf:"bytes,3,rep,name=port_states,json=portStates,proto3" json:"port_states,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	PortStates []*StpPortState `protobuf:"bytes,3,rep,name=port_states,json=portStates,proto3" json:"port_states,omitempty"`
+	// Clause 13.26.2 — priority part of the Bridge Identifier, multiple of 4096
+	BridgePriority *uint32 `protobuf:"varint,4,opt,name=bridge_priority,json=bridgePriority,proto3,oneof" json:"bridge_priority,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SpanningTreeInstance) Reset() {
@@ -422,6 +424,13 @@ func (x *SpanningTreeInstance) GetPortStates() []*StpPortState {
 	return nil
 }
 
+func (x *SpanningTreeInstance) GetBridgePriority() uint32 {
+	if x != nil && x.BridgePriority != nil {
+		return *x.BridgePriority
+	}
+	return 0
+}
+
 type StpPortState struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	PortId string                 `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
@@ -431,7 +440,11 @@ type StpPortState struct {
 	State PortState `protobuf:"varint,3,opt,name=state,proto3,enum=stp.PortState" json:"state,omitempty"`
 	// Indicates if this port is currently forwarding frames.
 	// This is often derived from state but provided explicitly for convenience.
-	Forwarding    bool `protobuf:"varint,4,opt,name=forwarding,proto3" json:"forwarding,omitempty"`
+	Forwarding bool `protobuf:"varint,4,opt,name=forwarding,proto3" json:"forwarding,omitempty"`
+	// Port path cost within this instance (Clause 13.27.33)
+	PathCost *uint32 `protobuf:"varint,5,opt,name=path_cost,json=pathCost,proto3,oneof" json:"path_cost,omitempty"`
+	// Priority part of the Port Identifier, multiple of 16 (Clause 13.27.47)
+	PortPriority  *uint32 `protobuf:"varint,6,opt,name=port_priority,json=portPriority,proto3,oneof" json:"port_priority,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -494,6 +507,20 @@ func (x *StpPortState) GetForwarding() bool {
 	return false
 }
 
+func (x *StpPortState) GetPathCost() uint32 {
+	if x != nil && x.PathCost != nil {
+		return *x.PathCost
+	}
+	return 0
+}
+
+func (x *StpPortState) GetPortPriority() uint32 {
+	if x != nil && x.PortPriority != nil {
+		return *x.PortPriority
+	}
+	return 0
+}
+
 var File_common_structures_stp_stp_proto protoreflect.FileDescriptor
 
 const file_common_structures_stp_stp_proto_rawDesc = "" +
@@ -513,20 +540,27 @@ const file_common_structures_stp_stp_proto_rawDesc = "" +
 	"\x06mst_id\x18\x02 \x01(\rR\x05mstId\"F\n" +
 	"\x19FidRangeToMstidAllocation\x12\x12\n" +
 	"\x04fids\x18\x01 \x03(\rR\x04fids\x12\x15\n" +
-	"\x06mst_id\x18\x02 \x01(\rR\x05mstId\"\x91\x01\n" +
+	"\x06mst_id\x18\x02 \x01(\rR\x05mstId\"\xd3\x01\n" +
 	"\x14SpanningTreeInstance\x12\x1f\n" +
 	"\vinstance_id\x18\x01 \x01(\tR\n" +
 	"instanceId\x12$\n" +
 	"\x0eroot_bridge_id\x18\x02 \x01(\tR\frootBridgeId\x122\n" +
 	"\vport_states\x18\x03 \x03(\v2\x11.stp.StpPortStateR\n" +
-	"portStates\"\x90\x01\n" +
+	"portStates\x12,\n" +
+	"\x0fbridge_priority\x18\x04 \x01(\rH\x00R\x0ebridgePriority\x88\x01\x01B\x12\n" +
+	"\x10_bridge_priority\"\xfc\x01\n" +
 	"\fStpPortState\x12\x17\n" +
 	"\aport_id\x18\x01 \x01(\tR\x06portId\x12!\n" +
 	"\x04role\x18\x02 \x01(\x0e2\r.stp.PortRoleR\x04role\x12$\n" +
 	"\x05state\x18\x03 \x01(\x0e2\x0e.stp.PortStateR\x05state\x12\x1e\n" +
 	"\n" +
 	"forwarding\x18\x04 \x01(\bR\n" +
-	"forwarding*\xb9\x01\n" +
+	"forwarding\x12 \n" +
+	"\tpath_cost\x18\x05 \x01(\rH\x00R\bpathCost\x88\x01\x01\x12(\n" +
+	"\rport_priority\x18\x06 \x01(\rH\x01R\fportPriority\x88\x01\x01B\f\n" +
+	"\n" +
+	"_path_costB\x10\n" +
+	"\x0e_port_priority*\xb9\x01\n" +
 	"\bPortRole\x12\x19\n" +
 	"\x15PORT_ROLE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0ePORT_ROLE_ROOT\x10\x01\x12\x18\n" +
@@ -585,6 +619,8 @@ func file_common_structures_stp_stp_proto_init() {
 		return
 	}
 	file_common_structures_stp_stp_proto_msgTypes[0].OneofWrappers = []any{}
+	file_common_structures_stp_stp_proto_msgTypes[4].OneofWrappers = []any{}
+	file_common_structures_stp_stp_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
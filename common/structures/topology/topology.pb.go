@@ -124,6 +124,55 @@ func (ManagementProtocol) EnumDescriptor() ([]byte, []int) {
 	return file_common_structures_topology_topology_proto_rawDescGZIP(), []int{1}
 }
 
+type ManagementTransport int32
+
+const (
+	ManagementTransport_DEFAULT_TRANSPORT ManagementTransport = 0 // SSH for NETCONF
+	ManagementTransport_SSH               ManagementTransport = 1
+	ManagementTransport_TLS               ManagementTransport = 2 // NETCONF over TLS (RFC 7589)
+)
+
+// Enum value maps for ManagementTransport.
+var (
+	ManagementTransport_name = map[int32]string{
+		0: "DEFAULT_TRANSPORT",
+		1: "SSH",
+		2: "TLS",
+	}
+	ManagementTransport_value = map[string]int32{
+		"DEFAULT_TRANSPORT": 0,
+		"SSH":               1,
+		"TLS":               2,
+	}
+)
+
+func (x ManagementTransport) Enum() *ManagementTransport {
+	p := new(ManagementTransport)
+	*p = x
+	return p
+}
+
+func (x ManagementTransport) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManagementTransport) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_topology_topology_proto_enumTypes[2].Descriptor()
+}
+
+func (ManagementTransport) Type() protoreflect.EnumType {
+	return &file_common_structures_topology_topology_proto_enumTypes[2]
+}
+
+func (x ManagementTransport) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManagementTransport.Descriptor instead.
+func (ManagementTransport) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_topology_topology_proto_rawDescGZIP(), []int{2}
+}
+
 type DuplexMode int32
 
 const (
@@ -154,11 +203,11 @@ func (x DuplexMode) String() string {
 }
 
 func (DuplexMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_structures_topology_topology_proto_enumTypes[2].Descriptor()
+	return file_common_structures_topology_topology_proto_enumTypes[3].Descriptor()
 }
 
 func (DuplexMode) Type() protoreflect.EnumType {
-	return &file_common_structures_topology_topology_proto_enumTypes[2]
+	return &file_common_structures_topology_topology_proto_enumTypes[3]
 }
 
 func (x DuplexMode) Number() protoreflect.EnumNumber {
@@ -167,7 +216,7 @@ func (x DuplexMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DuplexMode.Descriptor instead.
 func (DuplexMode) EnumDescriptor() ([]byte, []int) {
-	return file_common_structures_topology_topology_proto_rawDescGZIP(), []int{2}
+	return file_common_structures_topology_topology_proto_rawDescGZIP(), []int{3}
 }
 
 type Topology struct {
@@ -593,8 +642,10 @@ type ManagementInfo struct {
 	IpAddress       string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	ManagementPort  uint32                 `protobuf:"varint,3,opt,name=management_port,json=managementPort,proto3" json:"management_port,omitempty"` // TCP port (e.g., 830 for NETCONF)
 	UserName        string                 `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
-	ManagementVlan  uint32                 `protobuf:"varint,5,opt,name=management_vlan,json=managementVlan,proto3" json:"management_vlan,omitempty"` // VLAN used for management (IEEE 802.1Qcp context)
-	Protocol        ManagementProtocol     `protobuf:"varint,6,opt,name=protocol,proto3,enum=topology.ManagementProtocol" json:"protocol,omitempty"`  // NETCONF / SNMP / etc.
+	ManagementVlan  uint32                 `protobuf:"varint,5,opt,name=management_vlan,json=managementVlan,proto3" json:"management_vlan,omitempty"`   // VLAN used for management (IEEE 802.1Qcp context)
+	Protocol        ManagementProtocol     `protobuf:"varint,6,opt,name=protocol,proto3,enum=topology.ManagementProtocol" json:"protocol,omitempty"`    // NETCONF / SNMP / etc.
+	Transport       ManagementTransport    `protobuf:"varint,7,opt,name=transport,proto3,enum=topology.ManagementTransport" json:"transport,omitempty"` // SSH unless set to TLS
+	TlsServerName   string                 `protobuf:"bytes,8,opt,name=tls_server_name,json=tlsServerName,proto3" json:"tls_server_name,omitempty"`     // name checked against the TLS server certificate, ip_address if empty
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -671,6 +722,20 @@ func (x *ManagementInfo) GetProtocol() ManagementProtocol {
 	return ManagementProtocol_UNRECOGNIZED
 }
 
+func (x *ManagementInfo) GetTransport() ManagementTransport {
+	if x != nil {
+		return x.Transport
+	}
+	return ManagementTransport_DEFAULT_TRANSPORT
+}
+
+func (x *ManagementInfo) GetTlsServerName() string {
+	if x != nil {
+		return x.TlsServerName
+	}
+	return ""
+}
+
 type InventoryInfo struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	SoftwareVersion string                 `protobuf:"bytes,1,opt,name=software_version,json=softwareVersion,proto3" json:"software_version,omitempty"` // Optional extension: hardware_revision, serial_number, etc.
@@ -1127,7 +1192,7 @@ const file_common_structures_topology_topology_proto_rawDesc = "" +
 	"\n" +
 	"DeviceInfo\x12!\n" +
 	"\fdevice_model\x18\x01 \x01(\tR\vdeviceModel\x12/\n" +
-	"\x13supported_protocols\x18\x02 \x03(\tR\x12supportedProtocols\"\x83\x02\n" +
+	"\x13supported_protocols\x18\x02 \x03(\tR\x12supportedProtocols\"\xe8\x02\n" +
 	"\x0eManagementInfo\x12)\n" +
 	"\x10key_certificates\x18\x01 \x03(\tR\x0fkeyCertificates\x12\x1d\n" +
 	"\n" +
@@ -1135,7 +1200,9 @@ const file_common_structures_topology_topology_proto_rawDesc = "" +
 	"\x0fmanagement_port\x18\x03 \x01(\rR\x0emanagementPort\x12\x1b\n" +
 	"\tuser_name\x18\x04 \x01(\tR\buserName\x12'\n" +
 	"\x0fmanagement_vlan\x18\x05 \x01(\rR\x0emanagementVlan\x128\n" +
-	"\bprotocol\x18\x06 \x01(\x0e2\x1c.topology.ManagementProtocolR\bprotocol\":\n" +
+	"\bprotocol\x18\x06 \x01(\x0e2\x1c.topology.ManagementProtocolR\bprotocol\x12;\n" +
+	"\ttransport\x18\a \x01(\x0e2\x1d.topology.ManagementTransportR\ttransport\x12&\n" +
+	"\x0ftls_server_name\x18\b \x01(\tR\rtlsServerName\":\n" +
 	"\rInventoryInfo\x12)\n" +
 	"\x10software_version\x18\x01 \x01(\tR\x0fsoftwareVersion\"\xed\x02\n" +
 	"\x04Port\x12\x0e\n" +
@@ -1188,7 +1255,11 @@ const file_common_structures_topology_topology_proto_rawDesc = "" +
 	"\x12ManagementProtocol\x12\x10\n" +
 	"\fUNRECOGNIZED\x10\x00\x12\v\n" +
 	"\aNETCONF\x10\x01\x12\b\n" +
-	"\x04SNMP\x10\x02*\x1c\n" +
+	"\x04SNMP\x10\x02*>\n" +
+	"\x13ManagementTransport\x12\x15\n" +
+	"\x11DEFAULT_TRANSPORT\x10\x00\x12\a\n" +
+	"\x03SSH\x10\x01\x12\a\n" +
+	"\x03TLS\x10\x02*\x1c\n" +
 	"\n" +
 	"DuplexMode\x12\x06\n" +
 	"\x02HD\x10\x00\x12\x06\n" +
@@ -1206,45 +1277,47 @@ func file_common_structures_topology_topology_proto_rawDescGZIP() []byte {
 	return file_common_structures_topology_topology_proto_rawDescData
 }
 
-var file_common_structures_topology_topology_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_common_structures_topology_topology_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
 var file_common_structures_topology_topology_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_common_structures_topology_topology_proto_goTypes = []any{
 	(NodeRole)(0),                       // 0: topology.NodeRole
 	(ManagementProtocol)(0),             // 1: topology.ManagementProtocol
-	(DuplexMode)(0),                     // 2: topology.DuplexMode
-	(*Topology)(nil),                    // 3: topology.Topology
-	(*Node)(nil),                        // 4: topology.Node
-	(*NodeProperties)(nil),              // 5: topology.NodeProperties
-	(*BridgeProperties)(nil),            // 6: topology.BridgeProperties
-	(*EndStationProperties)(nil),        // 7: topology.EndStationProperties
-	(*BridgedEndStationProperties)(nil), // 8: topology.BridgedEndStationProperties
-	(*DeviceInfo)(nil),                  // 9: topology.DeviceInfo
-	(*ManagementInfo)(nil),              // 10: topology.ManagementInfo
-	(*InventoryInfo)(nil),               // 11: topology.InventoryInfo
-	(*Port)(nil),                        // 12: topology.Port
-	(*InterfaceCapabilities)(nil),       // 13: topology.InterfaceCapabilities
-	(*Link)(nil),                        // 14: topology.Link
+	(ManagementTransport)(0),            // 2: topology.ManagementTransport
+	(DuplexMode)(0),                     // 3: topology.DuplexMode
+	(*Topology)(nil),                    // 4: topology.Topology
+	(*Node)(nil),                        // 5: topology.Node
+	(*NodeProperties)(nil),              // 6: topology.NodeProperties
+	(*BridgeProperties)(nil),            // 7: topology.BridgeProperties
+	(*EndStationProperties)(nil),        // 8: topology.EndStationProperties
+	(*BridgedEndStationProperties)(nil), // 9: topology.BridgedEndStationProperties
+	(*DeviceInfo)(nil),                  // 10: topology.DeviceInfo
+	(*ManagementInfo)(nil),              // 11: topology.ManagementInfo
+	(*InventoryInfo)(nil),               // 12: topology.InventoryInfo
+	(*Port)(nil),                        // 13: topology.Port
+	(*InterfaceCapabilities)(nil),       // 14: topology.InterfaceCapabilities
+	(*Link)(nil),                        // 15: topology.Link
 }
 var file_common_structures_topology_topology_proto_depIdxs = []int32{
-	4,  // 0: topology.Topology.nodes:type_name -> topology.Node
-	14, // 1: topology.Topology.links:type_name -> topology.Link
+	5,  // 0: topology.Topology.nodes:type_name -> topology.Node
+	15, // 1: topology.Topology.links:type_name -> topology.Link
 	0,  // 2: topology.Node.type:type_name -> topology.NodeRole
-	12, // 3: topology.Node.ports:type_name -> topology.Port
-	9,  // 4: topology.Node.device_info:type_name -> topology.DeviceInfo
-	10, // 5: topology.Node.management_info:type_name -> topology.ManagementInfo
-	11, // 6: topology.Node.inventory_info:type_name -> topology.InventoryInfo
-	5,  // 7: topology.Node.properties:type_name -> topology.NodeProperties
-	6,  // 8: topology.NodeProperties.bridge:type_name -> topology.BridgeProperties
-	7,  // 9: topology.NodeProperties.end_station:type_name -> topology.EndStationProperties
-	8,  // 10: topology.NodeProperties.bridged_end_station:type_name -> topology.BridgedEndStationProperties
+	13, // 3: topology.Node.ports:type_name -> topology.Port
+	10, // 4: topology.Node.device_info:type_name -> topology.DeviceInfo
+	11, // 5: topology.Node.management_info:type_name -> topology.ManagementInfo
+	12, // 6: topology.Node.inventory_info:type_name -> topology.InventoryInfo
+	6,  // 7: topology.Node.properties:type_name -> topology.NodeProperties
+	7,  // 8: topology.NodeProperties.bridge:type_name -> topology.BridgeProperties
+	8,  // 9: topology.NodeProperties.end_station:type_name -> topology.EndStationProperties
+	9,  // 10: topology.NodeProperties.bridged_end_station:type_name -> topology.BridgedEndStationProperties
 	1,  // 11: topology.ManagementInfo.protocol:type_name -> topology.ManagementProtocol
-	13, // 12: topology.Port.capabilities:type_name -> topology.InterfaceCapabilities
-	2,  // 13: topology.InterfaceCapabilities.mode:type_name -> topology.DuplexMode
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	2,  // 12: topology.ManagementInfo.transport:type_name -> topology.ManagementTransport
+	14, // 13: topology.Port.capabilities:type_name -> topology.InterfaceCapabilities
+	3,  // 14: topology.InterfaceCapabilities.mode:type_name -> topology.DuplexMode
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_common_structures_topology_topology_proto_init() }
@@ -1260,7 +1333,7 @@ func file_common_structures_topology_topology_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_topology_topology_proto_rawDesc), len(file_common_structures_topology_topology_proto_rawDesc)),
-			NumEnums:      3,
+			NumEnums:      4,
 			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
@@ -7,6 +7,10 @@
 package topology_config
 
 import (
+	ats "OpenCNC_config_service/common/structures/ats"
+	cqf "OpenCNC_config_service/common/structures/cqf"
+	frer "OpenCNC_config_service/common/structures/frer"
+	psfp "OpenCNC_config_service/common/structures/psfp"
 	qav "OpenCNC_config_service/common/structures/qav"
 	qbv "OpenCNC_config_service/common/structures/qbv"
 	stp "OpenCNC_config_service/common/structures/stp"
@@ -129,14 +133,64 @@ func (GptpRole) EnumDescriptor() ([]byte, []int) {
 	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{1}
 }
 
+type FramePreemptionStatus int32
+
+const (
+	FramePreemptionStatus_FRAME_PREEMPTION_UNSPECIFIED FramePreemptionStatus = 0
+	FramePreemptionStatus_FRAME_PREEMPTION_EXPRESS     FramePreemptionStatus = 1
+	FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE FramePreemptionStatus = 2
+)
+
+// Enum value maps for FramePreemptionStatus.
+var (
+	FramePreemptionStatus_name = map[int32]string{
+		0: "FRAME_PREEMPTION_UNSPECIFIED",
+		1: "FRAME_PREEMPTION_EXPRESS",
+		2: "FRAME_PREEMPTION_PREEMPTABLE",
+	}
+	FramePreemptionStatus_value = map[string]int32{
+		"FRAME_PREEMPTION_UNSPECIFIED": 0,
+		"FRAME_PREEMPTION_EXPRESS":     1,
+		"FRAME_PREEMPTION_PREEMPTABLE": 2,
+	}
+)
+
+func (x FramePreemptionStatus) Enum() *FramePreemptionStatus {
+	p := new(FramePreemptionStatus)
+	*p = x
+	return p
+}
+
+func (x FramePreemptionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FramePreemptionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_structures_topology_config_topology_config_proto_enumTypes[2].Descriptor()
+}
+
+func (FramePreemptionStatus) Type() protoreflect.EnumType {
+	return &file_common_structures_topology_config_topology_config_proto_enumTypes[2]
+}
+
+func (x FramePreemptionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FramePreemptionStatus.Descriptor instead.
+func (FramePreemptionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{2}
+}
+
 type SchedulingModel int32
 
 const (
-	SchedulingModel_SCHEDULING_UNSPECIFIED    SchedulingModel = 0
-	SchedulingModel_STRICT_PRIORITY           SchedulingModel = 1 // SP
-	SchedulingModel_CREDIT_BASED_SHAPER       SchedulingModel = 2 // CBS
-	SchedulingModel_TIME_AWARE_SCHEDULING     SchedulingModel = 3 // Qbv
-	SchedulingModel_CYCLIC_QUEUING_FORWARDING SchedulingModel = 4 // CQF
+	SchedulingModel_SCHEDULING_UNSPECIFIED       SchedulingModel = 0
+	SchedulingModel_STRICT_PRIORITY              SchedulingModel = 1 // SP
+	SchedulingModel_CREDIT_BASED_SHAPER          SchedulingModel = 2 // CBS
+	SchedulingModel_TIME_AWARE_SCHEDULING        SchedulingModel = 3 // Qbv
+	SchedulingModel_CYCLIC_QUEUING_FORWARDING    SchedulingModel = 4 // CQF
+	SchedulingModel_ASYNCHRONOUS_TRAFFIC_SHAPING SchedulingModel = 5 // ATS
 )
 
 // Enum value maps for SchedulingModel.
@@ -147,13 +201,15 @@ var (
 		2: "CREDIT_BASED_SHAPER",
 		3: "TIME_AWARE_SCHEDULING",
 		4: "CYCLIC_QUEUING_FORWARDING",
+		5: "ASYNCHRONOUS_TRAFFIC_SHAPING",
 	}
 	SchedulingModel_value = map[string]int32{
-		"SCHEDULING_UNSPECIFIED":    0,
-		"STRICT_PRIORITY":           1,
-		"CREDIT_BASED_SHAPER":       2,
-		"TIME_AWARE_SCHEDULING":     3,
-		"CYCLIC_QUEUING_FORWARDING": 4,
+		"SCHEDULING_UNSPECIFIED":       0,
+		"STRICT_PRIORITY":              1,
+		"CREDIT_BASED_SHAPER":          2,
+		"TIME_AWARE_SCHEDULING":        3,
+		"CYCLIC_QUEUING_FORWARDING":    4,
+		"ASYNCHRONOUS_TRAFFIC_SHAPING": 5,
 	}
 )
 
@@ -168,11 +224,11 @@ func (x SchedulingModel) String() string {
 }
 
 func (SchedulingModel) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_structures_topology_config_topology_config_proto_enumTypes[2].Descriptor()
+	return file_common_structures_topology_config_topology_config_proto_enumTypes[3].Descriptor()
 }
 
 func (SchedulingModel) Type() protoreflect.EnumType {
-	return &file_common_structures_topology_config_topology_config_proto_enumTypes[2]
+	return &file_common_structures_topology_config_topology_config_proto_enumTypes[3]
 }
 
 func (x SchedulingModel) Number() protoreflect.EnumNumber {
@@ -181,7 +237,7 @@ func (x SchedulingModel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SchedulingModel.Descriptor instead.
 func (SchedulingModel) EnumDescriptor() ([]byte, []int) {
-	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{2}
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{3}
 }
 
 type LinkStateOverride int32
@@ -217,11 +273,11 @@ func (x LinkStateOverride) String() string {
 }
 
 func (LinkStateOverride) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_structures_topology_config_topology_config_proto_enumTypes[3].Descriptor()
+	return file_common_structures_topology_config_topology_config_proto_enumTypes[4].Descriptor()
 }
 
 func (LinkStateOverride) Type() protoreflect.EnumType {
-	return &file_common_structures_topology_config_topology_config_proto_enumTypes[3]
+	return &file_common_structures_topology_config_topology_config_proto_enumTypes[4]
 }
 
 func (x LinkStateOverride) Number() protoreflect.EnumNumber {
@@ -230,7 +286,7 @@ func (x LinkStateOverride) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LinkStateOverride.Descriptor instead.
 func (LinkStateOverride) EnumDescriptor() ([]byte, []int) {
-	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{3}
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{4}
 }
 
 type TopologyConfig struct {
@@ -378,6 +434,10 @@ type BridgeConfig struct {
 	FramePreemptionEnabled *bool                  `protobuf:"varint,3,opt,name=frame_preemption_enabled,json=framePreemptionEnabled,proto3,oneof" json:"frame_preemption_enabled,omitempty"`
 	VlanConfig             *vlan.BridgeVlanConfig `protobuf:"bytes,4,opt,name=vlan_config,json=vlanConfig,proto3,oneof" json:"vlan_config,omitempty"`
 	MstConfig              *stp.BridgeMstConfig   `protobuf:"bytes,5,opt,name=mst_config,json=mstConfig,proto3,oneof" json:"mst_config,omitempty"` // MST resource mapping (FID -> MSTID)
+	Psfp                   *psfp.PsfpConfig       `protobuf:"bytes,6,opt,name=psfp,proto3,oneof" json:"psfp,omitempty"`                            // Stream filters, gates and meters of the bridge
+	Frer                   *frer.FrerBridgeConfig `protobuf:"bytes,7,opt,name=frer,proto3,oneof" json:"frer,omitempty"`                            // Stream identification and FRER functions of the bridge
+	Cqf                    *cqf.CqfConfig         `protobuf:"bytes,8,opt,name=cqf,proto3,oneof" json:"cqf,omitempty"`                              // CQF intent, compiled into Qbv schedules and PSFP stream gates
+	Ats                    *ats.AtsConfig         `protobuf:"bytes,9,opt,name=ats,proto3,oneof" json:"ats,omitempty"`                              // ATS schedulers of the bridge, referenced by the PSFP stream filters
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -447,6 +507,34 @@ func (x *BridgeConfig) GetMstConfig() *stp.BridgeMstConfig {
 	return nil
 }
 
+func (x *BridgeConfig) GetPsfp() *psfp.PsfpConfig {
+	if x != nil {
+		return x.Psfp
+	}
+	return nil
+}
+
+func (x *BridgeConfig) GetFrer() *frer.FrerBridgeConfig {
+	if x != nil {
+		return x.Frer
+	}
+	return nil
+}
+
+func (x *BridgeConfig) GetCqf() *cqf.CqfConfig {
+	if x != nil {
+		return x.Cqf
+	}
+	return nil
+}
+
+func (x *BridgeConfig) GetAts() *ats.AtsConfig {
+	if x != nil {
+		return x.Ats
+	}
+	return nil
+}
+
 type EndStationConfig struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	ParticipateInTimeSync *bool                  `protobuf:"varint,1,opt,name=participate_in_time_sync,json=participateInTimeSync,proto3,oneof" json:"participate_in_time_sync,omitempty"`
@@ -554,9 +642,16 @@ type PortConfig struct {
 	QueueConfigs []*QueueConfig       `protobuf:"bytes,14,rep,name=queue_configs,json=queueConfigs,proto3" json:"queue_configs,omitempty"`
 	Description  string               `protobuf:"bytes,15,opt,name=description,proto3" json:"description,omitempty"`
 	// Optional advanced 802.1Q bridge-port VLAN controls.
-	VlanAdvanced  *vlan.PortVlanAdvancedConfig `protobuf:"bytes,16,opt,name=vlan_advanced,json=vlanAdvanced,proto3,oneof" json:"vlan_advanced,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	VlanAdvanced *vlan.PortVlanAdvancedConfig `protobuf:"bytes,16,opt,name=vlan_advanced,json=vlanAdvanced,proto3,oneof" json:"vlan_advanced,omitempty"`
+	// PSFP: stream filters for streams received on this port, with their gates and meters
+	Psfp *psfp.PsfpConfig `protobuf:"bytes,17,opt,name=psfp,proto3,oneof" json:"psfp,omitempty"`
+	// gPTP: number of the port in the PTP instance (portIdentity.portNumber, 1-based),
+	// required with gptp_role
+	GptpPortNumber *uint32 `protobuf:"varint,18,opt,name=gptp_port_number,json=gptpPortNumber,proto3,oneof" json:"gptp_port_number,omitempty"`
+	// Frame preemption (802.1Qbu/802.3br): express or preemptable status of each priority
+	FramePreemption []*FramePreemptionEntry `protobuf:"bytes,19,rep,name=frame_preemption,json=framePreemption,proto3" json:"frame_preemption,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PortConfig) Reset() {
@@ -701,6 +796,27 @@ func (x *PortConfig) GetVlanAdvanced() *vlan.PortVlanAdvancedConfig {
 	return nil
 }
 
+func (x *PortConfig) GetPsfp() *psfp.PsfpConfig {
+	if x != nil {
+		return x.Psfp
+	}
+	return nil
+}
+
+func (x *PortConfig) GetGptpPortNumber() uint32 {
+	if x != nil && x.GptpPortNumber != nil {
+		return *x.GptpPortNumber
+	}
+	return 0
+}
+
+func (x *PortConfig) GetFramePreemption() []*FramePreemptionEntry {
+	if x != nil {
+		return x.FramePreemption
+	}
+	return nil
+}
+
 // IEEE 802.1Q Clause 8.6.6.1
 // Per-port Priority to traffic class (=egress queue id) mapping
 type TrafficClassTableEntry struct {
@@ -755,6 +871,60 @@ func (x *TrafficClassTableEntry) GetEgressQueueId() uint32 {
 	return 0
 }
 
+// IEEE 802.1Q Clause 12.30.1.1
+// Per-port frame preemption status of a priority
+type FramePreemptionEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pcp           uint32                 `protobuf:"varint,1,opt,name=pcp,proto3" json:"pcp,omitempty"` // Priority Code Point (0–7)
+	Status        FramePreemptionStatus  `protobuf:"varint,2,opt,name=status,proto3,enum=topology_config.FramePreemptionStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FramePreemptionEntry) Reset() {
+	*x = FramePreemptionEntry{}
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FramePreemptionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FramePreemptionEntry) ProtoMessage() {}
+
+func (x *FramePreemptionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FramePreemptionEntry.ProtoReflect.Descriptor instead.
+func (*FramePreemptionEntry) Descriptor() ([]byte, []int) {
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FramePreemptionEntry) GetPcp() uint32 {
+	if x != nil {
+		return x.Pcp
+	}
+	return 0
+}
+
+func (x *FramePreemptionEntry) GetStatus() FramePreemptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FramePreemptionStatus_FRAME_PREEMPTION_UNSPECIFIED
+}
+
 type QueueConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QueueId       uint32                 `protobuf:"varint,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
@@ -769,7 +939,7 @@ type QueueConfig struct {
 
 func (x *QueueConfig) Reset() {
 	*x = QueueConfig{}
-	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[7]
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +951,7 @@ func (x *QueueConfig) String() string {
 func (*QueueConfig) ProtoMessage() {}
 
 func (x *QueueConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[7]
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +964,7 @@ func (x *QueueConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueConfig.ProtoReflect.Descriptor instead.
 func (*QueueConfig) Descriptor() ([]byte, []int) {
-	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{7}
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *QueueConfig) GetQueueId() uint32 {
@@ -842,7 +1012,7 @@ type LinkConfig struct {
 
 func (x *LinkConfig) Reset() {
 	*x = LinkConfig{}
-	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[8]
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -854,7 +1024,7 @@ func (x *LinkConfig) String() string {
 func (*LinkConfig) ProtoMessage() {}
 
 func (x *LinkConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[8]
+	mi := &file_common_structures_topology_config_topology_config_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +1037,7 @@ func (x *LinkConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LinkConfig.ProtoReflect.Descriptor instead.
 func (*LinkConfig) Descriptor() ([]byte, []int) {
-	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{8}
+	return file_common_structures_topology_config_topology_config_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LinkConfig) GetLinkId() string {
@@ -888,7 +1058,7 @@ var File_common_structures_topology_config_topology_config_proto protoreflect.Fi
 
 const file_common_structures_topology_config_topology_config_proto_rawDesc = "" +
 	"\n" +
-	"7common/structures/topology_config/topology_config.proto\x12\x0ftopology_config\x1a\x1fcommon/structures/qbv/qbv.proto\x1a\x1fcommon/structures/qav/qav.proto\x1a\x1fcommon/structures/stp/stp.proto\x1a!common/structures/vlan/vlan.proto\"\xad\x01\n" +
+	"7common/structures/topology_config/topology_config.proto\x12\x0ftopology_config\x1a\x1fcommon/structures/qbv/qbv.proto\x1a\x1fcommon/structures/qav/qav.proto\x1a\x1fcommon/structures/stp/stp.proto\x1a!common/structures/vlan/vlan.proto\x1a!common/structures/psfp/psfp.proto\x1a!common/structures/frer/frer.proto\x1a\x1fcommon/structures/cqf/cqf.proto\x1a\x1fcommon/structures/ats/ats.proto\"\xad\x01\n" +
 	"\x0eTopologyConfig\x12\x1b\n" +
 	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x12>\n" +
 	"\fnode_configs\x18\x02 \x03(\v2\x1b.topology_config.NodeConfigR\vnodeConfigs\x12>\n" +
@@ -903,7 +1073,7 @@ const file_common_structures_topology_config_topology_config_proto_rawDesc = ""
 	"\fport_configs\x18\x05 \x03(\v2\x1b.topology_config.PortConfigR\vportConfigsB\t\n" +
 	"\a_bridgeB\x0e\n" +
 	"\f_end_stationB\x16\n" +
-	"\x14_bridged_end_station\"\xf0\x02\n" +
+	"\x14_bridged_end_station\"\xc0\x04\n" +
 	"\fBridgeConfig\x12,\n" +
 	"\x03stp\x18\x01 \x01(\v2\x15.stp.StpConfigurationH\x00R\x03stp\x88\x01\x01\x12&\n" +
 	"\fgptp_enabled\x18\x02 \x01(\bH\x01R\vgptpEnabled\x88\x01\x01\x12=\n" +
@@ -911,18 +1081,26 @@ const file_common_structures_topology_config_topology_config_proto_rawDesc = ""
 	"\vvlan_config\x18\x04 \x01(\v2\x16.vlan.BridgeVlanConfigH\x03R\n" +
 	"vlanConfig\x88\x01\x01\x128\n" +
 	"\n" +
-	"mst_config\x18\x05 \x01(\v2\x14.stp.BridgeMstConfigH\x04R\tmstConfig\x88\x01\x01B\x06\n" +
+	"mst_config\x18\x05 \x01(\v2\x14.stp.BridgeMstConfigH\x04R\tmstConfig\x88\x01\x01\x12)\n" +
+	"\x04psfp\x18\x06 \x01(\v2\x10.psfp.PsfpConfigH\x05R\x04psfp\x88\x01\x01\x123\n" +
+	"\x04frer\x18\a \x01(\v2\x1a.tsn.frer.FrerBridgeConfigH\x06R\x04frer\x88\x01\x01\x12%\n" +
+	"\x03cqf\x18\b \x01(\v2\x0e.cqf.CqfConfigH\aR\x03cqf\x88\x01\x01\x12%\n" +
+	"\x03ats\x18\t \x01(\v2\x0e.ats.AtsConfigH\bR\x03ats\x88\x01\x01B\x06\n" +
 	"\x04_stpB\x0f\n" +
 	"\r_gptp_enabledB\x1b\n" +
 	"\x19_frame_preemption_enabledB\x0e\n" +
 	"\f_vlan_configB\r\n" +
-	"\v_mst_config\"m\n" +
+	"\v_mst_configB\a\n" +
+	"\x05_psfpB\a\n" +
+	"\x05_frerB\x06\n" +
+	"\x04_cqfB\x06\n" +
+	"\x04_ats\"m\n" +
 	"\x10EndStationConfig\x12<\n" +
 	"\x18participate_in_time_sync\x18\x01 \x01(\bH\x00R\x15participateInTimeSync\x88\x01\x01B\x1b\n" +
 	"\x19_participate_in_time_sync\"d\n" +
 	"\x17BridgedEndStationConfig\x122\n" +
 	"\x12forwarding_enabled\x18\x01 \x01(\bH\x00R\x11forwardingEnabled\x88\x01\x01B\x15\n" +
-	"\x13_forwarding_enabled\"\x93\b\n" +
+	"\x13_forwarding_enabled\"\xdd\t\n" +
 	"\n" +
 	"PortConfig\x12\x17\n" +
 	"\aport_id\x18\x01 \x01(\tR\x06portId\x12%\n" +
@@ -945,7 +1123,10 @@ const file_common_structures_topology_config_topology_config_proto_rawDesc = ""
 	"\rqueue_configs\x18\x0e \x03(\v2\x1c.topology_config.QueueConfigR\fqueueConfigs\x12 \n" +
 	"\vdescription\x18\x0f \x01(\tR\vdescription\x12F\n" +
 	"\rvlan_advanced\x18\x10 \x01(\v2\x1c.vlan.PortVlanAdvancedConfigH\n" +
-	"R\fvlanAdvanced\x88\x01\x01B\x0f\n" +
+	"R\fvlanAdvanced\x88\x01\x01\x12)\n" +
+	"\x04psfp\x18\x11 \x01(\v2\x10.psfp.PsfpConfigH\vR\x04psfp\x88\x01\x01\x12-\n" +
+	"\x10gptp_port_number\x18\x12 \x01(\rH\fR\x0egptpPortNumber\x88\x01\x01\x12P\n" +
+	"\x10frame_preemption\x18\x13 \x03(\v2%.topology_config.FramePreemptionEntryR\x0fframePreemptionB\x0f\n" +
 	"\r_is_edge_portB\x0e\n" +
 	"\f_admin_stateB\x12\n" +
 	"\x10_ingress_enabledB\x11\n" +
@@ -957,10 +1138,15 @@ const file_common_structures_topology_config_topology_config_proto_rawDesc = ""
 	"_gptp_roleB\x0e\n" +
 	"\f_stp_enabledB\x06\n" +
 	"\x04_gclB\x10\n" +
-	"\x0e_vlan_advanced\"R\n" +
+	"\x0e_vlan_advancedB\a\n" +
+	"\x05_psfpB\x13\n" +
+	"\x11_gptp_port_number\"R\n" +
 	"\x16TrafficClassTableEntry\x12\x10\n" +
 	"\x03pcp\x18\x01 \x01(\rR\x03pcp\x12&\n" +
-	"\x0fegress_queue_id\x18\x02 \x01(\rR\regressQueueId\"\xf3\x01\n" +
+	"\x0fegress_queue_id\x18\x02 \x01(\rR\regressQueueId\"h\n" +
+	"\x14FramePreemptionEntry\x12\x10\n" +
+	"\x03pcp\x18\x01 \x01(\rR\x03pcp\x12>\n" +
+	"\x06status\x18\x02 \x01(\x0e2&.topology_config.FramePreemptionStatusR\x06status\"\xf3\x01\n" +
 	"\vQueueConfig\x12\x19\n" +
 	"\bqueue_id\x18\x01 \x01(\rR\aqueueId\x12$\n" +
 	"\x0emax_frame_size\x18\x02 \x01(\rR\fmaxFrameSize\x12&\n" +
@@ -985,13 +1171,18 @@ const file_common_structures_topology_config_topology_config_proto_rawDesc = ""
 	"\x10GPTP_ROLE_MASTER\x10\x01\x12\x13\n" +
 	"\x0fGPTP_ROLE_SLAVE\x10\x02\x12\x15\n" +
 	"\x11GPTP_ROLE_PASSIVE\x10\x03\x12\x16\n" +
-	"\x12GPTP_ROLE_DISABLED\x10\x04*\x95\x01\n" +
+	"\x12GPTP_ROLE_DISABLED\x10\x04*y\n" +
+	"\x15FramePreemptionStatus\x12 \n" +
+	"\x1cFRAME_PREEMPTION_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18FRAME_PREEMPTION_EXPRESS\x10\x01\x12 \n" +
+	"\x1cFRAME_PREEMPTION_PREEMPTABLE\x10\x02*\xb7\x01\n" +
 	"\x0fSchedulingModel\x12\x1a\n" +
 	"\x16SCHEDULING_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fSTRICT_PRIORITY\x10\x01\x12\x17\n" +
 	"\x13CREDIT_BASED_SHAPER\x10\x02\x12\x19\n" +
 	"\x15TIME_AWARE_SCHEDULING\x10\x03\x12\x1d\n" +
-	"\x19CYCLIC_QUEUING_FORWARDING\x10\x04*@\n" +
+	"\x19CYCLIC_QUEUING_FORWARDING\x10\x04\x12 \n" +
+	"\x1cASYNCHRONOUS_TRAFFIC_SHAPING\x10\x05*@\n" +
 	"\x11LinkStateOverride\x12\r\n" +
 	"\tUNDEFINED\x10\x00\x12\f\n" +
 	"\bFORCE_UP\x10\x01\x12\x0e\n" +
@@ -1010,55 +1201,68 @@ func file_common_structures_topology_config_topology_config_proto_rawDescGZIP()
 	return file_common_structures_topology_config_topology_config_proto_rawDescData
 }
 
-var file_common_structures_topology_config_topology_config_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_common_structures_topology_config_topology_config_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_common_structures_topology_config_topology_config_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_common_structures_topology_config_topology_config_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_common_structures_topology_config_topology_config_proto_goTypes = []any{
 	(AdminState)(0),                     // 0: topology_config.AdminState
 	(GptpRole)(0),                       // 1: topology_config.GptpRole
-	(SchedulingModel)(0),                // 2: topology_config.SchedulingModel
-	(LinkStateOverride)(0),              // 3: topology_config.LinkStateOverride
-	(*TopologyConfig)(nil),              // 4: topology_config.TopologyConfig
-	(*NodeConfig)(nil),                  // 5: topology_config.NodeConfig
-	(*BridgeConfig)(nil),                // 6: topology_config.BridgeConfig
-	(*EndStationConfig)(nil),            // 7: topology_config.EndStationConfig
-	(*BridgedEndStationConfig)(nil),     // 8: topology_config.BridgedEndStationConfig
-	(*PortConfig)(nil),                  // 9: topology_config.PortConfig
-	(*TrafficClassTableEntry)(nil),      // 10: topology_config.TrafficClassTableEntry
-	(*QueueConfig)(nil),                 // 11: topology_config.QueueConfig
-	(*LinkConfig)(nil),                  // 12: topology_config.LinkConfig
-	(*stp.StpConfiguration)(nil),        // 13: stp.StpConfiguration
-	(*vlan.BridgeVlanConfig)(nil),       // 14: vlan.BridgeVlanConfig
-	(*stp.BridgeMstConfig)(nil),         // 15: stp.BridgeMstConfig
-	(*vlan.VlanMembership)(nil),         // 16: vlan.VlanMembership
-	(*qbv.GateControlList)(nil),         // 17: qbv.GateControlList
-	(*vlan.PortVlanAdvancedConfig)(nil), // 18: vlan.PortVlanAdvancedConfig
-	(*qav.CbsQueueConfig)(nil),          // 19: qav.CbsQueueConfig
+	(FramePreemptionStatus)(0),          // 2: topology_config.FramePreemptionStatus
+	(SchedulingModel)(0),                // 3: topology_config.SchedulingModel
+	(LinkStateOverride)(0),              // 4: topology_config.LinkStateOverride
+	(*TopologyConfig)(nil),              // 5: topology_config.TopologyConfig
+	(*NodeConfig)(nil),                  // 6: topology_config.NodeConfig
+	(*BridgeConfig)(nil),                // 7: topology_config.BridgeConfig
+	(*EndStationConfig)(nil),            // 8: topology_config.EndStationConfig
+	(*BridgedEndStationConfig)(nil),     // 9: topology_config.BridgedEndStationConfig
+	(*PortConfig)(nil),                  // 10: topology_config.PortConfig
+	(*TrafficClassTableEntry)(nil),      // 11: topology_config.TrafficClassTableEntry
+	(*FramePreemptionEntry)(nil),        // 12: topology_config.FramePreemptionEntry
+	(*QueueConfig)(nil),                 // 13: topology_config.QueueConfig
+	(*LinkConfig)(nil),                  // 14: topology_config.LinkConfig
+	(*stp.StpConfiguration)(nil),        // 15: stp.StpConfiguration
+	(*vlan.BridgeVlanConfig)(nil),       // 16: vlan.BridgeVlanConfig
+	(*stp.BridgeMstConfig)(nil),         // 17: stp.BridgeMstConfig
+	(*psfp.PsfpConfig)(nil),             // 18: psfp.PsfpConfig
+	(*frer.FrerBridgeConfig)(nil),       // 19: tsn.frer.FrerBridgeConfig
+	(*cqf.CqfConfig)(nil),               // 20: cqf.CqfConfig
+	(*ats.AtsConfig)(nil),               // 21: ats.AtsConfig
+	(*vlan.VlanMembership)(nil),         // 22: vlan.VlanMembership
+	(*qbv.GateControlList)(nil),         // 23: qbv.GateControlList
+	(*vlan.PortVlanAdvancedConfig)(nil), // 24: vlan.PortVlanAdvancedConfig
+	(*qav.CbsQueueConfig)(nil),          // 25: qav.CbsQueueConfig
 }
 var file_common_structures_topology_config_topology_config_proto_depIdxs = []int32{
-	5,  // 0: topology_config.TopologyConfig.node_configs:type_name -> topology_config.NodeConfig
-	12, // 1: topology_config.TopologyConfig.link_configs:type_name -> topology_config.LinkConfig
-	6,  // 2: topology_config.NodeConfig.bridge:type_name -> topology_config.BridgeConfig
-	7,  // 3: topology_config.NodeConfig.end_station:type_name -> topology_config.EndStationConfig
-	8,  // 4: topology_config.NodeConfig.bridged_end_station:type_name -> topology_config.BridgedEndStationConfig
-	9,  // 5: topology_config.NodeConfig.port_configs:type_name -> topology_config.PortConfig
-	13, // 6: topology_config.BridgeConfig.stp:type_name -> stp.StpConfiguration
-	14, // 7: topology_config.BridgeConfig.vlan_config:type_name -> vlan.BridgeVlanConfig
-	15, // 8: topology_config.BridgeConfig.mst_config:type_name -> stp.BridgeMstConfig
-	0,  // 9: topology_config.PortConfig.admin_state:type_name -> topology_config.AdminState
-	10, // 10: topology_config.PortConfig.traffic_class_table:type_name -> topology_config.TrafficClassTableEntry
-	16, // 11: topology_config.PortConfig.vlan_memberships:type_name -> vlan.VlanMembership
-	1,  // 12: topology_config.PortConfig.gptp_role:type_name -> topology_config.GptpRole
-	17, // 13: topology_config.PortConfig.gcl:type_name -> qbv.GateControlList
-	11, // 14: topology_config.PortConfig.queue_configs:type_name -> topology_config.QueueConfig
-	18, // 15: topology_config.PortConfig.vlan_advanced:type_name -> vlan.PortVlanAdvancedConfig
-	19, // 16: topology_config.QueueConfig.cbs:type_name -> qav.CbsQueueConfig
-	2,  // 17: topology_config.QueueConfig.scheduling:type_name -> topology_config.SchedulingModel
-	3,  // 18: topology_config.LinkConfig.state_override:type_name -> topology_config.LinkStateOverride
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	6,  // 0: topology_config.TopologyConfig.node_configs:type_name -> topology_config.NodeConfig
+	14, // 1: topology_config.TopologyConfig.link_configs:type_name -> topology_config.LinkConfig
+	7,  // 2: topology_config.NodeConfig.bridge:type_name -> topology_config.BridgeConfig
+	8,  // 3: topology_config.NodeConfig.end_station:type_name -> topology_config.EndStationConfig
+	9,  // 4: topology_config.NodeConfig.bridged_end_station:type_name -> topology_config.BridgedEndStationConfig
+	10, // 5: topology_config.NodeConfig.port_configs:type_name -> topology_config.PortConfig
+	15, // 6: topology_config.BridgeConfig.stp:type_name -> stp.StpConfiguration
+	16, // 7: topology_config.BridgeConfig.vlan_config:type_name -> vlan.BridgeVlanConfig
+	17, // 8: topology_config.BridgeConfig.mst_config:type_name -> stp.BridgeMstConfig
+	18, // 9: topology_config.BridgeConfig.psfp:type_name -> psfp.PsfpConfig
+	19, // 10: topology_config.BridgeConfig.frer:type_name -> tsn.frer.FrerBridgeConfig
+	20, // 11: topology_config.BridgeConfig.cqf:type_name -> cqf.CqfConfig
+	21, // 12: topology_config.BridgeConfig.ats:type_name -> ats.AtsConfig
+	0,  // 13: topology_config.PortConfig.admin_state:type_name -> topology_config.AdminState
+	11, // 14: topology_config.PortConfig.traffic_class_table:type_name -> topology_config.TrafficClassTableEntry
+	22, // 15: topology_config.PortConfig.vlan_memberships:type_name -> vlan.VlanMembership
+	1,  // 16: topology_config.PortConfig.gptp_role:type_name -> topology_config.GptpRole
+	23, // 17: topology_config.PortConfig.gcl:type_name -> qbv.GateControlList
+	13, // 18: topology_config.PortConfig.queue_configs:type_name -> topology_config.QueueConfig
+	24, // 19: topology_config.PortConfig.vlan_advanced:type_name -> vlan.PortVlanAdvancedConfig
+	18, // 20: topology_config.PortConfig.psfp:type_name -> psfp.PsfpConfig
+	12, // 21: topology_config.PortConfig.frame_preemption:type_name -> topology_config.FramePreemptionEntry
+	2,  // 22: topology_config.FramePreemptionEntry.status:type_name -> topology_config.FramePreemptionStatus
+	25, // 23: topology_config.QueueConfig.cbs:type_name -> qav.CbsQueueConfig
+	3,  // 24: topology_config.QueueConfig.scheduling:type_name -> topology_config.SchedulingModel
+	4,  // 25: topology_config.LinkConfig.state_override:type_name -> topology_config.LinkStateOverride
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_common_structures_topology_config_topology_config_proto_init() }
@@ -1071,15 +1275,15 @@ func file_common_structures_topology_config_topology_config_proto_init() {
 	file_common_structures_topology_config_topology_config_proto_msgTypes[3].OneofWrappers = []any{}
 	file_common_structures_topology_config_topology_config_proto_msgTypes[4].OneofWrappers = []any{}
 	file_common_structures_topology_config_topology_config_proto_msgTypes[5].OneofWrappers = []any{}
-	file_common_structures_topology_config_topology_config_proto_msgTypes[7].OneofWrappers = []any{}
 	file_common_structures_topology_config_topology_config_proto_msgTypes[8].OneofWrappers = []any{}
+	file_common_structures_topology_config_topology_config_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_topology_config_topology_config_proto_rawDesc), len(file_common_structures_topology_config_topology_config_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   9,
+			NumEnums:      5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
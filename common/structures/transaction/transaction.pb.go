@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: common/structures/transaction/transaction.proto
+
+package transaction
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransactionOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Prepared      bool                   `protobuf:"varint,2,opt,name=prepared,proto3" json:"prepared,omitempty"`                       // the working snapshot of the node was prepared
+	Committed     bool                   `protobuf:"varint,3,opt,name=committed,proto3" json:"committed,omitempty"`                     // the node runs the configuration of the transaction
+	RolledBack    bool                   `protobuf:"varint,4,opt,name=rolled_back,json=rolledBack,proto3" json:"rolled_back,omitempty"` // the node was restored to its previous configuration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionOperation) Reset() {
+	*x = TransactionOperation{}
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionOperation) ProtoMessage() {}
+
+func (x *TransactionOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionOperation.ProtoReflect.Descriptor instead.
+func (*TransactionOperation) Descriptor() ([]byte, []int) {
+	return file_common_structures_transaction_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransactionOperation) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *TransactionOperation) GetPrepared() bool {
+	if x != nil {
+		return x.Prepared
+	}
+	return false
+}
+
+func (x *TransactionOperation) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *TransactionOperation) GetRolledBack() bool {
+	if x != nil {
+		return x.RolledBack
+	}
+	return false
+}
+
+type TransactionState struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ConfigId      string                  `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Version       uint64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                            // configuration history version of the transaction
+	TimestampNs   uint64                  `protobuf:"varint,3,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"` // last change of the state (nanoseconds since epoch)
+	Operations    []*TransactionOperation `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionState) Reset() {
+	*x = TransactionState{}
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionState) ProtoMessage() {}
+
+func (x *TransactionState) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionState.ProtoReflect.Descriptor instead.
+func (*TransactionState) Descriptor() ([]byte, []int) {
+	return file_common_structures_transaction_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransactionState) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *TransactionState) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TransactionState) GetTimestampNs() uint64 {
+	if x != nil {
+		return x.TimestampNs
+	}
+	return 0
+}
+
+func (x *TransactionState) GetOperations() []*TransactionOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// Snapshots a protocol backend keeps of the configuration of a node.
+type NodeSnapshots struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Current       []byte                 `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`                         // configuration the node runs
+	LastStable    []byte                 `protobuf:"bytes,3,opt,name=last_stable,json=lastStable,proto3" json:"last_stable,omitempty"` // configuration restored by a rollback
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSnapshots) Reset() {
+	*x = NodeSnapshots{}
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSnapshots) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSnapshots) ProtoMessage() {}
+
+func (x *NodeSnapshots) ProtoReflect() protoreflect.Message {
+	mi := &file_common_structures_transaction_transaction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSnapshots.ProtoReflect.Descriptor instead.
+func (*NodeSnapshots) Descriptor() ([]byte, []int) {
+	return file_common_structures_transaction_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NodeSnapshots) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeSnapshots) GetCurrent() []byte {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *NodeSnapshots) GetLastStable() []byte {
+	if x != nil {
+		return x.LastStable
+	}
+	return nil
+}
+
+var File_common_structures_transaction_transaction_proto protoreflect.FileDescriptor
+
+const file_common_structures_transaction_transaction_proto_rawDesc = "" +
+	"\n" +
+	"/common/structures/transaction/transaction.proto\x12\vtransaction\"\x8a\x01\n" +
+	"\x14TransactionOperation\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1a\n" +
+	"\bprepared\x18\x02 \x01(\bR\bprepared\x12\x1c\n" +
+	"\tcommitted\x18\x03 \x01(\bR\tcommitted\x12\x1f\n" +
+	"\vrolled_back\x18\x04 \x01(\bR\n" +
+	"rolledBack\"\xaf\x01\n" +
+	"\x10TransactionState\x12\x1b\n" +
+	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\x12!\n" +
+	"\ftimestamp_ns\x18\x03 \x01(\x04R\vtimestampNs\x12A\n" +
+	"\n" +
+	"operations\x18\x04 \x03(\v2!.transaction.TransactionOperationR\n" +
+	"operations\"c\n" +
+	"\rNodeSnapshots\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\fR\acurrent\x12\x1f\n" +
+	"\vlast_stable\x18\x03 \x01(\fR\n" +
+	"lastStableBBZ@OpenCNC_config_service/common/structures/transaction;transactionb\x06proto3"
+
+var (
+	file_common_structures_transaction_transaction_proto_rawDescOnce sync.Once
+	file_common_structures_transaction_transaction_proto_rawDescData []byte
+)
+
+func file_common_structures_transaction_transaction_proto_rawDescGZIP() []byte {
+	file_common_structures_transaction_transaction_proto_rawDescOnce.Do(func() {
+		file_common_structures_transaction_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_structures_transaction_transaction_proto_rawDesc), len(file_common_structures_transaction_transaction_proto_rawDesc)))
+	})
+	return file_common_structures_transaction_transaction_proto_rawDescData
+}
+
+var file_common_structures_transaction_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_structures_transaction_transaction_proto_goTypes = []any{
+	(*TransactionOperation)(nil), // 0: transaction.TransactionOperation
+	(*TransactionState)(nil),     // 1: transaction.TransactionState
+	(*NodeSnapshots)(nil),        // 2: transaction.NodeSnapshots
+}
+var file_common_structures_transaction_transaction_proto_depIdxs = []int32{
+	0, // 0: transaction.TransactionState.operations:type_name -> transaction.TransactionOperation
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_common_structures_transaction_transaction_proto_init() }
+func file_common_structures_transaction_transaction_proto_init() {
+	if File_common_structures_transaction_transaction_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_structures_transaction_transaction_proto_rawDesc), len(file_common_structures_transaction_transaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_structures_transaction_transaction_proto_goTypes,
+		DependencyIndexes: file_common_structures_transaction_transaction_proto_depIdxs,
+		MessageInfos:      file_common_structures_transaction_transaction_proto_msgTypes,
+	}.Build()
+	File_common_structures_transaction_transaction_proto = out.File
+	file_common_structures_transaction_transaction_proto_goTypes = nil
+	file_common_structures_transaction_transaction_proto_depIdxs = nil
+}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/x509"
 	"log"
 	"net"
+	"os"
+	"strconv"
 	"time"
 
 	"OpenCNC_config_service/common/observability"
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -14,6 +18,7 @@ import (
 	service "OpenCNC_config_service/common/structures/service"
 	"OpenCNC_config_service/common/structures/topology"
 	"OpenCNC_config_service/config_service/pkg/engine" // Your wrapper implementing GNMIService
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
 	"OpenCNC_config_service/config_service/pkg/plugins"
 	"OpenCNC_config_service/config_service/pkg/protocolbackends"
 	// Official gNMI package
@@ -63,6 +68,13 @@ func main() {
 
 		creds := credentials.NewTLS(tlsConfig)
 	*/
+	// --- Connect to the k/v store, shared by the service and the backends ---
+	store, err := storewrapper.OpenFromEnv()
+	if err != nil {
+		obsClient.FatalF("Failed to open the store: %v", err)
+	}
+	defer store.Close()
+
 	// --- Create TCP listener ---
 	listener, err := net.Listen("tcp", ":5150")
 	if err != nil {
@@ -75,16 +87,77 @@ func main() {
 	//logger.Println("Starting gRPC server without TLS (for testing)...")
 
 	// --- Create the configuration engine and register backends ---
-	engine := engine.NewMappingEngine(obsClient)
+	engineOptions := append(engineOptionsFromEnv(obsClient), engine.WithStore(store))
+	engine := engine.NewMappingEngine(obsClient, engineOptions...)
 	// register the Netconf backend
 	netconfPlugins := plugins.ForProtocol(topology.ManagementProtocol_NETCONF, obsClient)
 	netconf_backend := protocolbackends.NewNetconfBackend("netconf", obsClient, netconfPlugins...)
+	sessionPool := managementSessions.NewSessionPool(obsClient, sessionPoolConfigFromEnv(obsClient))
+	credentialProvider := credentialProviderFromEnv()
+	netconf_backend.SetSessionPool(sessionPool)
+	netconf_backend.SetCredentialProvider(credentialProvider)
+	netconf_backend.SetKnownHosts(knownHostsFromEnv(obsClient, store))
+	netconf_backend.SetStore(store)
+	var rootCAs *x509.CertPool
+	if path := os.Getenv("NETCONF_TLS_CA_FILE"); path != "" {
+		roots, err := managementSessions.LoadCABundle(path)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_TLS_CA_FILE: %v", err)
+		}
+		rootCAs = roots
+		netconf_backend.SetRootCAs(roots)
+	}
+	startCallHomeFromEnv(obsClient, managementSessions.CallHomeConfig{
+		Pool:        sessionPool,
+		Nodes:       func(ctx context.Context) ([]*topology.Node, error) { return storewrapper.TopologyNodes(ctx, store) },
+		Credentials: credentialProvider,
+		HostKeys:    managementSessions.KVHostKeyStore{Store: store},
+		SSHUsername: os.Getenv("NETCONF_CALLHOME_USERNAME"),
+		RootCAs:     rootCAs,
+		Logger:      obsClient,
+		Auditor:     obsClient,
+	})
+	netconf_backend.SetEventEmitter(obsClient)
+	if raw := os.Getenv("NETCONF_DISCOVER_SCHEMAS"); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_DISCOVER_SCHEMAS %q: %v", raw, err)
+		}
+		netconf_backend.SetSchemaDiscovery(enabled)
+	}
+	if raw := os.Getenv("NETCONF_CONFIRM_TIMEOUT"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_CONFIRM_TIMEOUT %q: %v", raw, err)
+		}
+		netconf_backend.SetConfirmTimeout(d)
+	}
 	engine.RegisterBackend(netconf_backend)
 
+	// --- Reload the snapshots and the last transaction, so it can still be rolled back ---
+	if err := netconf_backend.LoadSnapshots(context.Background()); err != nil {
+		obsClient.Printf("Failed to load NETCONF snapshots: %v", err)
+	}
+	if err := engine.RestoreTransaction(context.Background()); err != nil {
+		obsClient.Printf("Failed to restore the last transaction: %v", err)
+	}
+
 	// --- Register ConfigService and gNMI service ---
-	svc := service.NewConfigServiceServerImpl(obsClient, engine)
+	svc := service.NewConfigServiceServerImpl(obsClient, engine, store)
 	service.RegisterConfigServiceServer(grpcServer, svc)
 
+	// CONFIG_APPLY_LAST_ON_STARTUP restores the latest succeeded configuration
+	// of the history on the devices before serving requests.
+	if raw := os.Getenv("CONFIG_APPLY_LAST_ON_STARTUP"); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid CONFIG_APPLY_LAST_ON_STARTUP %q: %v", raw, err)
+		}
+		if enabled {
+			_ = svc.ApplyLastConfiguration(context.Background())
+		}
+	}
+
 	//gnmi.RegisterGNMIServer(grpcServer, gnmiImpl.NewGNMIService(logger))
 
 	// --- Optional: reflection ---
@@ -105,3 +178,123 @@ func main() {
 	}
 
 }
+
+// engineOptionsFromEnv reads the transaction tuning knobs:
+// CONFIG_MAX_WORKERS (nodes handled concurrently) and
+// CONFIG_NODE_TIMEOUT (per-node deadline per phase, e.g. "30s").
+func engineOptionsFromEnv(obsClient *observability.Client) []engine.Option {
+	var opts []engine.Option
+
+	if raw := os.Getenv("CONFIG_MAX_WORKERS"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid CONFIG_MAX_WORKERS %q: %v", raw, err)
+		}
+		opts = append(opts, engine.WithMaxWorkers(n))
+	}
+
+	if raw := os.Getenv("CONFIG_NODE_TIMEOUT"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid CONFIG_NODE_TIMEOUT %q: %v", raw, err)
+		}
+		opts = append(opts, engine.WithNodeTimeout(d))
+	}
+
+	return opts
+}
+
+// sessionPoolConfigFromEnv reads the NETCONF session pool limits:
+// NETCONF_MAX_SESSIONS_PER_DEVICE and NETCONF_KEEPALIVE_INTERVAL (e.g. "30s").
+func sessionPoolConfigFromEnv(obsClient *observability.Client) managementSessions.PoolConfig {
+	var config managementSessions.PoolConfig
+
+	if raw := os.Getenv("NETCONF_MAX_SESSIONS_PER_DEVICE"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_MAX_SESSIONS_PER_DEVICE %q: %v", raw, err)
+		}
+		config.MaxSessionsPerDevice = n
+	}
+
+	if raw := os.Getenv("NETCONF_KEEPALIVE_INTERVAL"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_KEEPALIVE_INTERVAL %q: %v", raw, err)
+		}
+		config.KeepaliveInterval = d
+	}
+
+	return config
+}
+
+// credentialProviderFromEnv chains the NETCONF credential sources, first match
+// wins: NETCONF_USERNAME/NETCONF_PASSWORD/NETCONF_SSH_KEY_FILE (optionally
+// suffixed with the node name), the JSON file at NETCONF_CREDENTIALS_FILE, the
+// secrets directory at NETCONF_SECRETS_DIR, and finally the node's
+// key_certificates, resolved against NETCONF_KEY_CERTIFICATES_DIR.
+func credentialProviderFromEnv() managementSessions.CredentialProvider {
+	chain := managementSessions.CredentialChain{managementSessions.EnvCredentials{}}
+
+	if path := os.Getenv("NETCONF_CREDENTIALS_FILE"); path != "" {
+		chain = append(chain, managementSessions.FileCredentials{Path: path})
+	}
+
+	if dir := os.Getenv("NETCONF_SECRETS_DIR"); dir != "" {
+		chain = append(chain, managementSessions.SecretsDir{Dir: dir})
+	}
+
+	chain = append(chain, managementSessions.KeyCertificates{Dir: os.Getenv("NETCONF_KEY_CERTIFICATES_DIR")})
+
+	return chain
+}
+
+// knownHostsFromEnv verifies device host keys against the fingerprints pinned
+// in the k/v store. NETCONF_HOST_KEY_MODE is "tofu" (default), pinning the key
+// seen on first contact, or "strict", accepting pre-pinned keys only.
+func knownHostsFromEnv(obsClient *observability.Client, store storewrapper.Store) *managementSessions.KnownHosts {
+	mode := managementSessions.HostKeyTOFU
+
+	if raw := os.Getenv("NETCONF_HOST_KEY_MODE"); raw != "" {
+		m, err := managementSessions.ParseHostKeyMode(raw)
+		if err != nil {
+			obsClient.FatalF("Invalid NETCONF_HOST_KEY_MODE: %v", err)
+		}
+		mode = m
+	}
+
+	return managementSessions.NewKnownHosts(mode, managementSessions.KVHostKeyStore{Store: store}, obsClient, obsClient)
+}
+
+// startCallHomeFromEnv accepts NETCONF Call Home connections on
+// NETCONF_CALLHOME_SSH_ADDR and NETCONF_CALLHOME_TLS_ADDR (e.g. ":4334",
+// ":4335"); call home is disabled for an unset address. Calling devices are
+// matched to topology nodes and join the session pool.
+func startCallHomeFromEnv(obsClient *observability.Client, config managementSessions.CallHomeConfig) {
+	sshAddr := os.Getenv("NETCONF_CALLHOME_SSH_ADDR")
+	tlsAddr := os.Getenv("NETCONF_CALLHOME_TLS_ADDR")
+	if sshAddr == "" && tlsAddr == "" {
+		return
+	}
+
+	callHome := managementSessions.NewCallHomeListener(config)
+
+	serve := func(addr string, serve func(net.Listener) error) {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			obsClient.FatalF("Failed to listen for NETCONF call home on %s: %v", addr, err)
+		}
+		go func() {
+			if err := serve(ln); err != nil {
+				obsClient.Printf("NETCONF call home listener on %s stopped: %v", addr, err)
+			}
+		}()
+	}
+
+	if sshAddr != "" {
+		serve(sshAddr, callHome.ServeSSH)
+	}
+	if tlsAddr != "" {
+		serve(tlsAddr, callHome.ServeTLS)
+	}
+}
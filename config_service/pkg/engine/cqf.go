@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"fmt"
+
+	cqf "OpenCNC_config_service/common/structures/cqf"
+	psfp "OpenCNC_config_service/common/structures/psfp"
+	qbv "OpenCNC_config_service/common/structures/qbv"
+	"OpenCNC_config_service/common/structures/topology_config"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// compileCqf replaces the CQF intent of the bridges of cfg with the Qbv gate
+// control lists of their egress ports and the PSFP stream gates it stands
+// for, so the backends only see configuration the plugins know. cfg is
+// returned as is without CQF, otherwise a copy is modified.
+func compileCqf(cfg *topology_config.TopologyConfig) (*topology_config.TopologyConfig, error) {
+	found := false
+	for _, nodeCfg := range cfg.GetNodeConfigs() {
+		if nodeCfg.GetBridge().GetCqf() != nil {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return cfg, nil
+	}
+
+	compiled := proto.Clone(cfg).(*topology_config.TopologyConfig)
+
+	for _, nodeCfg := range compiled.GetNodeConfigs() {
+		cqfCfg := nodeCfg.GetBridge().GetCqf()
+		if cqfCfg == nil {
+			continue
+		}
+
+		if err := compileNodeCqf(nodeCfg, cqfCfg); err != nil {
+			return nil, fmt.Errorf("node %s: CQF: %w", nodeCfg.GetNodeId(), err)
+		}
+
+		nodeCfg.Bridge.Cqf = nil
+	}
+
+	return compiled, nil
+}
+
+func compileNodeCqf(nodeCfg *topology_config.NodeConfig, cqfCfg *cqf.CqfConfig) error {
+	cycle := cqfCfg.GetCycleTimeNs()
+	even, odd := cqfCfg.GetEvenQueueId(), cqfCfg.GetOddQueueId()
+
+	if cycle == 0 {
+		return fmt.Errorf("cycle time is not set")
+	}
+	if even > 7 || odd > 7 {
+		return fmt.Errorf("queue pair %d/%d out of range 0-7", even, odd)
+	}
+	if even == odd {
+		return fmt.Errorf("queue pair uses queue %d twice", even)
+	}
+
+	pair := uint32(1)<<even | uint32(1)<<odd
+	background := cqfCfg.GetBackgroundGateStates()
+	if background > 0xff {
+		return fmt.Errorf("background gate states %#x exceed 8 traffic classes", background)
+	}
+	if background&pair != 0 {
+		return fmt.Errorf("background gate states %#x open a queue of the pair", background)
+	}
+
+	if len(cqfCfg.GetEgressPortIds()) == 0 {
+		return fmt.Errorf("no egress port")
+	}
+
+	for _, portID := range cqfCfg.GetEgressPortIds() {
+		portCfg := ensureNodePortConfig(nodeCfg, portID)
+
+		if portCfg.Gcl != nil {
+			return fmt.Errorf("port %s already has a Qbv schedule", portID)
+		}
+
+		if err := checkCqfQueues(portCfg, even, odd); err != nil {
+			return fmt.Errorf("port %s: %w", portID, err)
+		}
+
+		portCfg.Gcl = &qbv.GateControlList{
+			ScheduleId: "cqf",
+			BaseTime:   cqfCfg.GetBaseTimeNs(),
+			CycleTime:  2 * cycle,
+			AdminState: qbv.AdminState_ENABLED,
+			Entries: []*qbv.GateControlEntry{
+				{
+					Index:        0,
+					TimeInterval: cycle,
+					GateStates:   []byte{byte(background | 1<<even)},
+					Description:  "CQF even cycle",
+				},
+				{
+					Index:        1,
+					TimeInterval: cycle,
+					GateStates:   []byte{byte(background | 1<<odd)},
+					Description:  "CQF odd cycle",
+				},
+			},
+			Description: "Compiled from the CQF configuration of the bridge",
+		}
+	}
+
+	if len(cqfCfg.GetStreamGates()) == 0 {
+		return nil
+	}
+
+	if nodeCfg.Bridge.Psfp == nil {
+		nodeCfg.Bridge.Psfp = &psfp.PsfpConfig{}
+	}
+	psfpCfg := nodeCfg.Bridge.Psfp
+
+	for _, gate := range cqfCfg.GetStreamGates() {
+		for _, existing := range psfpCfg.GetStreamGates() {
+			if existing.GetName() == gate.GetName() || existing.GetInstanceId() == gate.GetInstanceId() {
+				return fmt.Errorf(
+					"stream gate %s (%d) conflicts with stream gate %s (%d)",
+					gate.GetName(),
+					gate.GetInstanceId(),
+					existing.GetName(),
+					existing.GetInstanceId(),
+				)
+			}
+		}
+
+		// Frames received while a queue transmits are stored in the other.
+		psfpCfg.StreamGates = append(psfpCfg.StreamGates, &psfp.StreamGateInstance{
+			Name:            gate.GetName(),
+			InstanceId:      gate.GetInstanceId(),
+			AdminGateStates: []byte{1},
+			BaseTimeNs:      cqfCfg.GetBaseTimeNs(),
+			CycleTimeNs:     2 * cycle,
+			GateControlList: []*psfp.GateControlEntry{
+				{TimeIntervalNs: cycle, GateState: psfp.GateState_OPEN, PriorityOverride: proto.Uint32(odd)},
+				{TimeIntervalNs: cycle, GateState: psfp.GateState_OPEN, PriorityOverride: proto.Uint32(even)},
+			},
+			Description: "Compiled from the CQF configuration of the bridge",
+		})
+	}
+
+	return nil
+}
+
+// checkCqfQueues rejects egress ports whose configuration contradicts the
+// queue pair: another scheduling model for a queue of the pair, or a traffic
+// class table, given or default, not mapping the priorities of the pair to
+// their queues.
+func checkCqfQueues(portCfg *topology_config.PortConfig, even, odd uint32) error {
+	for _, queueCfg := range portCfg.GetQueueConfigs() {
+		if queueCfg.GetQueueId() != even && queueCfg.GetQueueId() != odd {
+			continue
+		}
+
+		if queueCfg.Scheduling != nil &&
+			queueCfg.GetScheduling() != topology_config.SchedulingModel_SCHEDULING_UNSPECIFIED &&
+			queueCfg.GetScheduling() != topology_config.SchedulingModel_CYCLIC_QUEUING_FORWARDING {
+			return fmt.Errorf("queue %d of the CQF pair uses %s scheduling", queueCfg.GetQueueId(), queueCfg.GetScheduling())
+		}
+	}
+
+	if len(portCfg.GetTrafficClassTable()) == 0 && (even < 2 || odd < 2) {
+		// The default table (802.1Q Table 8-5) swaps priorities 0 and 1.
+		return fmt.Errorf("queues 0 and 1 need a traffic class table mapping their priorities to them")
+	}
+
+	for _, entry := range portCfg.GetTrafficClassTable() {
+		if (entry.GetPcp() == even || entry.GetPcp() == odd) && entry.GetEgressQueueId() != entry.GetPcp() {
+			return fmt.Errorf(
+				"priority %d of the CQF pair maps to traffic class %d instead of %d",
+				entry.GetPcp(),
+				entry.GetEgressQueueId(),
+				entry.GetPcp(),
+			)
+		}
+	}
+
+	return nil
+}
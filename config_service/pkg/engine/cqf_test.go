@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"testing"
+
+	cqf "OpenCNC_config_service/common/structures/cqf"
+	psfp "OpenCNC_config_service/common/structures/psfp"
+	qbv "OpenCNC_config_service/common/structures/qbv"
+	"OpenCNC_config_service/common/structures/topology_config"
+)
+
+func testCqfConfig() *topology_config.TopologyConfig {
+	return &topology_config.TopologyConfig{
+		ConfigId: "cfg-1",
+		NodeConfigs: []*topology_config.NodeConfig{{
+			NodeId: "a",
+			Bridge: &topology_config.BridgeConfig{
+				Cqf: &cqf.CqfConfig{
+					CycleTimeNs:          250_000,
+					BaseTimeNs:           1_000_000,
+					EvenQueueId:          6,
+					OddQueueId:           7,
+					EgressPortIds:        []string{"sw0p2", "sw0p3"},
+					BackgroundGateStates: 0x01,
+					StreamGates:          []*cqf.CqfStreamGate{{Name: "cqf-in", InstanceId: 4}},
+				},
+			},
+		}},
+	}
+}
+
+func TestCompileCqf_SchedulesAndStreamGates(t *testing.T) {
+	cfg := testCqfConfig()
+
+	compiled, err := compileCqf(cfg)
+	if err != nil {
+		t.Fatalf("compileCqf failed: %v", err)
+	}
+
+	if cfg.NodeConfigs[0].Bridge.Cqf == nil || len(cfg.NodeConfigs[0].PortConfigs) != 0 {
+		t.Fatalf("compileCqf modified the caller's configuration")
+	}
+
+	nodeCfg := compiled.NodeConfigs[0]
+	if nodeCfg.Bridge.Cqf != nil {
+		t.Fatalf("expected the CQF intent to be replaced")
+	}
+
+	if len(nodeCfg.PortConfigs) != 2 {
+		t.Fatalf("expected 2 egress ports, got %d", len(nodeCfg.PortConfigs))
+	}
+	for _, portCfg := range nodeCfg.PortConfigs {
+		gcl := portCfg.GetGcl()
+		if gcl.GetCycleTime() != 500_000 || len(gcl.GetEntries()) != 2 {
+			t.Fatalf("port %s: unexpected schedule %v", portCfg.PortId, gcl)
+		}
+		if gcl.Entries[0].GateStates[0] != 0x41 || gcl.Entries[1].GateStates[0] != 0x81 {
+			t.Fatalf("port %s: expected gates 0x41/0x81, got %#x/%#x",
+				portCfg.PortId, gcl.Entries[0].GateStates[0], gcl.Entries[1].GateStates[0])
+		}
+	}
+
+	gates := nodeCfg.Bridge.GetPsfp().GetStreamGates()
+	if len(gates) != 1 || gates[0].GetInstanceId() != 4 || gates[0].GetCycleTimeNs() != 500_000 {
+		t.Fatalf("unexpected stream gates %v", gates)
+	}
+	gcl := gates[0].GetGateControlList()
+	if gcl[0].GetPriorityOverride() != 7 || gcl[1].GetPriorityOverride() != 6 ||
+		gcl[0].GetGateState() != psfp.GateState_OPEN {
+		t.Fatalf("expected frames stored in the idle queue, got %v", gcl)
+	}
+}
+
+func TestCompileCqf_RejectsExistingSchedule(t *testing.T) {
+	cfg := testCqfConfig()
+	cfg.NodeConfigs[0].PortConfigs = []*topology_config.PortConfig{{
+		PortId: "sw0p3",
+		Gcl:    &qbv.GateControlList{ScheduleId: "own"},
+	}}
+
+	if _, err := compileCqf(cfg); err == nil {
+		t.Fatalf("expected an error for an egress port with its own schedule")
+	}
+}
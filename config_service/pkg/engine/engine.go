@@ -1,52 +1,193 @@
 package engine
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"OpenCNC_config_service/common/observability"
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/config_history"
 	"OpenCNC_config_service/common/structures/topology"
 	"OpenCNC_config_service/common/structures/topology_config"
+	"OpenCNC_config_service/common/structures/transaction"
 	protocolbackends "OpenCNC_config_service/config_service/pkg/protocolbackends"
+
+	"github.com/golang/protobuf/proto"
 )
 
 type Operation struct {
 	Node      *topology.Node
 	Config    *topology_config.NodeConfig
+	Restore   []byte // snapshot prepared instead of Config, see protocolbackends.SnapshotRestorer
 	Backend   protocolbackends.ProtocolBackend
 	Prepared  bool
 	Committed bool
+	Locked    bool
+
+	results []NodeResult // per-phase outcomes, written only by the worker owning this operation
 }
 
-type ConfigurationTransaction struct {
+// Phase identifies the step of a configuration transaction a NodeResult belongs to.
+type Phase string
+
+const (
+	PhaseHealth   Phase = "health"
+	PhaseLock     Phase = "lock"
+	PhasePrepare  Phase = "prepare"
+	PhaseCommit   Phase = "commit"
+	PhaseConfirm  Phase = "confirm"
+	PhaseRollback Phase = "rollback"
+	PhaseUnlock   Phase = "unlock"
+)
+
+// NodeResult reports the outcome and timing of one phase on one node.
+type NodeResult struct {
+	Node     string
+	Phase    Phase
+	Started  time.Time
+	Duration time.Duration
+	Err      error
+}
+
+func (r NodeResult) Succeeded() bool {
+	return r.Err == nil
+}
+
+// TransactionResult is the deterministic aggregate of all per-node results of a
+// transaction: results are ordered by operation, then by the order phases ran.
+type TransactionResult struct {
+	ConfigId string
+	Version  uint64 // version recorded in the configuration history; 0 without a store
+	Nodes    []NodeResult
+}
+
+// NodeApplyResults converts the per-node results to their history records.
+func (r *TransactionResult) NodeApplyResults() []*config_history.NodeApplyResult {
+	if r == nil {
+		return nil
+	}
+
+	var results []*config_history.NodeApplyResult
+	for _, res := range r.Nodes {
+		nodeResult := &config_history.NodeApplyResult{
+			NodeId:     res.Node,
+			Phase:      string(res.Phase),
+			DurationNs: uint64(res.Duration),
+		}
+		if res.Err != nil {
+			nodeResult.Error = res.Err.Error()
+		}
+		results = append(results, nodeResult)
+	}
+	return results
+}
+
+// Failed returns the failed results of the given phase in operation order.
+func (r *TransactionResult) Failed(phase Phase) []NodeResult {
+	if r == nil {
+		return nil
+	}
+
+	var failed []NodeResult
+	for _, res := range r.Nodes {
+		if res.Phase == phase && res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// TransactionError is returned when a phase fails on one or more nodes.
+// It carries every per-node result of the transaction, not only the first error.
+type TransactionError struct {
 	ConfigId   string
-	Operations []Operation
+	Phase      Phase
+	RolledBack bool
+	Result     *TransactionResult
 }
 
-func (t *ConfigurationTransaction) Commit() error {
+func (e *TransactionError) Error() string {
+	failed := e.Result.Failed(e.Phase)
 
-	for i := range t.Operations {
+	parts := make([]string, 0, len(failed))
+	for _, res := range failed {
+		parts = append(parts, fmt.Sprintf("%s: %v", res.Node, res.Err))
+	}
 
-		op := &t.Operations[i]
+	msg := fmt.Sprintf("%s failed for node(s) [%s]", e.Phase, strings.Join(parts, "; "))
+	if e.RolledBack {
+		msg += ", aborted transaction and rolled back previous commits"
+	}
+	return msg
+}
 
-		if err := op.Backend.Commit(op.Node); err != nil {
+func (e *TransactionError) Unwrap() []error {
+	var errs []error
+	for _, res := range e.Result.Failed(e.Phase) {
+		errs = append(errs, res.Err)
+	}
+	return errs
+}
+
+type ConfigurationTransaction struct {
+	ConfigId     string
+	Version      uint64 // configuration history version; 0 if not recorded
+	RolledBackTo uint64 // version restored by RollbackTo; 0 when applying a configuration
+	Operations   []Operation
+
+	MaxWorkers  int           // concurrent nodes per phase; <= 0 means one worker per node
+	NodeTimeout time.Duration // per-node deadline for each phase; 0 means no deadline
+}
 
-			// Roll back everything that was already committed.
-			t.Rollback()
+// Commit pushes every prepared operation concurrently. Backends implementing
+// protocolbackends.Confirmer are confirmed only after every node committed.
+// If any node fails, every node that did commit is rolled back in reverse
+// operation order.
+func (t *ConfigurationTransaction) Commit(ctx context.Context) error {
 
-			return fmt.Errorf(
-				"commit failed for node %s, Aborted transaction and rolled back previous commits",
-				op.Node.Name,
-			)
+	phase := PhaseCommit
+
+	failed := t.runPhase(ctx, phase, func(ctx context.Context, op *Operation) error {
+		if err := op.Backend.Commit(ctx, op.Node); err != nil {
+			return err
 		}
 
 		op.Committed = true
 		op.Prepared = false
+		return nil
+	}, nil)
+
+	if !failed {
+		phase = PhaseConfirm
+
+		failed = t.runPhase(ctx, phase, func(ctx context.Context, op *Operation) error {
+			return op.Backend.(protocolbackends.Confirmer).Confirm(ctx, op.Node)
+		}, needsConfirm)
 	}
 
-	return nil
+	if !failed {
+		return nil
+	}
+
+	// Roll back everything that was already committed.
+	// The rollback gets a fresh context: the caller's may be what expired.
+	t.Rollback(context.WithoutCancel(ctx))
+
+	return &TransactionError{
+		ConfigId:   t.ConfigId,
+		Phase:      phase,
+		RolledBack: true,
+		Result:     t.Result(),
+	}
 }
 
-func (t *ConfigurationTransaction) Rollback() error {
+// Rollback restores every committed operation, one node at a time in reverse
+// operation order.
+func (t *ConfigurationTransaction) Rollback(ctx context.Context) error {
 
 	var firstErr error
 
@@ -58,7 +199,10 @@ func (t *ConfigurationTransaction) Rollback() error {
 			continue
 		}
 
-		if err := op.Backend.Rollback(op.Node); err != nil {
+		err := t.runNode(ctx, PhaseRollback, op, func(ctx context.Context, op *Operation) error {
+			return op.Backend.Rollback(ctx, op.Node)
+		})
+		if err != nil {
 			if firstErr == nil {
 				firstErr = err
 			}
@@ -70,25 +214,205 @@ func (t *ConfigurationTransaction) Rollback() error {
 	return firstErr
 }
 
-func (t *ConfigurationTransaction) Prepare() error {
+// Prepare builds the working snapshot of every operation concurrently, from
+// its configuration or from the snapshot it restores.
+func (t *ConfigurationTransaction) Prepare(ctx context.Context) error {
+
+	failed := t.runPhase(ctx, PhasePrepare, func(ctx context.Context, op *Operation) error {
+		var err error
+		if op.Restore != nil {
+			err = op.Backend.(protocolbackends.SnapshotRestorer).RestoreSnapshot(ctx, op.Node, op.Restore)
+		} else {
+			err = op.Backend.PrepareSnapshot(ctx, op.Config, op.Node)
+		}
+		if err != nil {
+			return err
+		}
+
+		op.Prepared = true
+		return nil
+	}, nil)
+
+	if !failed {
+		return nil
+	}
+
+	return &TransactionError{
+		ConfigId: t.ConfigId,
+		Phase:    PhasePrepare,
+		Result:   t.Result(),
+	}
+}
+
+// CheckHealth fails the transaction up front if a backend already knows one of
+// its nodes to be unreachable, instead of waiting for that node to time out.
+func (t *ConfigurationTransaction) CheckHealth(ctx context.Context) error {
+
+	failed := t.runPhase(ctx, PhaseHealth, func(ctx context.Context, op *Operation) error {
+		return op.Backend.(protocolbackends.HealthChecker).CheckHealth(op.Node)
+	}, tracksHealth)
+
+	if !failed {
+		return nil
+	}
+
+	return &TransactionError{
+		ConfigId: t.ConfigId,
+		Phase:    PhaseHealth,
+		Result:   t.Result(),
+	}
+}
+
+// Lock takes the datastore lock of every node whose backend supports locking.
+// If any lock is denied, the locks already taken are released and the
+// transaction must be aborted.
+func (t *ConfigurationTransaction) Lock(ctx context.Context) error {
+
+	failed := t.runPhase(ctx, PhaseLock, func(ctx context.Context, op *Operation) error {
+		if err := op.Backend.(protocolbackends.Locker).Lock(ctx, op.Node); err != nil {
+			return err
+		}
+
+		op.Locked = true
+		return nil
+	}, needsLock)
+
+	if !failed {
+		return nil
+	}
+
+	t.Unlock(context.WithoutCancel(ctx))
+
+	return &TransactionError{
+		ConfigId: t.ConfigId,
+		Phase:    PhaseLock,
+		Result:   t.Result(),
+	}
+}
+
+// Unlock releases every lock taken by Lock.
+func (t *ConfigurationTransaction) Unlock(ctx context.Context) error {
+
+	failed := t.runPhase(ctx, PhaseUnlock, func(ctx context.Context, op *Operation) error {
+		op.Locked = false
+		return op.Backend.(protocolbackends.Locker).Unlock(ctx, op.Node)
+	}, func(op *Operation) bool { return op.Locked })
+
+	if !failed {
+		return nil
+	}
+
+	return &TransactionError{
+		ConfigId: t.ConfigId,
+		Phase:    PhaseUnlock,
+		Result:   t.Result(),
+	}
+}
+
+// Result returns the per-node outcomes recorded so far.
+func (t *ConfigurationTransaction) Result() *TransactionResult {
+	result := &TransactionResult{ConfigId: t.ConfigId}
 
 	for i := range t.Operations {
+		result.Nodes = append(result.Nodes, t.Operations[i].results...)
+	}
 
-		op := &t.Operations[i]
+	return result
+}
 
-		if err := op.Backend.PrepareSnapshot(op.Config, op.Node); err != nil {
+func tracksHealth(op *Operation) bool {
+	_, ok := op.Backend.(protocolbackends.HealthChecker)
+	return ok
+}
+
+func needsLock(op *Operation) bool {
+	_, ok := op.Backend.(protocolbackends.Locker)
+	return ok
+}
 
-			return fmt.Errorf(
-				"prepare failed for node %s: %w",
-				op.Node.Name,
-				err,
-			)
+func needsConfirm(op *Operation) bool {
+	_, ok := op.Backend.(protocolbackends.Confirmer)
+	return ok
+}
+
+// runPhase runs fn for every operation accepted by include (all when nil), with
+// at most MaxWorkers nodes in flight, and reports whether any of them failed.
+func (t *ConfigurationTransaction) runPhase(ctx context.Context, phase Phase, fn func(context.Context, *Operation) error, include func(*Operation) bool) bool {
+
+	var selected []int
+	for i := range t.Operations {
+		if include == nil || include(&t.Operations[i]) {
+			selected = append(selected, i)
 		}
+	}
 
-		op.Prepared = true
+	if len(selected) == 0 {
+		return false
 	}
 
-	return nil
+	workers := t.MaxWorkers
+	if workers <= 0 || workers > len(selected) {
+		workers = len(selected)
+	}
+
+	jobs := make(chan int)
+	errs := make([]error, len(t.Operations))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = t.runNode(ctx, phase, &t.Operations[i], fn)
+			}
+		}()
+	}
+
+	for _, i := range selected {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// runNode runs fn for a single operation under the per-node deadline and
+// records its outcome.
+func (t *ConfigurationTransaction) runNode(ctx context.Context, phase Phase, op *Operation, fn func(context.Context, *Operation) error) error {
+
+	nodeCtx := ctx
+	if t.NodeTimeout > 0 {
+		var cancel context.CancelFunc
+		nodeCtx, cancel = context.WithTimeout(ctx, t.NodeTimeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+
+	err := nodeCtx.Err()
+	if err == nil {
+		err = fn(nodeCtx, op)
+	}
+	if err != nil && errors.Is(nodeCtx.Err(), context.DeadlineExceeded) && !errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	}
+
+	op.results = append(op.results, NodeResult{
+		Node:     op.Node.GetName(),
+		Phase:    phase,
+		Started:  started,
+		Duration: time.Since(started),
+		Err:      err,
+	})
+
+	return err
 }
 
 func NewConfigurationTransaction(configId string) *ConfigurationTransaction {
@@ -108,13 +432,63 @@ type MappingEngine struct {
 	lastTransaction *ConfigurationTransaction // last applied configuration transaction
 
 	backends map[topology.ManagementProtocol]protocolbackends.ProtocolBackend
+
+	store storewrapper.Store // applied configurations; nil keeps them in memory only
+
+	maxWorkers  int
+	nodeTimeout time.Duration
+}
+
+// Option configures optional MappingEngine behaviour.
+type Option func(*MappingEngine)
+
+// WithMaxWorkers limits how many nodes are prepared or committed concurrently.
+func WithMaxWorkers(n int) Option {
+	return func(m *MappingEngine) {
+		m.maxWorkers = n
+	}
+}
+
+// WithNodeTimeout sets the deadline given to each node for each transaction phase.
+func WithNodeTimeout(d time.Duration) Option {
+	return func(m *MappingEngine) {
+		m.nodeTimeout = d
+	}
+}
+
+// WithStore persists the configurations the engine applies in the store, and
+// records every transaction in the configuration history.
+func WithStore(store storewrapper.Store) Option {
+	return func(m *MappingEngine) {
+		m.store = store
+	}
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx naming who requests the configurations
+// applied with it, recorded in the configuration history.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, empty if none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
 }
 
-func NewMappingEngine(logger observability.Logger) *MappingEngine {
-	return &MappingEngine{
+func NewMappingEngine(logger observability.Logger, opts ...Option) *MappingEngine {
+	m := &MappingEngine{
 		logger:   observability.NormalizeLogger(logger),
 		backends: make(map[topology.ManagementProtocol]protocolbackends.ProtocolBackend),
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
 }
 
 func (m *MappingEngine) RegisterBackend(backend protocolbackends.ProtocolBackend) {
@@ -128,12 +502,26 @@ func (m *MappingEngine) GetLastTransactionId() *string {
 	return &m.lastTransaction.ConfigId
 }
 
-func (m *MappingEngine) ApplyConfiguration(topo *topology.Topology, cfg *topology_config.TopologyConfig, secret string) error {
+func (m *MappingEngine) ApplyConfiguration(ctx context.Context, topo *topology.Topology, cfg *topology_config.TopologyConfig) (*TransactionResult, error) {
 	if topo == nil || cfg == nil {
-		return fmt.Errorf("topology and config must not be nil")
+		return nil, fmt.Errorf("topology and config must not be nil")
+	}
+
+	requested := cfg
+
+	cfg, err := m.resolveLinkOverrides(topo, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err = compileCqf(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	tx := NewConfigurationTransaction(cfg.GetConfigId())
+	tx.MaxWorkers = m.maxWorkers
+	tx.NodeTimeout = m.nodeTimeout
 
 	for _, node := range topo.Nodes {
 		if node == nil || node.ManagementInfo == nil {
@@ -160,22 +548,171 @@ func (m *MappingEngine) ApplyConfiguration(topo *topology.Topology, cfg *topolog
 		})
 	}
 
-	if err := tx.Prepare(); err != nil {
-		return err
+	return m.execute(ctx, tx, requested)
+}
+
+// execute runs tx, records it in the configuration history as applying
+// requested, and makes it the last transaction if it succeeded.
+func (m *MappingEngine) execute(ctx context.Context, tx *ConfigurationTransaction, requested *topology_config.TopologyConfig) (*TransactionResult, error) {
+	err := tx.CheckHealth(ctx)
+	if err == nil {
+		err = tx.Lock(ctx)
+	}
+	if err == nil {
+		err = tx.Prepare(ctx)
+		if err == nil {
+			err = tx.Commit(ctx)
+		}
+		m.unlock(ctx, tx)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+	result := tx.Result()
+	m.logResult(result)
+
+	result.Version = m.recordHistory(ctx, tx, requested, err)
+	tx.Version = result.Version
+
+	if err != nil {
+		return result, err
 	}
 
 	// transaction promotion: update the current and previous transaction IDs
 	m.lastTransaction = tx
+	m.persistTransaction(ctx, tx)
+	m.recordSnapshots(ctx, tx)
 
-	// TODO:
-	// Persist the new configuration in the KV store only after all
-	// backends have successfully committed.
+	// Persisted only after all backends committed. The devices run the
+	// configuration either way, so a store failure does not fail it.
+	if m.store != nil {
+		if err := storewrapper.StoreConfiguration(context.WithoutCancel(ctx), m.store, requested); err != nil {
+			m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		}
+	}
 
-	return nil
+	return result, nil
+}
+
+// recordHistory records the outcome of tx in the configuration history and
+// returns its version, 0 without a store or if it could not be recorded.
+// Like the configuration itself, a failed record does not fail the transaction.
+func (m *MappingEngine) recordHistory(ctx context.Context, tx *ConfigurationTransaction, requested *topology_config.TopologyConfig, txErr error) uint64 {
+	if m.store == nil {
+		return 0
+	}
+
+	record := &config_history.ConfigurationRecord{
+		TimestampNs:   uint64(time.Now().UnixNano()),
+		Actor:         ActorFromContext(ctx),
+		Result:        config_history.ApplyResult_APPLY_RESULT_SUCCEEDED,
+		Configuration: requested,
+		RolledBackTo:  tx.RolledBackTo,
+	}
+
+	var txError *TransactionError
+	switch {
+	case errors.As(txErr, &txError) && txError.RolledBack:
+		record.Result = config_history.ApplyResult_APPLY_RESULT_ROLLED_BACK
+	case txErr != nil:
+		record.Result = config_history.ApplyResult_APPLY_RESULT_FAILED
+	}
+	if txErr != nil {
+		record.Message = txErr.Error()
+	}
+
+	for i := range tx.Operations {
+		record.NodeIds = append(record.NodeIds, tx.Operations[i].Node.GetName())
+	}
+
+	record.NodeResults = tx.Result().NodeApplyResults()
+
+	version, err := storewrapper.RecordConfiguration(context.WithoutCancel(ctx), m.store, record)
+	if err != nil {
+		m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		return version
+	}
+
+	m.logger.Printf("transaction %s: recorded as configuration version %d", tx.ConfigId, version)
+	return version
+}
+
+// recordSnapshots stores the snapshot each node of tx runs with the history
+// version of tx, the target of a later rollback to that version, and the
+// snapshot it ran before as its baseline if it is configured the first time.
+func (m *MappingEngine) recordSnapshots(ctx context.Context, tx *ConfigurationTransaction) {
+	if m.store == nil || tx.Version == 0 {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	for i := range tx.Operations {
+		op := &tx.Operations[i]
+
+		restorer, ok := op.Backend.(protocolbackends.SnapshotRestorer)
+		if !ok {
+			continue
+		}
+
+		current, lastStable, ok := restorer.Snapshots(op.Node.GetName())
+		if !ok {
+			continue
+		}
+
+		if err := storewrapper.StoreVersionSnapshot(ctx, m.store, tx.Version, op.Node.GetName(), current); err != nil {
+			m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		}
+		if lastStable == nil {
+			continue
+		}
+		if err := storewrapper.StoreBaselineSnapshot(ctx, m.store, op.Node.GetName(), lastStable); err != nil {
+			m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		}
+	}
+}
+
+// unlock releases the transaction's locks. A failed unlock does not change the
+// outcome of the transaction: the device drops the lock with the session anyway.
+func (m *MappingEngine) unlock(ctx context.Context, tx *ConfigurationTransaction) {
+	if err := tx.Unlock(context.WithoutCancel(ctx)); err != nil {
+		m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+	}
+}
+
+func (m *MappingEngine) logResult(result *TransactionResult) {
+	for _, res := range result.Nodes {
+		if res.Err != nil {
+			m.logger.Printf("transaction %s: %-8s %-20s failed after %v: %v", result.ConfigId, res.Phase, res.Node, res.Duration, res.Err)
+			continue
+		}
+		m.logger.Printf("transaction %s: %-8s %-20s ok in %v", result.ConfigId, res.Phase, res.Node, res.Duration)
+	}
+}
+
+// SyncSnapshots re-reads the configuration of every managed node whose backend
+// keeps device snapshots, seeding snapshots of new nodes and resyncing drifted ones.
+func (m *MappingEngine) SyncSnapshots(ctx context.Context, topo *topology.Topology) error {
+	if topo == nil {
+		return fmt.Errorf("topology must not be nil")
+	}
+
+	var errs []error
+
+	for _, node := range topo.Nodes {
+		if node == nil || node.ManagementInfo == nil {
+			continue
+		}
+
+		syncer, ok := m.backends[node.ManagementInfo.Protocol].(protocolbackends.SnapshotSyncer)
+		if !ok {
+			continue
+		}
+
+		if err := syncer.SyncSnapshot(ctx, node); err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", node.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func findNodeConfig(cfg *topology_config.TopologyConfig, nodeName string) *topology_config.NodeConfig {
@@ -187,18 +724,409 @@ func findNodeConfig(cfg *topology_config.TopologyConfig, nodeName string) *topol
 	return nil
 }
 
-func (m *MappingEngine) Rollback() error {
+// resolveLinkOverrides applies the state overrides of the links of cfg to the
+// admin state of the ports at both ends of each link, found in the topology.
+// The override decides the state of the ports: it replaces the admin state and
+// directions their configuration sets. cfg is returned as is without
+// overrides, otherwise a copy is modified.
+func (m *MappingEngine) resolveLinkOverrides(topo *topology.Topology, cfg *topology_config.TopologyConfig) (*topology_config.TopologyConfig, error) {
+	var overrides []*topology_config.LinkConfig
+	for _, linkCfg := range cfg.GetLinkConfigs() {
+		if linkCfg.GetStateOverride() != topology_config.LinkStateOverride_UNDEFINED {
+			overrides = append(overrides, linkCfg)
+		}
+	}
+
+	if len(overrides) == 0 {
+		return cfg, nil
+	}
+
+	resolved := proto.Clone(cfg).(*topology_config.TopologyConfig)
+
+	for _, linkCfg := range overrides {
+		link := findLink(topo, linkCfg.GetLinkId())
+		if link == nil {
+			return nil, fmt.Errorf("link %q has a state override but is not in the topology", linkCfg.GetLinkId())
+		}
+
+		state := topology_config.AdminState_ADMIN_STATE_ENABLED
+		if linkCfg.GetStateOverride() == topology_config.LinkStateOverride_FORCE_DOWN {
+			state = topology_config.AdminState_ADMIN_STATE_DISABLED
+		}
+
+		ends := [][2]string{
+			{link.GetSourceNode(), link.GetSourcePort()},
+			{link.GetTargetNode(), link.GetTargetPort()},
+		}
+
+		for _, end := range ends {
+			if end[0] == "" || end[1] == "" {
+				return nil, fmt.Errorf("link %q has an end without node or port", link.GetId())
+			}
+
+			portCfg := ensurePortConfig(resolved, end[0], end[1])
+
+			if (portCfg.AdminState != nil && portCfg.GetAdminState() != state) ||
+				portCfg.IngressEnabled != nil || portCfg.EgressEnabled != nil {
+				m.logger.Printf(
+					"link %s: %s overrides the state configured for %s/%s",
+					link.GetId(),
+					linkCfg.GetStateOverride(),
+					end[0],
+					end[1],
+				)
+			}
+
+			portCfg.AdminState = state.Enum()
+			portCfg.IngressEnabled = nil
+			portCfg.EgressEnabled = nil
+		}
+	}
+
+	return resolved, nil
+}
+
+func findLink(topo *topology.Topology, linkID string) *topology.Link {
+	for _, link := range topo.GetLinks() {
+		if link != nil && link.GetId() == linkID {
+			return link
+		}
+	}
+	return nil
+}
+
+// ensurePortConfig returns the configuration of the port of the node in cfg,
+// adding the node and port configurations when missing.
+func ensurePortConfig(cfg *topology_config.TopologyConfig, nodeName, portID string) *topology_config.PortConfig {
+	nodeCfg := findNodeConfig(cfg, nodeName)
+	if nodeCfg == nil {
+		nodeCfg = &topology_config.NodeConfig{NodeId: nodeName}
+		cfg.NodeConfigs = append(cfg.NodeConfigs, nodeCfg)
+	}
+
+	return ensureNodePortConfig(nodeCfg, portID)
+}
+
+// ensureNodePortConfig returns the configuration of the port in nodeCfg,
+// adding it when missing.
+func ensureNodePortConfig(nodeCfg *topology_config.NodeConfig, portID string) *topology_config.PortConfig {
+	for _, portCfg := range nodeCfg.GetPortConfigs() {
+		if portCfg != nil && portCfg.GetPortId() == portID {
+			return portCfg
+		}
+	}
+
+	portCfg := &topology_config.PortConfig{PortId: portID}
+	nodeCfg.PortConfigs = append(nodeCfg.PortConfigs, portCfg)
+
+	return portCfg
+}
+
+func (m *MappingEngine) Rollback(ctx context.Context) (*TransactionResult, error) {
 
 	if m.lastTransaction == nil {
-		return fmt.Errorf("transaction rollback is available only after a successful configuration transaction!!")
+		return nil, fmt.Errorf("transaction rollback is available only after a successful configuration transaction!!")
 	}
 
-	if err := m.lastTransaction.Rollback(); err != nil {
-		return err
+	tx := m.lastTransaction
+
+	if err := tx.CheckHealth(ctx); err != nil {
+		return tx.Result(), err
+	}
+
+	if err := tx.Lock(ctx); err != nil {
+		return tx.Result(), err
+	}
+
+	err := tx.Rollback(ctx)
+	m.unlock(ctx, tx)
+
+	// Persisted either way: nodes that failed to roll back stay committed
+	// and can be retried, also after a restart.
+	m.persistTransaction(ctx, tx)
+
+	result := tx.Result()
+	result.Version = m.recordUndo(ctx, tx, err)
+
+	if err != nil {
+		return result, err
 	}
 
 	m.lastTransaction = nil
 
+	return result, nil
+}
+
+// recordUndo records the rollback of tx in the configuration history as a
+// new version restoring the configuration the network ran before tx, with
+// the nodes rolled back so far, and returns it. The snapshots those nodes
+// run are stored with it, so RollbackTo sees them changed.
+func (m *MappingEngine) recordUndo(ctx context.Context, tx *ConfigurationTransaction, txErr error) uint64 {
+	if m.store == nil || tx.Version == 0 {
+		return 0
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	restored, err := storewrapper.ConfigurationVersionBefore(ctx, m.store, tx.Version)
+	if err != nil {
+		m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		return 0
+	}
+
+	record := &config_history.ConfigurationRecord{
+		TimestampNs:  uint64(time.Now().UnixNano()),
+		Actor:        ActorFromContext(ctx),
+		Result:       config_history.ApplyResult_APPLY_RESULT_SUCCEEDED,
+		RolledBackTo: restored,
+		Undone:       tx.Version,
+		NodeResults:  tx.Result().NodeApplyResults(),
+	}
+	if txErr != nil {
+		record.Result = config_history.ApplyResult_APPLY_RESULT_FAILED
+		record.Message = txErr.Error()
+	}
+
+	if restored > 0 {
+		restoredRecord, err := storewrapper.GetConfigurationVersion(ctx, m.store, restored)
+		if err != nil {
+			m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+			return 0
+		}
+		record.Configuration = restoredRecord.GetConfiguration()
+	}
+
+	undo := &ConfigurationTransaction{ConfigId: tx.ConfigId}
+
+	for i := range tx.Operations {
+		op := &tx.Operations[i]
+		if !op.Committed && rolledBack(op) {
+			record.NodeIds = append(record.NodeIds, op.Node.GetName())
+			undo.Operations = append(undo.Operations, Operation{Node: op.Node, Backend: op.Backend})
+		}
+	}
+
+	version, err := storewrapper.RecordConfiguration(ctx, m.store, record)
+	if err != nil {
+		m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+		return version
+	}
+
+	m.logger.Printf("transaction %s: rollback recorded as configuration version %d", tx.ConfigId, version)
+
+	if txErr == nil {
+		undo.Version = version
+		m.recordSnapshots(ctx, undo)
+	}
+
+	return version
+}
+
+// rolledBack reports whether op was rolled back successfully.
+func rolledBack(op *Operation) bool {
+	for _, res := range op.results {
+		if res.Phase == PhaseRollback && res.Err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RollbackTo restores every node changed since a succeeded configuration
+// version to the snapshot it ran at that version, or before its first
+// configuration if it was configured only later. The nodes are restored in a
+// single transaction, recorded in the history as a new version applying the
+// configuration of the target, which Rollback can undo.
+func (m *MappingEngine) RollbackTo(ctx context.Context, topo *topology.Topology, version uint64) (*TransactionResult, error) {
+	if topo == nil {
+		return nil, fmt.Errorf("topology must not be nil")
+	}
+	if m.store == nil {
+		return nil, fmt.Errorf("rollback to a configuration version requires a store")
+	}
+
+	records, err := storewrapper.ListConfigurationHistory(ctx, m.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *config_history.ConfigurationRecord
+
+	latest := make(map[string]uint64)   // latest succeeded version of each node
+	atTarget := make(map[string]uint64) // latest succeeded version of each node up to the target
+
+	for _, record := range records {
+		if record.GetResult() != config_history.ApplyResult_APPLY_RESULT_SUCCEEDED {
+			continue
+		}
+		if record.GetVersion() == version {
+			target = record
+		}
+		for _, node := range record.GetNodeIds() {
+			latest[node] = record.GetVersion()
+			if record.GetVersion() <= version {
+				atTarget[node] = record.GetVersion()
+			}
+		}
+	}
+
+	if target == nil {
+		return nil, fmt.Errorf("version %d is not a succeeded configuration", version)
+	}
+
+	tx := NewConfigurationTransaction(target.GetConfiguration().GetConfigId())
+	tx.RolledBackTo = version
+	tx.MaxWorkers = m.maxWorkers
+	tx.NodeTimeout = m.nodeTimeout
+
+	for _, node := range topo.Nodes {
+		if node == nil || node.ManagementInfo == nil {
+			continue
+		}
+
+		nodeVersion, configured := latest[node.Name]
+		if !configured || nodeVersion == atTarget[node.Name] {
+			continue
+		}
+
+		backend, ok := m.backends[node.ManagementInfo.Protocol]
+		if !ok {
+			return nil, fmt.Errorf("node %s: no backend registered for protocol %v", node.Name, node.ManagementInfo.Protocol)
+		}
+		if _, ok := backend.(protocolbackends.SnapshotRestorer); !ok {
+			return nil, fmt.Errorf("node %s: backend %s cannot restore snapshots", node.Name, backend.Name())
+		}
+
+		var snapshot []byte
+		if v := atTarget[node.Name]; v > 0 {
+			snapshot, err = storewrapper.GetVersionSnapshot(ctx, m.store, v, node.Name)
+		} else {
+			snapshot, err = storewrapper.GetBaselineSnapshot(ctx, m.store, node.Name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+
+		tx.Operations = append(tx.Operations, Operation{
+			Node:    node,
+			Restore: snapshot,
+			Backend: backend,
+		})
+	}
+
+	if len(tx.Operations) == 0 {
+		m.logger.Printf("no node changed since configuration version %d", version)
+		return tx.Result(), nil
+	}
+
+	return m.execute(ctx, tx, target.GetConfiguration())
+}
+
+// persistTransaction stores the state of tx as the last transaction, if the
+// engine has a store. Like the configuration, a failed write is only logged.
+func (m *MappingEngine) persistTransaction(ctx context.Context, tx *ConfigurationTransaction) {
+	if m.store == nil {
+		return
+	}
+
+	state := &transaction.TransactionState{
+		ConfigId:    tx.ConfigId,
+		Version:     tx.Version,
+		TimestampNs: uint64(time.Now().UnixNano()),
+	}
+
+	for i := range tx.Operations {
+		op := &tx.Operations[i]
+
+		opState := &transaction.TransactionOperation{
+			NodeId:    op.Node.GetName(),
+			Committed: op.Committed,
+		}
+		for _, res := range op.results {
+			if res.Err != nil {
+				continue
+			}
+			switch res.Phase {
+			case PhasePrepare:
+				opState.Prepared = true
+			case PhaseRollback:
+				opState.RolledBack = true
+			}
+		}
+
+		state.Operations = append(state.Operations, opState)
+	}
+
+	if err := storewrapper.StoreLastTransaction(context.WithoutCancel(ctx), m.store, state); err != nil {
+		m.logger.Printf("transaction %s: %v", tx.ConfigId, err)
+	}
+}
+
+// RestoreTransaction reloads the last transaction persisted in the store, so
+// Rollback keeps working after a restart. Only the nodes still committed are
+// restored, with the backends registered for their protocol in the stored
+// topology; the backends must have reloaded their snapshots.
+func (m *MappingEngine) RestoreTransaction(ctx context.Context) error {
+	if m.store == nil {
+		return fmt.Errorf("no store to restore the last transaction from")
+	}
+
+	state, err := storewrapper.GetLastTransaction(ctx, m.store)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+
+	topo, err := storewrapper.GetTopology(ctx, m.store)
+	if err != nil {
+		return err
+	}
+
+	tx := NewConfigurationTransaction(state.GetConfigId())
+	tx.Version = state.GetVersion()
+	tx.MaxWorkers = m.maxWorkers
+	tx.NodeTimeout = m.nodeTimeout
+
+	for _, opState := range state.GetOperations() {
+		if !opState.GetCommitted() {
+			continue
+		}
+
+		node := findNode(topo, opState.GetNodeId())
+		if node == nil || node.ManagementInfo == nil {
+			return fmt.Errorf("transaction %s: node %s is no longer managed in the topology", tx.ConfigId, opState.GetNodeId())
+		}
+
+		backend, ok := m.backends[node.ManagementInfo.Protocol]
+		if !ok {
+			return fmt.Errorf("transaction %s: no backend registered for protocol %v of node %s", tx.ConfigId, node.ManagementInfo.Protocol, node.Name)
+		}
+
+		tx.Operations = append(tx.Operations, Operation{
+			Node:      node,
+			Backend:   backend,
+			Committed: true,
+		})
+	}
+
+	if len(tx.Operations) == 0 {
+		return nil
+	}
+
+	m.lastTransaction = tx
+	m.logger.Printf("transaction %s: restored with %d committed node(s)", tx.ConfigId, len(tx.Operations))
+
+	return nil
+}
+
+func findNode(topo *topology.Topology, name string) *topology.Node {
+	for _, node := range topo.GetNodes() {
+		if node != nil && node.GetName() == name {
+			return node
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,790 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/config_history"
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/common/structures/topology_config"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type fakeBackend struct {
+	mu         sync.Mutex
+	failCommit map[string]error
+	block      map[string]bool // commit waits for ctx to expire
+	delay      time.Duration
+	rollbacks  []string
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (b *fakeBackend) Name() string { return "fake" }
+func (b *fakeBackend) Protocol() topology.ManagementProtocol {
+	return topology.ManagementProtocol_NETCONF
+}
+func (b *fakeBackend) AddPlugin(plugins.Plugin)  {}
+func (b *fakeBackend) Plugins() []plugins.Plugin { return nil }
+
+func (b *fakeBackend) PrepareSnapshot(ctx context.Context, _ *topology_config.NodeConfig, _ *topology.Node) error {
+	return nil
+}
+
+func (b *fakeBackend) Commit(ctx context.Context, node *topology.Node) error {
+	n := b.inFlight.Add(1)
+	defer b.inFlight.Add(-1)
+
+	for {
+		max := b.maxInFlight.Load()
+		if n <= max || b.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	if b.block[node.Name] {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	time.Sleep(b.delay)
+	return b.failCommit[node.Name]
+}
+
+func (b *fakeBackend) Rollback(ctx context.Context, node *topology.Node) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollbacks = append(b.rollbacks, node.Name)
+	return nil
+}
+
+func newTestTransaction(backend *fakeBackend, names ...string) *ConfigurationTransaction {
+	tx := NewConfigurationTransaction("tx-1")
+	for _, name := range names {
+		tx.Operations = append(tx.Operations, Operation{
+			Node:    &topology.Node{Name: name},
+			Config:  &topology_config.NodeConfig{NodeId: name},
+			Backend: backend,
+		})
+	}
+	return tx
+}
+
+func TestCommit_RespectsMaxWorkers(t *testing.T) {
+	backend := &fakeBackend{delay: 20 * time.Millisecond}
+	tx := newTestTransaction(backend, "a", "b", "c", "d", "e", "f")
+	tx.MaxWorkers = 2
+
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("expected commit to succeed, got %v", err)
+	}
+
+	if got := backend.maxInFlight.Load(); got != 2 {
+		t.Fatalf("expected 2 concurrent commits, got %d", got)
+	}
+
+	result := tx.Result()
+	if len(result.Nodes) != 6 {
+		t.Fatalf("expected 6 node results, got %d", len(result.Nodes))
+	}
+	for i, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		if result.Nodes[i].Node != name || result.Nodes[i].Phase != PhaseCommit {
+			t.Fatalf("unexpected result order at %d: %+v", i, result.Nodes[i])
+		}
+	}
+}
+
+func TestCommit_FailureRollsBackCommittedInReverseOrder(t *testing.T) {
+	errDevice := errors.New("device refused")
+	backend := &fakeBackend{failCommit: map[string]error{"c": errDevice}}
+	tx := newTestTransaction(backend, "a", "b", "c", "d")
+
+	err := tx.Commit(context.Background())
+
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected TransactionError, got %v", err)
+	}
+	if !txErr.RolledBack || txErr.Phase != PhaseCommit {
+		t.Fatalf("unexpected transaction error: %+v", txErr)
+	}
+	if !errors.Is(err, errDevice) {
+		t.Fatalf("expected error to wrap the device error, got %v", err)
+	}
+
+	want := []string{"d", "b", "a"}
+	if len(backend.rollbacks) != len(want) {
+		t.Fatalf("expected rollbacks %v, got %v", want, backend.rollbacks)
+	}
+	for i := range want {
+		if backend.rollbacks[i] != want[i] {
+			t.Fatalf("expected rollbacks %v, got %v", want, backend.rollbacks)
+		}
+	}
+
+	for _, op := range tx.Operations {
+		if op.Committed {
+			t.Fatalf("node %s still marked committed after rollback", op.Node.Name)
+		}
+	}
+}
+
+func TestCommit_NodeTimeout(t *testing.T) {
+	backend := &fakeBackend{block: map[string]bool{"slow": true}}
+	tx := newTestTransaction(backend, "fast", "slow")
+	tx.NodeTimeout = 20 * time.Millisecond
+
+	err := tx.Commit(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	failed := tx.Result().Failed(PhaseCommit)
+	if len(failed) != 1 || failed[0].Node != "slow" {
+		t.Fatalf("expected only the slow node to fail, got %+v", failed)
+	}
+
+	if len(backend.rollbacks) != 1 || backend.rollbacks[0] != "fast" {
+		t.Fatalf("expected the fast node to be rolled back, got %v", backend.rollbacks)
+	}
+}
+
+type confirmingBackend struct {
+	fakeBackend
+	failConfirm map[string]error
+	confirmed   sync.Map
+}
+
+func (b *confirmingBackend) Confirm(ctx context.Context, node *topology.Node) error {
+	if err := b.failConfirm[node.Name]; err != nil {
+		return err
+	}
+	b.confirmed.Store(node.Name, true)
+	return nil
+}
+
+func TestCommit_ConfirmsOnlyAfterAllNodesCommitted(t *testing.T) {
+	backend := &confirmingBackend{}
+	tx := NewConfigurationTransaction("tx-1")
+	for _, name := range []string{"a", "b"} {
+		tx.Operations = append(tx.Operations, Operation{
+			Node:    &topology.Node{Name: name},
+			Backend: backend,
+		})
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("expected commit to succeed, got %v", err)
+	}
+
+	result := tx.Result()
+	phases := []Phase{PhaseCommit, PhaseConfirm, PhaseCommit, PhaseConfirm}
+	if len(result.Nodes) != len(phases) {
+		t.Fatalf("expected %d results, got %+v", len(phases), result.Nodes)
+	}
+	for i, phase := range phases {
+		if result.Nodes[i].Phase != phase {
+			t.Fatalf("unexpected phase at %d: %+v", i, result.Nodes[i])
+		}
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if _, ok := backend.confirmed.Load(name); !ok {
+			t.Fatalf("node %s was not confirmed", name)
+		}
+	}
+	var lastCommitEnd time.Time
+	for _, res := range result.Nodes {
+		if end := res.Started.Add(res.Duration); res.Phase == PhaseCommit && end.After(lastCommitEnd) {
+			lastCommitEnd = end
+		}
+	}
+	for _, res := range result.Nodes {
+		if res.Phase == PhaseConfirm && res.Started.Before(lastCommitEnd) {
+			t.Fatalf("confirm of %s started before all commits finished", res.Node)
+		}
+	}
+}
+
+func TestCommit_ConfirmFailureRollsBack(t *testing.T) {
+	errConfirm := errors.New("confirm lost")
+	backend := &confirmingBackend{failConfirm: map[string]error{"b": errConfirm}}
+	tx := NewConfigurationTransaction("tx-1")
+	for _, name := range []string{"a", "b"} {
+		tx.Operations = append(tx.Operations, Operation{
+			Node:    &topology.Node{Name: name},
+			Backend: backend,
+		})
+	}
+
+	err := tx.Commit(context.Background())
+
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) || txErr.Phase != PhaseConfirm {
+		t.Fatalf("expected confirm TransactionError, got %v", err)
+	}
+	if len(backend.rollbacks) != 2 || backend.rollbacks[0] != "b" || backend.rollbacks[1] != "a" {
+		t.Fatalf("expected both nodes rolled back in reverse order, got %v", backend.rollbacks)
+	}
+}
+
+type lockingBackend struct {
+	fakeBackend
+	denyLock map[string]error
+	locked   sync.Map
+	prepared atomic.Int32
+}
+
+func (b *lockingBackend) PrepareSnapshot(ctx context.Context, _ *topology_config.NodeConfig, _ *topology.Node) error {
+	b.prepared.Add(1)
+	return nil
+}
+
+func (b *lockingBackend) Lock(ctx context.Context, node *topology.Node) error {
+	if err := b.denyLock[node.Name]; err != nil {
+		return err
+	}
+	b.locked.Store(node.Name, true)
+	return nil
+}
+
+func (b *lockingBackend) Unlock(ctx context.Context, node *topology.Node) error {
+	b.locked.Delete(node.Name)
+	return nil
+}
+
+func TestApplyConfiguration_LockDeniedAbortsTransaction(t *testing.T) {
+	errDenied := errors.New("running datastore is locked by session 42")
+	backend := &lockingBackend{denyLock: map[string]error{"b": errDenied}}
+
+	m := NewMappingEngine(nil)
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{}
+	cfg := &topology_config.TopologyConfig{ConfigId: "cfg-1"}
+	for _, name := range []string{"a", "b", "c"} {
+		topo.Nodes = append(topo.Nodes, &topology.Node{
+			Name:           name,
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		})
+		cfg.NodeConfigs = append(cfg.NodeConfigs, &topology_config.NodeConfig{NodeId: name})
+	}
+
+	_, err := m.ApplyConfiguration(context.Background(), topo, cfg)
+
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) || txErr.Phase != PhaseLock {
+		t.Fatalf("expected lock TransactionError, got %v", err)
+	}
+	if !errors.Is(err, errDenied) {
+		t.Fatalf("expected error to carry the lock holder, got %v", err)
+	}
+	if n := backend.prepared.Load(); n != 0 {
+		t.Fatalf("expected no node to be prepared, got %d", n)
+	}
+	backend.locked.Range(func(key, _ any) bool {
+		t.Fatalf("node %v still locked after abort", key)
+		return false
+	})
+	if m.GetLastTransactionId() != nil {
+		t.Fatalf("aborted transaction must not become the last transaction")
+	}
+}
+
+type recordingBackend struct {
+	fakeBackend
+	configs sync.Map // node name -> *topology_config.NodeConfig
+}
+
+func (b *recordingBackend) PrepareSnapshot(ctx context.Context, cfg *topology_config.NodeConfig, node *topology.Node) error {
+	b.configs.Store(node.Name, cfg)
+	return nil
+}
+
+func TestApplyConfiguration_LinkOverrideDisablesBothEnds(t *testing.T) {
+	backend := &recordingBackend{}
+
+	m := NewMappingEngine(nil)
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{
+		Links: []*topology.Link{{
+			Id:         "a-b",
+			SourceNode: "a",
+			SourcePort: "sw0p1",
+			TargetNode: "b",
+			TargetPort: "sw0p2",
+		}},
+	}
+	for _, name := range []string{"a", "b"} {
+		topo.Nodes = append(topo.Nodes, &topology.Node{
+			Name:           name,
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		})
+	}
+
+	enabled := topology_config.AdminState_ADMIN_STATE_ENABLED
+	cfg := &topology_config.TopologyConfig{
+		ConfigId: "cfg-1",
+		NodeConfigs: []*topology_config.NodeConfig{{
+			NodeId:      "a",
+			PortConfigs: []*topology_config.PortConfig{{PortId: "sw0p1", AdminState: &enabled}},
+		}},
+		LinkConfigs: []*topology_config.LinkConfig{{
+			LinkId:        "a-b",
+			StateOverride: topology_config.LinkStateOverride_FORCE_DOWN.Enum(),
+		}},
+	}
+
+	if _, err := m.ApplyConfiguration(context.Background(), topo, cfg); err != nil {
+		t.Fatalf("ApplyConfiguration failed: %v", err)
+	}
+
+	for node, port := range map[string]string{"a": "sw0p1", "b": "sw0p2"} {
+		v, ok := backend.configs.Load(node)
+		if !ok {
+			t.Fatalf("node %s was not prepared", node)
+		}
+		ports := v.(*topology_config.NodeConfig).GetPortConfigs()
+		if len(ports) != 1 || ports[0].GetPortId() != port ||
+			ports[0].GetAdminState() != topology_config.AdminState_ADMIN_STATE_DISABLED {
+			t.Fatalf("expected %s/%s disabled, got %v", node, port, ports)
+		}
+	}
+
+	if cfg.NodeConfigs[0].PortConfigs[0].GetAdminState() != enabled || len(cfg.NodeConfigs) != 1 {
+		t.Fatalf("the override modified the caller's configuration")
+	}
+}
+
+func TestApplyConfiguration_LinkOverrideUnknownLink(t *testing.T) {
+	m := NewMappingEngine(nil)
+	m.RegisterBackend(&recordingBackend{})
+
+	cfg := &topology_config.TopologyConfig{
+		ConfigId: "cfg-1",
+		LinkConfigs: []*topology_config.LinkConfig{{
+			LinkId:        "missing",
+			StateOverride: topology_config.LinkStateOverride_FORCE_UP.Enum(),
+		}},
+	}
+
+	if _, err := m.ApplyConfiguration(context.Background(), &topology.Topology{}, cfg); err == nil {
+		t.Fatalf("expected an error for a link missing from the topology")
+	}
+}
+
+func TestApplyConfiguration_StoresRequestedConfiguration(t *testing.T) {
+	store := storewrapper.NewMemoryStore()
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(&recordingBackend{})
+
+	topo := &topology.Topology{
+		Nodes: []*topology.Node{{
+			Name:           "a",
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		}},
+		Links: []*topology.Link{{Id: "a-b", SourceNode: "a", SourcePort: "sw0p1", TargetNode: "b", TargetPort: "sw0p2"}},
+	}
+	cfg := &topology_config.TopologyConfig{
+		ConfigId: "cfg-1",
+		NodeConfigs: []*topology_config.NodeConfig{{
+			NodeId: "a",
+		}},
+		LinkConfigs: []*topology_config.LinkConfig{{
+			LinkId:        "a-b",
+			StateOverride: topology_config.LinkStateOverride_FORCE_DOWN.Enum(),
+		}},
+	}
+
+	if _, err := m.ApplyConfiguration(context.Background(), topo, cfg); err != nil {
+		t.Fatalf("ApplyConfiguration failed: %v", err)
+	}
+
+	stored, err := storewrapper.GetConfiguration(context.Background(), store, "cfg-1")
+	if err != nil {
+		t.Fatalf("configuration not stored: %v", err)
+	}
+	if !proto.Equal(stored, cfg) {
+		t.Fatalf("expected the requested configuration, got %v", stored)
+	}
+}
+
+func TestApplyConfiguration_RecordsHistory(t *testing.T) {
+	ctx := WithActor(context.Background(), "operator")
+	store := storewrapper.NewMemoryStore()
+
+	backend := &fakeBackend{failCommit: map[string]error{"b": errors.New("commit refused")}}
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{}
+	for _, name := range []string{"a", "b"} {
+		topo.Nodes = append(topo.Nodes, &topology.Node{
+			Name:           name,
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		})
+	}
+
+	applied := &topology_config.TopologyConfig{
+		ConfigId:    "cfg-1",
+		NodeConfigs: []*topology_config.NodeConfig{{NodeId: "a"}},
+	}
+	result, err := m.ApplyConfiguration(ctx, topo, applied)
+	if err != nil {
+		t.Fatalf("ApplyConfiguration failed: %v", err)
+	}
+	if result.Version != 1 {
+		t.Fatalf("expected version 1, got %d", result.Version)
+	}
+
+	failing := &topology_config.TopologyConfig{
+		ConfigId:    "cfg-2",
+		NodeConfigs: []*topology_config.NodeConfig{{NodeId: "a"}, {NodeId: "b"}},
+	}
+	result, err = m.ApplyConfiguration(ctx, topo, failing)
+	if err == nil {
+		t.Fatalf("expected the commit on b to fail")
+	}
+	if result.Version != 2 {
+		t.Fatalf("expected the failed transaction recorded as version 2, got %d", result.Version)
+	}
+
+	records, err := storewrapper.ListConfigurationHistory(ctx, store)
+	if err != nil {
+		t.Fatalf("ListConfigurationHistory failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if r := records[0]; r.GetResult() != config_history.ApplyResult_APPLY_RESULT_SUCCEEDED ||
+		r.GetActor() != "operator" || !proto.Equal(r.GetConfiguration(), applied) {
+		t.Fatalf("unexpected first record %v", r)
+	}
+	if r := records[1]; r.GetResult() != config_history.ApplyResult_APPLY_RESULT_ROLLED_BACK || r.GetMessage() == "" {
+		t.Fatalf("expected the second record rolled back, got %v", r)
+	}
+
+	last, err := storewrapper.GetLastConfiguration(ctx, store)
+	if err != nil || last.GetVersion() != 1 {
+		t.Fatalf("expected version 1 as the latest configuration, got %v, %v", last, err)
+	}
+	if _, err := storewrapper.GetLastNodeConfiguration(ctx, store, "b"); !errors.Is(err, storewrapper.ErrNoConfiguration) {
+		t.Fatalf("expected no configuration for b, got %v", err)
+	}
+}
+
+func TestRestoreTransaction_RollbackAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	store := storewrapper.NewMemoryStore()
+
+	topo := &topology.Topology{}
+	cfg := &topology_config.TopologyConfig{ConfigId: "cfg-1"}
+	for _, name := range []string{"a", "b"} {
+		node := &topology.Node{
+			Name:           name,
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		}
+		topo.Nodes = append(topo.Nodes, node)
+		cfg.NodeConfigs = append(cfg.NodeConfigs, &topology_config.NodeConfig{NodeId: name})
+
+		raw, err := proto.Marshal(node)
+		if err != nil {
+			t.Fatalf("marshal node: %v", err)
+		}
+		if err := store.Put(ctx, "bridges/"+name, raw); err != nil {
+			t.Fatalf("store node: %v", err)
+		}
+	}
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(&fakeBackend{})
+
+	if _, err := m.ApplyConfiguration(ctx, topo, cfg); err != nil {
+		t.Fatalf("ApplyConfiguration failed: %v", err)
+	}
+
+	// A new engine, as after a restart of the service.
+	backend := &fakeBackend{}
+	restarted := NewMappingEngine(nil, WithStore(store))
+	restarted.RegisterBackend(backend)
+
+	if err := restarted.RestoreTransaction(ctx); err != nil {
+		t.Fatalf("RestoreTransaction failed: %v", err)
+	}
+	if id := restarted.GetLastTransactionId(); id == nil || *id != "cfg-1" {
+		t.Fatalf("expected cfg-1 restored as the last transaction, got %v", id)
+	}
+
+	if _, err := restarted.Rollback(ctx); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if len(backend.rollbacks) != 2 || backend.rollbacks[0] != "b" || backend.rollbacks[1] != "a" {
+		t.Fatalf("expected b and a rolled back, got %v", backend.rollbacks)
+	}
+
+	state, err := storewrapper.GetLastTransaction(ctx, store)
+	if err != nil {
+		t.Fatalf("GetLastTransaction failed: %v", err)
+	}
+	for _, op := range state.GetOperations() {
+		if op.GetCommitted() || !op.GetRolledBack() {
+			t.Fatalf("expected %s persisted as rolled back, got %v", op.GetNodeId(), op)
+		}
+	}
+
+	again := NewMappingEngine(nil, WithStore(store))
+	again.RegisterBackend(&fakeBackend{})
+
+	if err := again.RestoreTransaction(ctx); err != nil {
+		t.Fatalf("RestoreTransaction failed: %v", err)
+	}
+	if again.GetLastTransactionId() != nil {
+		t.Fatalf("a rolled back transaction must not be restored")
+	}
+}
+
+// snapshotBackend keeps one string snapshot per node: PrepareSnapshot stages
+// next, RestoreSnapshot the snapshot given.
+type snapshotBackend struct {
+	fakeBackend
+	next       string
+	current    map[string]string
+	lastStable map[string]string
+	working    map[string]string
+}
+
+func newSnapshotBackend() *snapshotBackend {
+	return &snapshotBackend{
+		current:    make(map[string]string),
+		lastStable: make(map[string]string),
+		working:    make(map[string]string),
+	}
+}
+
+func (b *snapshotBackend) stage(node, snapshot string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.current[node]; !ok {
+		b.current[node] = "baseline"
+	}
+	b.working[node] = snapshot
+}
+
+func (b *snapshotBackend) PrepareSnapshot(ctx context.Context, _ *topology_config.NodeConfig, node *topology.Node) error {
+	b.stage(node.Name, b.next)
+	return nil
+}
+
+func (b *snapshotBackend) RestoreSnapshot(ctx context.Context, node *topology.Node, snapshot []byte) error {
+	b.stage(node.Name, string(snapshot))
+	return nil
+}
+
+func (b *snapshotBackend) Commit(ctx context.Context, node *topology.Node) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastStable[node.Name] = b.current[node.Name]
+	b.current[node.Name] = b.working[node.Name]
+	return nil
+}
+
+func (b *snapshotBackend) Rollback(ctx context.Context, node *topology.Node) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current[node.Name] = b.lastStable[node.Name]
+	return nil
+}
+
+func (b *snapshotBackend) Snapshots(node string) ([]byte, []byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, ok := b.current[node]
+	return []byte(current), []byte(b.lastStable[node]), ok
+}
+
+func TestRollbackTo_RestoresNodesChangedSinceVersion(t *testing.T) {
+	ctx := context.Background()
+	store := storewrapper.NewMemoryStore()
+	backend := newSnapshotBackend()
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{}
+	for _, name := range []string{"a", "b", "c"} {
+		topo.Nodes = append(topo.Nodes, &topology.Node{
+			Name:           name,
+			ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+		})
+	}
+
+	apply := func(configId string, nodes ...string) {
+		cfg := &topology_config.TopologyConfig{ConfigId: configId}
+		for _, name := range nodes {
+			cfg.NodeConfigs = append(cfg.NodeConfigs, &topology_config.NodeConfig{NodeId: name})
+		}
+
+		backend.next = configId
+		if _, err := m.ApplyConfiguration(ctx, topo, cfg); err != nil {
+			t.Fatalf("ApplyConfiguration %s failed: %v", configId, err)
+		}
+	}
+
+	apply("cfg-1", "a", "c") // version 1
+	apply("cfg-2", "a", "b") // version 2
+	apply("cfg-3", "b")      // version 3
+
+	result, err := m.RollbackTo(ctx, topo, 1)
+	if err != nil {
+		t.Fatalf("RollbackTo failed: %v", err)
+	}
+	if result.Version != 4 {
+		t.Fatalf("expected the rollback recorded as version 4, got %d", result.Version)
+	}
+
+	// a is back to version 1, b was first configured after it and c is unchanged.
+	want := map[string]string{"a": "cfg-1", "b": "baseline", "c": "cfg-1"}
+	for node, snapshot := range want {
+		if backend.current[node] != snapshot {
+			t.Fatalf("expected %s to run %s, got %s", node, snapshot, backend.current[node])
+		}
+	}
+
+	var restored []string
+	for _, res := range result.Nodes {
+		if res.Phase == PhaseCommit {
+			restored = append(restored, res.Node)
+		}
+	}
+	if len(restored) != 2 || restored[0] != "a" || restored[1] != "b" {
+		t.Fatalf("expected only a and b restored, got %v", restored)
+	}
+
+	last, err := storewrapper.GetLastConfiguration(ctx, store)
+	if err != nil || last.GetVersion() != 4 || last.GetConfiguration().GetConfigId() != "cfg-1" {
+		t.Fatalf("expected version 4 applying cfg-1 as the latest configuration, got %v, %v", last, err)
+	}
+
+	if _, err := m.RollbackTo(ctx, topo, 7); err == nil {
+		t.Fatalf("expected an unknown version to be rejected")
+	}
+}
+
+func TestRollbackTo_StepsSkipRollbacks(t *testing.T) {
+	ctx := context.Background()
+	store := storewrapper.NewMemoryStore()
+	backend := newSnapshotBackend()
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{Nodes: []*topology.Node{{
+		Name:           "a",
+		ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+	}}}
+
+	for _, configId := range []string{"cfg-1", "cfg-2", "cfg-3"} {
+		backend.next = configId
+		cfg := &topology_config.TopologyConfig{
+			ConfigId:    configId,
+			NodeConfigs: []*topology_config.NodeConfig{{NodeId: "a"}},
+		}
+		if _, err := m.ApplyConfiguration(ctx, topo, cfg); err != nil {
+			t.Fatalf("ApplyConfiguration %s failed: %v", configId, err)
+		}
+	}
+
+	// Going back one step twice walks back two configurations, the
+	// rollback recorded in between is not a step.
+	for _, want := range []string{"cfg-2", "cfg-1"} {
+		version, err := storewrapper.PreviousConfigurationVersion(ctx, store, 1)
+		if err != nil {
+			t.Fatalf("PreviousConfigurationVersion failed: %v", err)
+		}
+
+		if _, err := m.RollbackTo(ctx, topo, version); err != nil {
+			t.Fatalf("RollbackTo %d failed: %v", version, err)
+		}
+		if backend.current["a"] != want {
+			t.Fatalf("expected a to run %s, got %s", want, backend.current["a"])
+		}
+	}
+
+	last, err := storewrapper.GetLastConfiguration(ctx, store)
+	if err != nil || last.GetRolledBackTo() != 1 {
+		t.Fatalf("expected the last record to be the rollback to version 1, got %v, %v", last, err)
+	}
+
+	if _, err := storewrapper.PreviousConfigurationVersion(ctx, store, 1); err == nil {
+		t.Fatalf("expected no configuration before version 1")
+	}
+}
+
+func TestRollback_RecordsUndoInHistory(t *testing.T) {
+	ctx := context.Background()
+	store := storewrapper.NewMemoryStore()
+	backend := newSnapshotBackend()
+
+	m := NewMappingEngine(nil, WithStore(store))
+	m.RegisterBackend(backend)
+
+	topo := &topology.Topology{Nodes: []*topology.Node{{
+		Name:           "a",
+		ManagementInfo: &topology.ManagementInfo{Protocol: topology.ManagementProtocol_NETCONF},
+	}}}
+
+	for _, configId := range []string{"cfg-1", "cfg-2", "cfg-3"} {
+		backend.next = configId
+		cfg := &topology_config.TopologyConfig{
+			ConfigId:    configId,
+			NodeConfigs: []*topology_config.NodeConfig{{NodeId: "a"}},
+		}
+		if _, err := m.ApplyConfiguration(ctx, topo, cfg); err != nil {
+			t.Fatalf("ApplyConfiguration %s failed: %v", configId, err)
+		}
+	}
+
+	result, err := m.Rollback(ctx)
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if result.Version != 4 || backend.current["a"] != "cfg-2" {
+		t.Fatalf("expected the undo recorded as version 4 with a running cfg-2, got %d and %s", result.Version, backend.current["a"])
+	}
+
+	last, err := storewrapper.GetLastNodeConfiguration(ctx, store, "a")
+	if err != nil || last.GetVersion() != 4 || last.GetUndone() != 3 || last.GetRolledBackTo() != 2 ||
+		last.GetConfiguration().GetConfigId() != "cfg-2" {
+		t.Fatalf("expected version 4 undoing 3 back to cfg-2 as the latest of a, got %v, %v", last, err)
+	}
+
+	// One step back from cfg-2, the configuration the network runs.
+	version, err := storewrapper.PreviousConfigurationVersion(ctx, store, 1)
+	if err != nil || version != 1 {
+		t.Fatalf("expected version 1 one step back, got %d, %v", version, err)
+	}
+
+	// a changed since version 3: rolling back to it restores it.
+	if _, err := m.RollbackTo(ctx, topo, 3); err != nil {
+		t.Fatalf("RollbackTo failed: %v", err)
+	}
+	if backend.current["a"] != "cfg-3" {
+		t.Fatalf("expected a to run cfg-3, got %s", backend.current["a"])
+	}
+}
@@ -0,0 +1,388 @@
+package managementSessions
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"OpenCNC_config_service/common/observability"
+	observabilityv1 "OpenCNC_config_service/common/structures/logging"
+	"OpenCNC_config_service/common/structures/topology"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"golang.org/x/crypto/ssh"
+)
+
+// NETCONF Call Home ports (RFC 8071).
+const (
+	DefaultCallHomeSSHPort = 4334
+	DefaultCallHomeTLSPort = 4335
+)
+
+// ErrUnknownCaller is returned when a calling device matches no node.
+var ErrUnknownCaller = errors.New("calling device matches no node")
+
+// CallHomeConfig configures a CallHomeListener.
+type CallHomeConfig struct {
+	Pool        *SessionPool
+	Nodes       func(ctx context.Context) ([]*topology.Node, error) // candidate nodes
+	Credentials CredentialProvider
+
+	// SSH: devices are identified by their pinned host key. The SSH user is
+	// sent before the device is known, so all SSH callers share SSHUsername;
+	// the password or keys are those of the matched node.
+	HostKeys    HostKeyStore
+	SSHUsername string
+
+	// TLS: devices are identified by a certificate signed by RootCAs and
+	// valid for the node's tls_server_name, or its ip_address.
+	RootCAs *x509.CertPool
+
+	HandshakeTimeout time.Duration // default 30s
+	Logger           observability.Logger
+	Auditor          Auditor // optional
+}
+
+// CallHomeListener accepts NETCONF Call Home connections, acts as NETCONF
+// client over them, matches each calling device to a topology node and
+// registers the session in the pool under the node name. Backends acquiring
+// sessions from that pool then use it like a dialed one.
+type CallHomeListener struct {
+	config CallHomeConfig
+	logger observability.Logger
+
+	mu        sync.Mutex
+	listeners []net.Listener
+	closed    bool
+}
+
+func NewCallHomeListener(config CallHomeConfig) *CallHomeListener {
+	if config.HandshakeTimeout <= 0 {
+		config.HandshakeTimeout = 30 * time.Second
+	}
+	return &CallHomeListener{
+		config: config,
+		logger: observability.NormalizeLogger(config.Logger),
+	}
+}
+
+// ServeSSH accepts SSH Call Home connections on ln until Close.
+func (l *CallHomeListener) ServeSSH(ln net.Listener) error {
+	return l.serve(ln, l.acceptSSH)
+}
+
+// ServeTLS accepts TLS Call Home connections on ln until Close.
+func (l *CallHomeListener) ServeTLS(ln net.Listener) error {
+	return l.serve(ln, l.acceptTLS)
+}
+
+// Close stops accepting connections. Registered sessions stay in the pool.
+func (l *CallHomeListener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closed = true
+
+	var errs []error
+	for _, ln := range l.listeners {
+		errs = append(errs, ln.Close())
+	}
+	l.listeners = nil
+
+	return errors.Join(errs...)
+}
+
+func (l *CallHomeListener) serve(ln net.Listener, accept func(context.Context, net.Conn) (string, *netconf.Session, error)) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		ln.Close()
+		return net.ErrClosed
+	}
+	l.listeners = append(l.listeners, ln)
+	l.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			l.mu.Lock()
+			closed := l.closed
+			l.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+
+		go l.handle(conn, accept)
+	}
+}
+
+func (l *CallHomeListener) handle(conn net.Conn, accept func(context.Context, net.Conn) (string, *netconf.Session, error)) {
+	remote := conn.RemoteAddr().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.config.HandshakeTimeout)
+	defer cancel()
+
+	node, session, err := accept(ctx, conn)
+	if err != nil {
+		conn.Close()
+		l.logger.Printf("Rejected NETCONF call home from %s: %v", remote, err)
+		if errors.Is(err, ErrUnknownCaller) {
+			l.audit(remote, observabilityv1.AuditResult_AUDIT_RESULT_DENIED, err.Error())
+		}
+		return
+	}
+
+	if err := l.config.Pool.Register(node, session); err != nil {
+		CloseSession(session)
+		l.logger.Printf("Dropped NETCONF call home of node %s from %s: %v", node, remote, err)
+		return
+	}
+
+	l.logger.Printf("Node %s called home from %s (session %d)", node, remote, session.SessionID)
+	l.audit(node, observabilityv1.AuditResult_AUDIT_RESULT_ALLOWED, "called home from "+remote)
+}
+
+// acceptSSH runs the SSH client handshake over conn, identifying the device
+// by its host key before authenticating with the matched node's credentials.
+func (l *CallHomeListener) acceptSSH(ctx context.Context, conn net.Conn) (string, *netconf.Session, error) {
+	if l.config.HostKeys == nil {
+		return "", nil, fmt.Errorf("no host key store configured")
+	}
+
+	var (
+		node  *topology.Node
+		creds Credentials
+	)
+
+	identify := func(address string, _ net.Addr, key ssh.PublicKey) error {
+		fingerprint := ssh.FingerprintSHA256(key)
+
+		if node != nil { // re-keying
+			pinned, err := l.config.HostKeys.HostKeys(node.GetName())
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(pinned, fingerprint) {
+				return &HostKeyMismatchError{Node: node.GetName(), Address: address, Fingerprint: fingerprint, Pinned: pinned}
+			}
+			return nil
+		}
+
+		matched, err := l.match(ctx, func(n *topology.Node) (bool, error) {
+			pinned, err := l.config.HostKeys.HostKeys(n.GetName())
+			return slices.Contains(pinned, fingerprint), err
+		})
+		if err != nil {
+			return fmt.Errorf("host key %s: %w", fingerprint, err)
+		}
+
+		resolved, err := l.credentials(ctx, matched)
+		if err != nil {
+			return err
+		}
+
+		node, creds = matched, resolved
+		return nil
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		User:            l.config.SSHUsername,
+		HostKeyCallback: identify,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeysCallback(func() ([]ssh.Signer, error) { return creds.sshSigners() }),
+			ssh.PasswordCallback(func() (string, error) { return creds.Password, nil }),
+			ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+				return answerAll(creds.Password)(user, instruction, questions, echos)
+			}),
+		},
+		Timeout: l.config.HandshakeTimeout,
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, conn.RemoteAddr().String(), sshConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	transport, err := netconf.NoDialSSH(ssh.NewClient(sshConn, channels, requests))
+	if err != nil {
+		sshConn.Close()
+		return "", nil, fmt.Errorf("opening netconf subsystem: %w", err)
+	}
+
+	session, err := netconf.NewSession(transport)
+	if err != nil {
+		transport.Close()
+		return "", nil, fmt.Errorf("reading hello: %w", err)
+	}
+
+	if err := startSession(session); err != nil {
+		CloseSession(session)
+		return "", nil, fmt.Errorf("failed to send hello: %w", err)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return node.GetName(), session, nil
+}
+
+// acceptTLS runs the TLS client handshake over conn, identifying the device
+// by its certificate before presenting the matched node's client certificate.
+func (l *CallHomeListener) acceptTLS(ctx context.Context, conn net.Conn) (string, *netconf.Session, error) {
+	var (
+		node  *topology.Node
+		creds Credentials
+	)
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The expected server name is only known once the certificate
+		// identified the device, see VerifyPeerCertificate.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			leaf, err := l.verifyChain(rawCerts)
+			if err != nil {
+				return err
+			}
+
+			matched, err := l.match(ctx, func(n *topology.Node) (bool, error) {
+				name := n.GetManagementInfo().GetTlsServerName()
+				if name == "" {
+					name = n.GetManagementInfo().GetIpAddress()
+				}
+				return name != "" && leaf.VerifyHostname(name) == nil, nil
+			})
+			if err != nil {
+				return fmt.Errorf("certificate %q: %w", leaf.Subject.String(), err)
+			}
+
+			resolved, err := l.credentials(ctx, matched)
+			if err != nil {
+				return err
+			}
+
+			node, creds = matched, resolved
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := creds.TLSCertificate()
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		},
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return "", nil, err
+	}
+
+	session, err := startTLSSession(ctx, tlsConn)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return node.GetName(), session, nil
+}
+
+func (l *CallHomeListener) verifyChain(rawCerts [][]byte) (*x509.Certificate, error) {
+	if len(rawCerts) == 0 {
+		return nil, fmt.Errorf("no server certificate")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing server certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         l.config.RootCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certs[0], nil
+}
+
+// match returns the single candidate node accepted by matches.
+func (l *CallHomeListener) match(ctx context.Context, matches func(*topology.Node) (bool, error)) (*topology.Node, error) {
+	nodes, err := l.config.Nodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading nodes: %w", err)
+	}
+
+	var found []*topology.Node
+	for _, node := range nodes {
+		if node.GetManagementInfo() == nil {
+			continue
+		}
+		ok, err := matches(node)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, node)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, ErrUnknownCaller
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("calling device matches nodes %s and %s", found[0].GetName(), found[1].GetName())
+	}
+}
+
+func (l *CallHomeListener) credentials(ctx context.Context, node *topology.Node) (Credentials, error) {
+	if l.config.Credentials == nil {
+		return Credentials{}, nil
+	}
+
+	creds, err := l.config.Credentials.Credentials(ctx, node)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("credentials for node %s: %w", node.GetName(), err)
+	}
+	return creds, nil
+}
+
+func (l *CallHomeListener) audit(target string, result observabilityv1.AuditResult, reason string) {
+	if l.config.Auditor == nil {
+		return
+	}
+
+	severity := observabilityv1.Severity_SEVERITY_INFO
+	if result == observabilityv1.AuditResult_AUDIT_RESULT_DENIED {
+		severity = observabilityv1.Severity_SEVERITY_WARN
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_ = l.config.Auditor.Audit(ctx, severity, "config-service", "netconf-call-home", "node", target, result, reason)
+}
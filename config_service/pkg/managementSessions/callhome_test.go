@@ -0,0 +1,277 @@
+package managementSessions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	observabilityv1 "OpenCNC_config_service/common/structures/logging"
+	"OpenCNC_config_service/common/structures/topology"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"golang.org/x/crypto/ssh"
+)
+
+// callHomeSSH plays a device calling home over SSH: it dials addr and serves
+// the netconf subsystem with base:1.0 framing, answering every RPC with <ok/>.
+func callHomeSSH(t *testing.T, addr string, hostKey ssh.Signer, password string) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+			if string(p) != password {
+				return nil, errors.New("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	go func() {
+		defer conn.Close()
+
+		_, channels, requests, err := ssh.NewServerConn(conn, config)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(requests)
+
+		for newChannel := range channels {
+			channel, reqs, err := newChannel.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				for req := range reqs {
+					req.Reply(req.Type == "subsystem", nil)
+				}
+			}()
+			go serveEOMNetconf(channel)
+		}
+	}()
+}
+
+func serveEOMNetconf(channel ssh.Channel) {
+	defer channel.Close()
+
+	fmt.Fprint(channel, `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>`+
+		`<capability>urn:ietf:params:netconf:base:1.0</capability></capabilities>`+
+		`<session-id>11</session-id></hello>]]>]]>`)
+
+	scanner := bufio.NewScanner(channel)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, []byte("]]>]]>")); i >= 0 {
+			return i + 6, data[:i], nil
+		}
+		if atEOF {
+			return 0, nil, errors.New("unterminated message")
+		}
+		return 0, nil, nil
+	})
+
+	for scanner.Scan() {
+		if m := messageID.FindSubmatch(scanner.Bytes()); m != nil {
+			fmt.Fprintf(channel, `<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" message-id="%s"><ok/></rpc-reply>]]>]]>`, m[1])
+		}
+	}
+}
+
+func testSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+type staticCredentials map[string]Credentials
+
+func (s staticCredentials) Credentials(_ context.Context, node *topology.Node) (Credentials, error) {
+	return s[node.GetName()], nil
+}
+
+func staticNodes(nodes ...*topology.Node) func(context.Context) ([]*topology.Node, error) {
+	return func(context.Context) ([]*topology.Node, error) { return nodes, nil }
+}
+
+// awaitCallHome waits until the pool holds a session of node.
+func awaitCallHome(t *testing.T, pool *SessionPool, node string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Health(node).Idle == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s did not call home", node)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func serveCallHome(t *testing.T, serve func(net.Listener) error) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go serve(ln)
+
+	return ln.Addr().String()
+}
+
+func TestCallHome_SSHRegistersMatchedNode(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{})
+	defer pool.Close()
+
+	hostKey := testSigner(t)
+	hostKeys := NewMemoryHostKeyStore()
+	_ = hostKeys.PinHostKey("bridge-2", ssh.FingerprintSHA256(hostKey.PublicKey()))
+
+	auditor := &recordingAuditor{}
+
+	listener := NewCallHomeListener(CallHomeConfig{
+		Pool: pool,
+		Nodes: staticNodes(
+			&topology.Node{Name: "bridge-1", ManagementInfo: &topology.ManagementInfo{}},
+			&topology.Node{Name: "bridge-2", ManagementInfo: &topology.ManagementInfo{}},
+		),
+		Credentials: staticCredentials{"bridge-2": {Password: "bridge-2-password"}},
+		HostKeys:    hostKeys,
+		SSHUsername: "netconf",
+		Auditor:     auditor,
+	})
+	defer listener.Close()
+
+	callHomeSSH(t, serveCallHome(t, listener.ServeSSH), hostKey, "bridge-2-password")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	awaitCallHome(t, pool, "bridge-2")
+
+	dial := func(context.Context) (*netconf.Session, error) {
+		return nil, errors.New("a call home device must not be dialed")
+	}
+
+	lease, err := pool.Acquire(ctx, "bridge-2", dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer lease.Release()
+
+	if lease.SessionID != 11 {
+		t.Fatalf("expected session-id 11, got %d", lease.SessionID)
+	}
+	if err := Lock(ctx, lease.Session, "running"); err != nil {
+		t.Fatalf("lock over call home session: %v", err)
+	}
+}
+
+func TestCallHome_SSHRejectsUnknownHostKey(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{})
+	defer pool.Close()
+
+	auditor := &recordingAuditor{}
+
+	listener := NewCallHomeListener(CallHomeConfig{
+		Pool:        pool,
+		Nodes:       staticNodes(&topology.Node{Name: "bridge-1", ManagementInfo: &topology.ManagementInfo{}}),
+		HostKeys:    NewMemoryHostKeyStore(),
+		SSHUsername: "netconf",
+		Auditor:     auditor,
+	})
+	defer listener.Close()
+
+	callHomeSSH(t, serveCallHome(t, listener.ServeSSH), testSigner(t), "")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(auditor.snapshot()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("unknown caller was not audited")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := auditor.snapshot(); got[0] != observabilityv1.AuditResult_AUDIT_RESULT_DENIED {
+		t.Fatalf("expected a denied audit, got %v", got)
+	}
+	if pool.Health("bridge-1").Open != 0 {
+		t.Fatalf("unknown caller was registered")
+	}
+}
+
+func TestCallHome_TLSMatchesCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+
+	pool := NewSessionPool(nil, PoolConfig{})
+	defer pool.Close()
+
+	clientCert, clientKey := pki.issue(t, "controller", x509.ExtKeyUsageClientAuth)
+
+	listener := NewCallHomeListener(CallHomeConfig{
+		Pool: pool,
+		Nodes: staticNodes(
+			&topology.Node{Name: "bridge-1", ManagementInfo: &topology.ManagementInfo{TlsServerName: "bridge-1.test"}},
+			&topology.Node{Name: "bridge-2", ManagementInfo: &topology.ManagementInfo{TlsServerName: "bridge-2.test"}},
+		),
+		Credentials: staticCredentials{"bridge-1": {Certificates: [][]byte{clientCert}, PrivateKeys: [][]byte{clientKey}}},
+		RootCAs:     pki.caPool,
+	})
+	defer listener.Close()
+
+	addr := serveCallHome(t, listener.ServeTLS)
+
+	// The device dials, then acts as TLS server.
+	certPEM, keyPEM := pki.issue(t, "bridge-1.test", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go serveTLSConn(tls.Server(conn, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pki.caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	awaitCallHome(t, pool, "bridge-1")
+
+	lease, err := pool.Acquire(ctx, "bridge-1", func(context.Context) (*netconf.Session, error) {
+		return nil, errors.New("not dialable")
+	})
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer lease.Release()
+
+	if err := Lock(ctx, lease.Session, "running"); err != nil {
+		t.Fatalf("lock over call home session: %v", err)
+	}
+}
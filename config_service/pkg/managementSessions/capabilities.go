@@ -0,0 +1,272 @@
+package managementSessions
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"github.com/openshift-telco/go-netconf-client/netconf/message"
+)
+
+// Capabilities advertised by servers that list their modules elsewhere than
+// in the <hello>.
+const (
+	CapabilityYangLibrary   = "urn:ietf:params:netconf:capability:yang-library:1.0"
+	CapabilityYangLibrary11 = "urn:ietf:params:netconf:capability:yang-library:1.1"
+	NamespaceMonitoring     = "urn:ietf:params:xml:ns:yang:ietf-netconf-monitoring"
+)
+
+// Module is a YANG module implemented by a device.
+type Module struct {
+	Name       string
+	Namespace  string
+	Revision   string   // "" if the device did not tell
+	Features   []string // enabled if-feature names
+	Deviations []string // modules deviating this one
+
+	// Listed is set for modules only found in the schema list, which tells
+	// neither features nor deviations.
+	Listed bool
+}
+
+// Capabilities is what a device runs, as advertised in its <hello> and,
+// optionally, listed by ietf-netconf-monitoring.
+type Capabilities struct {
+	SessionID int                // session the capabilities were read on
+	Modules   map[string]*Module // by module name
+	URIs      []string           // protocol capabilities, parameters stripped
+
+	// Partial is set when the device lists its modules in the YANG library
+	// (RFC 8526) rather than in the <hello>, so Modules may miss some.
+	Partial bool
+}
+
+// ParseCapabilities reads the session's <hello> capabilities. Module
+// capabilities (RFC 6020 section 5.6.4) carry module, revision, features and
+// deviations parameters; every other URI is a protocol capability.
+func ParseCapabilities(session *netconf.Session) *Capabilities {
+	caps := &Capabilities{Modules: make(map[string]*Module)}
+	if session == nil {
+		return caps
+	}
+
+	caps.SessionID = session.SessionID
+
+	for _, advertised := range session.Capabilities {
+		uri, rawQuery, _ := strings.Cut(strings.TrimSpace(advertised), "?")
+
+		// Parameters are separated by "&", escaped as "&amp;" by some servers.
+		query, _ := url.ParseQuery(strings.ReplaceAll(rawQuery, "&amp;", "&"))
+
+		name := query.Get("module")
+		if name == "" {
+			caps.URIs = append(caps.URIs, uri)
+			if uri == CapabilityYangLibrary || uri == CapabilityYangLibrary11 {
+				caps.Partial = true
+			}
+			continue
+		}
+
+		caps.Modules[name] = &Module{
+			Name:       name,
+			Namespace:  uri,
+			Revision:   query.Get("revision"),
+			Features:   splitList(query.Get("features")),
+			Deviations: splitList(query.Get("deviations")),
+		}
+	}
+
+	return caps
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// Module returns the implemented module, or nil.
+func (c *Capabilities) Module(name string) *Module {
+	if c == nil {
+		return nil
+	}
+	return c.Modules[name]
+}
+
+// HasModule reports whether the device implements the module, at the revision
+// if one is given.
+func (c *Capabilities) HasModule(name, revision string) bool {
+	m := c.Module(name)
+	return m != nil && (revision == "" || m.Revision == revision)
+}
+
+// HasFeature reports whether the device implements the module with the feature enabled.
+func (c *Capabilities) HasFeature(module, feature string) bool {
+	m := c.Module(module)
+	return m != nil && slices.Contains(m.Features, feature)
+}
+
+// Deviated reports whether some module deviates the module.
+func (c *Capabilities) Deviated(module string) bool {
+	m := c.Module(module)
+	return m != nil && len(m.Deviations) > 0
+}
+
+// DeviceModel describes the implemented modules the way the device model
+// registry does, so plugins can check them with SupportedByDevice.
+func (c *Capabilities) DeviceModel(name string) *devicemodelregistry.DeviceModel {
+	model := &devicemodelregistry.DeviceModel{Name: name}
+
+	for _, m := range c.sortedModules() {
+		model.YangFiles = append(model.YangFiles, &devicemodelregistry.YangFile{
+			Name:     m.Name + ".yang",
+			Revision: m.Revision,
+		})
+	}
+
+	return model
+}
+
+// Mismatches lists where the registered device model disagrees with what the
+// device runs: declared modules it does not implement, or at another revision.
+func (c *Capabilities) Mismatches(model *devicemodelregistry.DeviceModel) []string {
+	var mismatches []string
+
+	for _, yf := range model.GetYangFiles() {
+		name := strings.TrimSuffix(yf.GetName(), ".yang")
+
+		m := c.Module(name)
+		switch {
+		case m == nil && !c.Partial:
+			mismatches = append(mismatches, fmt.Sprintf("%s is not implemented", name))
+		case m == nil:
+			// Possibly listed in the YANG library only.
+		case yf.GetRevision() != "" && m.Revision != yf.GetRevision():
+			mismatches = append(mismatches, fmt.Sprintf(
+				"%s runs revision %q, registered %q", name, m.Revision, yf.GetRevision()))
+		}
+	}
+
+	return mismatches
+}
+
+func (c *Capabilities) sortedModules() []*Module {
+	modules := make([]*Module, 0, len(c.Modules))
+	for _, m := range c.Modules {
+		modules = append(modules, m)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Name < modules[j].Name })
+	return modules
+}
+
+// Schema is an entry of the ietf-netconf-monitoring schema list.
+type Schema struct {
+	Identifier string `xml:"identifier"`
+	Version    string `xml:"version"`
+	Format     string `xml:"format"`
+	Namespace  string `xml:"namespace"`
+}
+
+// ListSchemas reads the schemas the device offers through <get-schema>
+// (RFC 6022). It lists YANG 1.1 modules missing from the <hello>.
+func ListSchemas(ctx context.Context, session *netconf.Session) ([]Schema, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := `<netconf-state xmlns="` + NamespaceMonitoring + `"><schemas/></netconf-state>`
+
+	reply, err := syncRPC(session, message.NewGet(message.FilterTypeSubtree, filter), rpcTimeout(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("get RPC failed: %w", err)
+	}
+
+	if reply == nil || reply.RawReply == "" {
+		return nil, fmt.Errorf("empty reply from get")
+	}
+
+	if len(reply.Errors) > 0 {
+		return nil, fmt.Errorf("get schemas failed: %w", &reply.Errors[0])
+	}
+
+	var rpcReply struct {
+		Schemas []Schema `xml:"data>netconf-state>schemas>schema"`
+	}
+
+	if err := xml.Unmarshal([]byte(reply.RawReply), &rpcReply); err != nil {
+		return nil, fmt.Errorf("failed parsing schema list: %w", err)
+	}
+
+	return rpcReply.Schemas, nil
+}
+
+// GetSchema retrieves a module's source with <get-schema>; an empty version
+// asks for the latest one.
+func GetSchema(ctx context.Context, session *netconf.Session, identifier, version string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var rpc strings.Builder
+	fmt.Fprintf(&rpc, `<get-schema xmlns="%s"><identifier>`, NamespaceMonitoring)
+	xml.EscapeText(&rpc, []byte(identifier))
+	rpc.WriteString(`</identifier>`)
+	if version != "" {
+		rpc.WriteString(`<version>`)
+		xml.EscapeText(&rpc, []byte(version))
+		rpc.WriteString(`</version>`)
+	}
+	rpc.WriteString(`<format>yang</format></get-schema>`)
+
+	reply, err := syncRPC(session, message.NewRPC(rpc.String()), rpcTimeout(ctx))
+	if err != nil {
+		return "", fmt.Errorf("get-schema RPC failed: %w", err)
+	}
+
+	if reply == nil || reply.RawReply == "" {
+		return "", fmt.Errorf("empty reply from get-schema")
+	}
+
+	if len(reply.Errors) > 0 {
+		return "", fmt.Errorf("get-schema %s failed: %w", identifier, &reply.Errors[0])
+	}
+
+	var rpcReply struct {
+		Data string `xml:"data"`
+	}
+
+	if err := xml.Unmarshal([]byte(reply.RawReply), &rpcReply); err != nil {
+		return "", fmt.Errorf("failed parsing get-schema reply: %w", err)
+	}
+
+	return rpcReply.Data, nil
+}
+
+// AddSchemas completes the modules with the YANG schemas the device lists.
+// Modules already advertised in the <hello> keep their parameters.
+func (c *Capabilities) AddSchemas(schemas []Schema) {
+	for _, s := range schemas {
+		if s.Format != "" && !strings.HasSuffix(s.Format, "yang") {
+			continue // e.g. yin
+		}
+		if _, ok := c.Modules[s.Identifier]; ok {
+			continue
+		}
+		c.Modules[s.Identifier] = &Module{
+			Name:      s.Identifier,
+			Namespace: s.Namespace,
+			Revision:  s.Version,
+			Listed:    true,
+		}
+	}
+
+	c.Partial = false
+}
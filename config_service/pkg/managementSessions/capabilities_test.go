@@ -0,0 +1,113 @@
+package managementSessions
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+func TestParseCapabilities_ModulesFeaturesDeviations(t *testing.T) {
+	session := &netconf.Session{
+		SessionID: 3,
+		Capabilities: []string{
+			"urn:ietf:params:netconf:base:1.1",
+			"urn:ietf:params:netconf:capability:candidate:1.0",
+			"urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched?module=ieee802-dot1q-sched&amp;revision=2021-04-09&amp;features=scheduled-traffic",
+			"urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2018-02-20&deviations=vendor-interfaces-deviations",
+		},
+	}
+
+	caps := ParseCapabilities(session)
+
+	if caps.SessionID != 3 || caps.Partial {
+		t.Fatalf("unexpected capabilities %+v", caps)
+	}
+	if !slices.Equal(caps.URIs, []string{"urn:ietf:params:netconf:base:1.1", CapabilityCandidate}) {
+		t.Fatalf("unexpected protocol capabilities %v", caps.URIs)
+	}
+	if !caps.HasModule("ieee802-dot1q-sched", "2021-04-09") || caps.HasModule("ieee802-dot1q-sched", "2018-03-15") {
+		t.Fatalf("sched revision not parsed: %+v", caps.Module("ieee802-dot1q-sched"))
+	}
+	if !caps.HasFeature("ieee802-dot1q-sched", "scheduled-traffic") || caps.HasFeature("ietf-interfaces", "scheduled-traffic") {
+		t.Fatalf("features not parsed")
+	}
+	if !caps.Deviated("ietf-interfaces") || caps.Deviated("ieee802-dot1q-sched") {
+		t.Fatalf("deviations not parsed")
+	}
+}
+
+func TestCapabilities_MismatchesWithRegisteredModel(t *testing.T) {
+	caps := ParseCapabilities(&netconf.Session{Capabilities: []string{
+		"urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched?module=ieee802-dot1q-sched&revision=2018-03-15",
+		"urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2018-02-20",
+	}})
+
+	registered := &devicemodelregistry.DeviceModel{
+		Name: "bridge",
+		YangFiles: []*devicemodelregistry.YangFile{
+			{Name: "ieee802-dot1q-sched.yang", Revision: "2021-04-09"},
+			{Name: "ietf-interfaces.yang", Revision: "2018-02-20"},
+			{Name: "ieee802-dot1q-bridge"},
+		},
+	}
+
+	got := caps.Mismatches(registered)
+	want := []string{
+		`ieee802-dot1q-sched runs revision "2018-03-15", registered "2021-04-09"`,
+		"ieee802-dot1q-bridge is not implemented",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	model := caps.DeviceModel("bridge")
+	if len(model.YangFiles) != 2 || model.YangFiles[0].Name != "ieee802-dot1q-sched.yang" || model.YangFiles[0].Revision != "2018-03-15" {
+		t.Fatalf("unexpected runtime model %v", model.YangFiles)
+	}
+}
+
+func TestListSchemas_CompletesYangLibraryModules(t *testing.T) {
+	transport := newFakeTransport()
+	transport.body = `<data><netconf-state xmlns="urn:ietf:params:xml:ns:yang:ietf-netconf-monitoring"><schemas>` +
+		`<schema><identifier>ieee802-dot1q-bridge</identifier><version>2022-01-19</version><format>yang</format>` +
+		`<namespace>urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge</namespace><location>NETCONF</location></schema>` +
+		`<schema><identifier>ieee802-dot1q-bridge</identifier><version>2022-01-19</version><format>yin</format>` +
+		`<namespace>urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge</namespace><location>NETCONF</location></schema>` +
+		`</schemas></netconf-state></data>`
+
+	session, err := NewSession(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CloseSession(session)
+
+	session.Capabilities = append(session.Capabilities, CapabilityYangLibrary+"?revision=2019-01-04&module-set-id=1")
+
+	caps := ParseCapabilities(session)
+	if !caps.Partial {
+		t.Fatalf("yang-library capability should mark the modules partial")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schemas, err := ListSchemas(ctx, session)
+	if err != nil {
+		t.Fatalf("list schemas: %v", err)
+	}
+	if len(schemas) != 2 {
+		t.Fatalf("expected 2 schemas, got %+v", schemas)
+	}
+
+	caps.AddSchemas(schemas)
+
+	bridge := caps.Module("ieee802-dot1q-bridge")
+	if caps.Partial || bridge == nil || bridge.Revision != "2022-01-19" || !bridge.Listed {
+		t.Fatalf("schemas not merged: %+v", bridge)
+	}
+}
@@ -0,0 +1,129 @@
+package managementSessions
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"github.com/openshift-telco/go-netconf-client/netconf/message"
+)
+
+// NETCONF capabilities (RFC 6241 section 8) relevant to how configuration is committed.
+const (
+	CapabilityCandidate         = "urn:ietf:params:netconf:capability:candidate:1.0"
+	CapabilityConfirmedCommit   = "urn:ietf:params:netconf:capability:confirmed-commit:1.0"
+	CapabilityConfirmedCommit11 = "urn:ietf:params:netconf:capability:confirmed-commit:1.1"
+	CapabilityValidate          = "urn:ietf:params:netconf:capability:validate:1.0"
+	CapabilityValidate11        = "urn:ietf:params:netconf:capability:validate:1.1"
+)
+
+// DefaultConfirmTimeout is the confirm-timeout defined by RFC 6241 when none is given.
+const DefaultConfirmTimeout = 600 * time.Second
+
+// HasCapability reports whether the server advertised the capability in its
+// <hello>. Capability parameters (anything after '?') are ignored.
+func HasCapability(session *netconf.Session, capability string) bool {
+	if session == nil {
+		return false
+	}
+
+	for _, advertised := range session.Capabilities {
+		uri, _, _ := strings.Cut(strings.TrimSpace(advertised), "?")
+		if uri == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsConfirmedCommit reports whether the server can take configuration
+// into <candidate> and commit it with <confirmed/>.
+func SupportsConfirmedCommit(session *netconf.Session) bool {
+	return HasCapability(session, CapabilityCandidate) &&
+		(HasCapability(session, CapabilityConfirmedCommit) || HasCapability(session, CapabilityConfirmedCommit11))
+}
+
+// EditCandidateContext sends an <edit-config> RPC merging the XML payload into the <candidate> datastore.
+func EditCandidateContext(ctx context.Context, session *netconf.Session, xmlData string) error {
+	rpc := message.NewEditConfig(
+		message.DatastoreCandidate,
+		message.DefaultOperationTypeMerge,
+		xmlData,
+	)
+	return syncOK(ctx, session, rpc, "edit-config")
+}
+
+// ValidateCandidate sends a <validate> RPC for the <candidate> datastore.
+// Servers without the :validate capability are skipped.
+func ValidateCandidate(ctx context.Context, session *netconf.Session) error {
+	if !HasCapability(session, CapabilityValidate) && !HasCapability(session, CapabilityValidate11) {
+		return nil
+	}
+	return syncOK(ctx, session, message.NewValidate(message.DatastoreCandidate), "validate")
+}
+
+// DiscardChanges reverts the <candidate> datastore to the current <running> configuration.
+func DiscardChanges(ctx context.Context, session *netconf.Session) error {
+	return syncOK(ctx, session, message.NewRPC("<discard-changes/>"), "discard-changes")
+}
+
+// CommitCandidate sends a plain <commit>. On a session with a pending confirmed
+// commit, this is the confirming commit.
+func CommitCandidate(ctx context.Context, session *netconf.Session) error {
+	return syncOK(ctx, session, message.NewCommit(), "commit")
+}
+
+// ConfirmedCommit sends <commit><confirmed/> with the given confirm-timeout.
+// The server reverts the commit unless a confirming commit arrives within the
+// timeout, or immediately if this session is closed first.
+func ConfirmedCommit(ctx context.Context, session *netconf.Session, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultConfirmTimeout
+	}
+
+	seconds := uint64(math.Ceil(timeout.Seconds()))
+
+	rpc := message.NewRPC(fmt.Sprintf(
+		"<commit><confirmed/><confirm-timeout>%d</confirm-timeout></commit>",
+		seconds,
+	))
+	return syncOK(ctx, session, rpc, "confirmed commit")
+}
+
+// CancelCommit sends <cancel-commit/>, reverting a pending confirmed commit.
+// It needs :confirmed-commit:1.1; on 1.0 servers closing the session has the same effect.
+func CancelCommit(ctx context.Context, session *netconf.Session) error {
+	if !HasCapability(session, CapabilityConfirmedCommit11) {
+		return fmt.Errorf("cancel-commit requires %s", CapabilityConfirmedCommit11)
+	}
+	return syncOK(ctx, session, message.NewRPC("<cancel-commit/>"), "cancel-commit")
+}
+
+// syncOK runs the RPC bounded by ctx and requires an <ok/> reply.
+func syncOK(ctx context.Context, session *netconf.Session, rpc message.RPCMethod, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	reply, err := syncRPC(session, rpc, rpcTimeout(ctx))
+	if err != nil {
+		return fmt.Errorf("%s RPC failed: %w", name, err)
+	}
+
+	if reply == nil || reply.RawReply == "" {
+		return fmt.Errorf("empty reply from %s", name)
+	}
+
+	if len(reply.Errors) > 0 {
+		return fmt.Errorf("%s failed: %w", name, &reply.Errors[0])
+	}
+
+	if err := checkNetconfOKReply(reply.RawReply); err != nil {
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	return nil
+}
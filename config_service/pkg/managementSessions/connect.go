@@ -0,0 +1,48 @@
+package managementSessions
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"OpenCNC_config_service/common/structures/topology"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"golang.org/x/crypto/ssh"
+)
+
+// Verification holds what is needed to authenticate a device, per transport.
+type Verification struct {
+	HostKeyCallback ssh.HostKeyCallback // SSH, see KnownHosts.Callback
+	RootCAs         *x509.CertPool      // TLS, the system roots if nil
+}
+
+// Connect opens a NETCONF session to the device described by info, over the
+// transport it selects; callers need not care which one is used.
+func Connect(ctx context.Context, info *topology.ManagementInfo, creds Credentials, verify Verification) (*netconf.Session, error) {
+	if info == nil {
+		return nil, fmt.Errorf("no management info")
+	}
+
+	switch transport := info.GetTransport(); transport {
+	case topology.ManagementTransport_DEFAULT_TRANSPORT, topology.ManagementTransport_SSH:
+		return CreateSessionContext(ctx, SSHConfig{
+			Host:            info.GetIpAddress(),
+			Port:            info.GetManagementPort(),
+			Credentials:     creds,
+			HostKeyCallback: verify.HostKeyCallback,
+		})
+
+	case topology.ManagementTransport_TLS:
+		return CreateTLSSessionContext(ctx, TLSConfig{
+			Host:        info.GetIpAddress(),
+			Port:        info.GetManagementPort(),
+			Credentials: creds,
+			RootCAs:     verify.RootCAs,
+			ServerName:  info.GetTlsServerName(),
+		})
+
+	default:
+		return nil, fmt.Errorf("unsupported management transport %s", transport)
+	}
+}
@@ -0,0 +1,245 @@
+package managementSessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"OpenCNC_config_service/common/structures/topology"
+)
+
+// EnvCredentials reads credentials from the environment:
+//
+//	NETCONF_USERNAME_<NODE>, NETCONF_USERNAME
+//	NETCONF_PASSWORD_<NODE>, NETCONF_PASSWORD
+//	NETCONF_SSH_KEY_FILE_<NODE>, NETCONF_SSH_KEY_FILE
+//
+// where <NODE> is the node name upper-cased, with every character other than
+// letters and digits replaced by '_'. Node specific variables take precedence.
+type EnvCredentials struct{}
+
+func (EnvCredentials) Credentials(_ context.Context, node *topology.Node) (Credentials, error) {
+	suffix := "_" + envSuffix(node.GetName())
+
+	lookup := func(name string) string {
+		if v, ok := os.LookupEnv(name + suffix); ok {
+			return v
+		}
+		return os.Getenv(name)
+	}
+
+	creds := Credentials{
+		Username: lookup("NETCONF_USERNAME"),
+		Password: lookup("NETCONF_PASSWORD"),
+	}
+
+	if path := lookup("NETCONF_SSH_KEY_FILE"); path != "" {
+		keys, certs, err := readPEMFile(path)
+		if err != nil {
+			return Credentials{}, err
+		}
+		creds.PrivateKeys = keys
+		creds.Certificates = certs
+	}
+
+	return creds, nil
+}
+
+func envSuffix(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// FileCredentials reads credentials from a JSON file, re-read on every lookup
+// so rotated secrets are picked up:
+//
+//	{
+//	  "default": {"username": "admin", "password": "..."},
+//	  "nodes": {
+//	    "bridge-1": {"private_key_file": "/secrets/bridge-1.key"}
+//	  }
+//	}
+//
+// Node entries take precedence over "default", field by field.
+type FileCredentials struct {
+	Path string
+}
+
+type credentialsEntry struct {
+	Username       string `json:"username"`
+	Password       string `json:"password"`
+	PrivateKeyFile string `json:"private_key_file"`
+}
+
+func (f FileCredentials) Credentials(_ context.Context, node *topology.Node) (Credentials, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("reading credentials file: %w", err)
+	}
+
+	var file struct {
+		Default credentialsEntry            `json:"default"`
+		Nodes   map[string]credentialsEntry `json:"nodes"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		// never wrap the decode error, it may quote the file content
+		return Credentials{}, fmt.Errorf("credentials file %s is not valid JSON", f.Path)
+	}
+
+	var creds Credentials
+
+	for _, entry := range []credentialsEntry{file.Nodes[node.GetName()], file.Default} {
+		found := Credentials{Username: entry.Username, Password: entry.Password}
+
+		if entry.PrivateKeyFile != "" && len(creds.PrivateKeys) == 0 {
+			keys, certs, err := readPEMFile(entry.PrivateKeyFile)
+			if err != nil {
+				return Credentials{}, err
+			}
+			found.PrivateKeys = keys
+			found.Certificates = certs
+		}
+
+		creds = creds.merge(found)
+	}
+
+	return creds, nil
+}
+
+// SecretsDir reads credentials from a directory laid out like mounted
+// Kubernetes secrets, one sub-directory per node with "default" as fallback:
+//
+//	<dir>/<node>/username
+//	<dir>/<node>/password
+//	<dir>/<node>/ssh-privatekey
+//	<dir>/<node>/tls.crt, <dir>/<node>/tls.key
+//
+// Missing files are skipped; trailing newlines are trimmed from username and password.
+type SecretsDir struct {
+	Dir string
+}
+
+func (s SecretsDir) Credentials(_ context.Context, node *topology.Node) (Credentials, error) {
+	var creds Credentials
+
+	dirs := []string{"default"}
+	if name := node.GetName(); name != "" && filepath.Base(name) == name && name != ".." {
+		dirs = []string{name, "default"}
+	}
+
+	for _, dir := range dirs {
+		found, err := s.read(filepath.Join(s.Dir, dir))
+		if err != nil {
+			return Credentials{}, err
+		}
+		creds = creds.merge(found)
+	}
+
+	return creds, nil
+}
+
+func (s SecretsDir) read(dir string) (Credentials, error) {
+	var creds Credentials
+
+	username, err := readSecretFile(filepath.Join(dir, "username"))
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := readSecretFile(filepath.Join(dir, "password"))
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	creds.Username = strings.TrimRight(string(username), "\r\n")
+	creds.Password = strings.TrimRight(string(password), "\r\n")
+
+	for _, name := range []string{"ssh-privatekey", "tls.crt", "tls.key"} {
+		data, err := readSecretFile(filepath.Join(dir, name))
+		if err != nil {
+			return Credentials{}, err
+		}
+		keys, certs := splitPEM(data)
+		creds.PrivateKeys = append(creds.PrivateKeys, keys...)
+		creds.Certificates = append(creds.Certificates, certs...)
+	}
+
+	return creds, nil
+}
+
+// KeyCertificates resolves the node's ManagementInfo.key_certificates. Each
+// entry is either inline PEM, or the path of a PEM file; relative paths are
+// resolved against Dir.
+type KeyCertificates struct {
+	Dir string
+}
+
+func (k KeyCertificates) Credentials(_ context.Context, node *topology.Node) (Credentials, error) {
+	var creds Credentials
+
+	for i, entry := range node.GetManagementInfo().GetKeyCertificates() {
+
+		if strings.HasPrefix(strings.TrimSpace(entry), "-----BEGIN ") {
+			keys, certs := splitPEM([]byte(entry))
+			if len(keys) == 0 && len(certs) == 0 {
+				return Credentials{}, fmt.Errorf("key_certificates[%d] of node %s holds no usable PEM block", i, node.GetName())
+			}
+			creds.PrivateKeys = append(creds.PrivateKeys, keys...)
+			creds.Certificates = append(creds.Certificates, certs...)
+			continue
+		}
+
+		path := entry
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(k.Dir, path)
+		}
+
+		keys, certs, err := readPEMFile(path)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("key_certificates[%d] of node %s: %w", i, node.GetName(), err)
+		}
+		creds.PrivateKeys = append(creds.PrivateKeys, keys...)
+		creds.Certificates = append(creds.Certificates, certs...)
+	}
+
+	return creds, nil
+}
+
+// readSecretFile returns the file content, or nil if it does not exist.
+func readSecretFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading secret %s: %w", path, err)
+	}
+	return data, nil
+}
+
+func readPEMFile(path string) (keys, certs [][]byte, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading key file: %w", err)
+	}
+
+	keys, certs = splitPEM(data)
+	if len(keys) == 0 && len(certs) == 0 {
+		return nil, nil, fmt.Errorf("key file %s holds no PEM encoded key or certificate", path)
+	}
+
+	return keys, certs, nil
+}
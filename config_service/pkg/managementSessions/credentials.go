@@ -0,0 +1,187 @@
+package managementSessions
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"OpenCNC_config_service/common/structures/topology"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Credentials authenticate the controller towards one device.
+//
+// Credentials format as a redacted summary with %v, %+v and %#v, so a value
+// can be logged without leaking secrets; the fields themselves must never be.
+type Credentials struct {
+	Username     string
+	Password     string
+	PrivateKeys  [][]byte // PEM encoded private keys, for SSH public key authentication or the TLS client certificate
+	Certificates [][]byte // PEM encoded client certificate chain, leaf first, for TLS transports
+}
+
+func (c Credentials) String() string {
+	password := "unset"
+	if c.Password != "" {
+		password = "<redacted>"
+	}
+
+	return fmt.Sprintf(
+		"{user:%q password:%s private-keys:%d certificates:%d}",
+		c.Username,
+		password,
+		len(c.PrivateKeys),
+		len(c.Certificates),
+	)
+}
+
+func (c Credentials) GoString() string {
+	return "managementSessions.Credentials" + c.String()
+}
+
+// IsZero reports whether no secret at all is set.
+func (c Credentials) IsZero() bool {
+	return c.Password == "" && len(c.PrivateKeys) == 0 && len(c.Certificates) == 0
+}
+
+// merge fills the fields of c that are unset from other; keys and
+// certificates from both are kept.
+func (c Credentials) merge(other Credentials) Credentials {
+	if c.Username == "" {
+		c.Username = other.Username
+	}
+	if c.Password == "" {
+		c.Password = other.Password
+	}
+	c.PrivateKeys = append(c.PrivateKeys, other.PrivateKeys...)
+	c.Certificates = append(c.Certificates, other.Certificates...)
+	return c
+}
+
+// SSHAuthMethods returns the SSH authentication methods for the credentials:
+// public keys first, then password and keyboard-interactive.
+func (c Credentials) SSHAuthMethods() ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	signers, err := c.sshSigners()
+	if err != nil {
+		return nil, err
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if c.Password != "" {
+		password := c.Password
+		methods = append(methods,
+			ssh.Password(password),
+			ssh.KeyboardInteractive(answerAll(password)),
+		)
+	}
+
+	if len(methods) == 0 {
+		// Some lab devices accept an empty password.
+		methods = append(methods, ssh.Password(""))
+	}
+
+	return methods, nil
+}
+
+// TLSCertificate returns the client certificate chain of the credentials,
+// paired with the private key it was issued for.
+func (c Credentials) TLSCertificate() (tls.Certificate, error) {
+	if len(c.Certificates) == 0 {
+		return tls.Certificate{}, errors.New("no client certificate")
+	}
+
+	chain := bytes.Join(c.Certificates, nil)
+
+	for _, key := range c.PrivateKeys {
+		if cert, err := tls.X509KeyPair(chain, key); err == nil {
+			return cert, nil
+		}
+	}
+
+	// never wrap the pairing error, it may quote key material
+	return tls.Certificate{}, errors.New("no private key matches the client certificate")
+}
+
+func (c Credentials) sshSigners() ([]ssh.Signer, error) {
+	signers := make([]ssh.Signer, 0, len(c.PrivateKeys))
+
+	for i, key := range c.PrivateKeys {
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			var missing *ssh.PassphraseMissingError
+			if errors.As(err, &missing) {
+				return nil, fmt.Errorf("private key %d is passphrase protected", i)
+			}
+			// never wrap the parse error, it may quote key material
+			return nil, fmt.Errorf("private key %d cannot be parsed", i)
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+// answerAll answers every keyboard-interactive question with the password.
+func answerAll(password string) ssh.KeyboardInteractiveChallenge {
+	return func(_, _ string, questions []string, _ []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range answers {
+			answers[i] = password
+		}
+		return answers, nil
+	}
+}
+
+// CredentialProvider resolves the credentials used to manage a node.
+type CredentialProvider interface {
+	Credentials(ctx context.Context, node *topology.Node) (Credentials, error)
+}
+
+// CredentialChain asks every provider in order and merges their answers: the
+// first username and password found win, keys and certificates accumulate.
+// The username falls back to ManagementInfo.user_name.
+type CredentialChain []CredentialProvider
+
+func (c CredentialChain) Credentials(ctx context.Context, node *topology.Node) (Credentials, error) {
+	var creds Credentials
+
+	for _, provider := range c {
+		found, err := provider.Credentials(ctx, node)
+		if err != nil {
+			return Credentials{}, err
+		}
+		creds = creds.merge(found)
+	}
+
+	if creds.Username == "" {
+		creds.Username = node.GetManagementInfo().GetUserName()
+	}
+
+	return creds, nil
+}
+
+// splitPEM sorts the PEM blocks of data into private keys and certificates.
+func splitPEM(data []byte) (keys, certs [][]byte) {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return keys, certs
+		}
+		data = rest
+
+		switch block.Type {
+		case "CERTIFICATE":
+			certs = append(certs, pem.EncodeToMemory(block))
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY", "OPENSSH PRIVATE KEY", "ENCRYPTED PRIVATE KEY":
+			keys = append(keys, pem.EncodeToMemory(block))
+		}
+	}
+}
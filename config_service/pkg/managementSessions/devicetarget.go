@@ -1,17 +1,37 @@
 package managementSessions
 
 import (
+	"context"
+	"crypto/x509"
+
 	"OpenCNC_config_service/common/observability"
 	topology "OpenCNC_config_service/common/structures/topology"
 
 	"github.com/openshift-telco/go-netconf-client/netconf"
+	"golang.org/x/crypto/ssh"
 )
 
 type DeviceTarget struct {
 	Info          *topology.ManagementInfo
-	Secret        string
+	Credentials   Credentials
+	HostKey       ssh.HostKeyCallback // see KnownHosts.Callback
+	RootCAs       *x509.CertPool      // CAs of TLS devices, the system roots if nil
 	Logger        observability.Logger
 	Session       *netconf.Session
 	InterfaceName string
 	// You can extend this with sessions, retry, TLS configs, etc.
 }
+
+// Connect opens a NETCONF session to the target with its credentials, over
+// the transport and port set in Info; the username defaults to Info.UserName.
+func (t *DeviceTarget) Connect() (*netconf.Session, error) {
+	creds := t.Credentials
+	if creds.Username == "" {
+		creds.Username = t.Info.GetUserName()
+	}
+
+	return Connect(context.Background(), t.Info, creds, Verification{
+		HostKeyCallback: t.HostKey,
+		RootCAs:         t.RootCAs,
+	})
+}
@@ -0,0 +1,198 @@
+package managementSessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"OpenCNC_config_service/common/observability"
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	observabilityv1 "OpenCNC_config_service/common/structures/logging"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// HostKeyMode selects how unknown device host keys are handled.
+type HostKeyMode string
+
+const (
+	// HostKeyTOFU trusts and pins the host key presented on first contact.
+	HostKeyTOFU HostKeyMode = "tofu"
+	// HostKeyStrict only accepts host keys pinned beforehand.
+	HostKeyStrict HostKeyMode = "strict"
+)
+
+func ParseHostKeyMode(s string) (HostKeyMode, error) {
+	switch mode := HostKeyMode(strings.ToLower(s)); mode {
+	case HostKeyTOFU, HostKeyStrict:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown host key mode %q, expected %q or %q", s, HostKeyTOFU, HostKeyStrict)
+	}
+}
+
+// ErrHostKeyNotPinned is returned in strict mode for a node without pinned host key.
+var ErrHostKeyNotPinned = errors.New("host key not pinned")
+
+// HostKeyMismatchError is returned when a device presents a host key other
+// than the ones pinned for its node.
+type HostKeyMismatchError struct {
+	Node        string
+	Address     string
+	Fingerprint string   // SHA256 fingerprint presented by the device
+	Pinned      []string // fingerprints pinned for the node
+}
+
+func (e *HostKeyMismatchError) Error() string {
+	return fmt.Sprintf(
+		"host key mismatch for node %s at %s: got %s, pinned %s",
+		e.Node,
+		e.Address,
+		e.Fingerprint,
+		strings.Join(e.Pinned, ", "),
+	)
+}
+
+// HostKeyStore keeps the host key fingerprints pinned per node.
+type HostKeyStore interface {
+	HostKeys(node string) ([]string, error)
+	PinHostKey(node, fingerprint string) error
+}
+
+// KVHostKeyStore pins host keys in the k/v store, under known-hosts/<node>.
+type KVHostKeyStore struct {
+	Store storewrapper.Store
+}
+
+func (s KVHostKeyStore) HostKeys(node string) ([]string, error) {
+	return storewrapper.GetHostKeys(context.Background(), s.Store, node)
+}
+
+func (s KVHostKeyStore) PinHostKey(node, fingerprint string) error {
+	pinned, err := storewrapper.GetHostKeys(context.Background(), s.Store, node)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(pinned, fingerprint) {
+		return nil
+	}
+	return storewrapper.StoreHostKeys(context.Background(), s.Store, node, append(pinned, fingerprint))
+}
+
+// MemoryHostKeyStore pins host keys for the lifetime of the process.
+type MemoryHostKeyStore struct {
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func NewMemoryHostKeyStore() *MemoryHostKeyStore {
+	return &MemoryHostKeyStore{keys: make(map[string][]string)}
+}
+
+func (s *MemoryHostKeyStore) HostKeys(node string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.keys[node]), nil
+}
+
+func (s *MemoryHostKeyStore) PinHostKey(node, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.keys[node], fingerprint) {
+		s.keys[node] = append(s.keys[node], fingerprint)
+	}
+	return nil
+}
+
+// Auditor records security relevant decisions; *observability.Client
+// implements it.
+type Auditor interface {
+	Audit(ctx context.Context, severity observabilityv1.Severity, actor string, action string, targetType string, targetID string, result observabilityv1.AuditResult, reason string) error
+}
+
+// KnownHosts verifies device host keys against the fingerprints pinned per
+// node. Mismatches, and unknown keys in strict mode, are rejected and audited.
+type KnownHosts struct {
+	mode    HostKeyMode
+	store   HostKeyStore
+	logger  observability.Logger
+	auditor Auditor // optional
+
+	mu sync.Mutex // serializes first-use pinning
+}
+
+func NewKnownHosts(mode HostKeyMode, store HostKeyStore, logger observability.Logger, auditor Auditor) *KnownHosts {
+	return &KnownHosts{
+		mode:    mode,
+		store:   store,
+		logger:  observability.NormalizeLogger(logger),
+		auditor: auditor,
+	}
+}
+
+// Callback returns the host key callback for sessions to node.
+func (k *KnownHosts) Callback(node string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		return k.verify(node, hostname, key)
+	}
+}
+
+func (k *KnownHosts) verify(node, address string, key ssh.PublicKey) error {
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	pinned, err := k.store.HostKeys(node)
+	if err != nil {
+		return fmt.Errorf("loading pinned host keys of node %s: %w", node, err)
+	}
+
+	if slices.Contains(pinned, fingerprint) {
+		return nil
+	}
+
+	if len(pinned) > 0 {
+		err := &HostKeyMismatchError{
+			Node:        node,
+			Address:     address,
+			Fingerprint: fingerprint,
+			Pinned:      pinned,
+		}
+		k.logger.Printf("Rejecting NETCONF session: %v", err)
+		k.audit(node, observabilityv1.Severity_SEVERITY_CRITICAL, observabilityv1.AuditResult_AUDIT_RESULT_DENIED, err.Error())
+		return err
+	}
+
+	if k.mode != HostKeyTOFU {
+		err := fmt.Errorf("%w: node %s at %s presented %s", ErrHostKeyNotPinned, node, address, fingerprint)
+		k.logger.Printf("Rejecting NETCONF session: %v", err)
+		k.audit(node, observabilityv1.Severity_SEVERITY_ERROR, observabilityv1.AuditResult_AUDIT_RESULT_DENIED, err.Error())
+		return err
+	}
+
+	if err := k.store.PinHostKey(node, fingerprint); err != nil {
+		return fmt.Errorf("pinning host key of node %s: %w", node, err)
+	}
+
+	k.logger.Printf("Pinned host key %s %s for node %s on first use", key.Type(), fingerprint, node)
+	k.audit(node, observabilityv1.Severity_SEVERITY_WARN, observabilityv1.AuditResult_AUDIT_RESULT_ALLOWED, "pinned "+fingerprint+" on first use")
+
+	return nil
+}
+
+func (k *KnownHosts) audit(node string, severity observabilityv1.Severity, result observabilityv1.AuditResult, reason string) {
+	if k.auditor == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_ = k.auditor.Audit(ctx, severity, "config-service", "ssh-host-key", "node", node, result, reason)
+}
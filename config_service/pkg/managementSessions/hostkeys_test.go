@@ -0,0 +1,111 @@
+package managementSessions
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	observabilityv1 "OpenCNC_config_service/common/structures/logging"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type recordingAuditor struct {
+	mu      sync.Mutex
+	results []observabilityv1.AuditResult
+}
+
+func (a *recordingAuditor) Audit(_ context.Context, _ observabilityv1.Severity, _ string, _ string, _ string, _ string, result observabilityv1.AuditResult, _ string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results = append(a.results, result)
+	return nil
+}
+
+func (a *recordingAuditor) snapshot() []observabilityv1.AuditResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.results)
+}
+
+func testHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("public key: %v", err)
+	}
+	return key
+}
+
+func TestKnownHosts_TOFUPinsThenRejectsMismatch(t *testing.T) {
+	store := NewMemoryHostKeyStore()
+	auditor := &recordingAuditor{}
+	hosts := NewKnownHosts(HostKeyTOFU, store, nil, auditor)
+
+	first, second := testHostKey(t), testHostKey(t)
+
+	if err := hosts.Callback("bridge-1")("10.0.0.1:830", nil, first); err != nil {
+		t.Fatalf("first contact: %v", err)
+	}
+	if err := hosts.Callback("bridge-1")("10.0.0.1:830", nil, first); err != nil {
+		t.Fatalf("pinned key: %v", err)
+	}
+
+	pinned, _ := store.HostKeys("bridge-1")
+	if len(pinned) != 1 || pinned[0] != ssh.FingerprintSHA256(first) {
+		t.Fatalf("unexpected pins %v", pinned)
+	}
+
+	err := hosts.Callback("bridge-1")("10.0.0.1:830", nil, second)
+
+	var mismatch *HostKeyMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected HostKeyMismatchError, got %v", err)
+	}
+	if mismatch.Fingerprint != ssh.FingerprintSHA256(second) {
+		t.Fatalf("unexpected fingerprint %s", mismatch.Fingerprint)
+	}
+
+	want := []observabilityv1.AuditResult{
+		observabilityv1.AuditResult_AUDIT_RESULT_ALLOWED,
+		observabilityv1.AuditResult_AUDIT_RESULT_DENIED,
+	}
+	if got := auditor.snapshot(); !slices.Equal(got, want) {
+		t.Fatalf("expected audits %v, got %v", want, got)
+	}
+}
+
+func TestKnownHosts_StrictRejectsUnpinned(t *testing.T) {
+	store := NewMemoryHostKeyStore()
+	hosts := NewKnownHosts(HostKeyStrict, store, nil, nil)
+
+	key := testHostKey(t)
+
+	if err := hosts.Callback("bridge-1")("10.0.0.1:830", nil, key); !errors.Is(err, ErrHostKeyNotPinned) {
+		t.Fatalf("expected ErrHostKeyNotPinned, got %v", err)
+	}
+
+	_ = store.PinHostKey("bridge-1", ssh.FingerprintSHA256(key))
+
+	if err := hosts.Callback("bridge-1")("10.0.0.1:830", nil, key); err != nil {
+		t.Fatalf("pinned key: %v", err)
+	}
+}
+
+func TestSSHConfig_AddressHonoursPort(t *testing.T) {
+	if got := (SSHConfig{Host: "10.0.0.1"}).Address(); got != "10.0.0.1:830" {
+		t.Fatalf("default port: got %s", got)
+	}
+	if got := (SSHConfig{Host: "fd00::1", Port: 2022}).Address(); got != "[fd00::1]:2022" {
+		t.Fatalf("configured port: got %s", got)
+	}
+}
@@ -0,0 +1,96 @@
+package managementSessions
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"github.com/openshift-telco/go-netconf-client/netconf/message"
+)
+
+// LockDeniedError is returned when a datastore lock is held by another session.
+type LockDeniedError struct {
+	Datastore string
+	SessionID string // session holding the lock; "0" if it is held by a non-NETCONF entity
+	Message   string
+}
+
+func (e *LockDeniedError) Error() string {
+	msg := fmt.Sprintf("%s datastore is locked by session %s", e.Datastore, e.SessionID)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// LockTargets returns the datastores to lock before configuring through this
+// session, in locking order: <running>, then <candidate> when the server
+// supports it, so that no other session changes <running> directly before the
+// commit (RFC 6241 section 7.5).
+func LockTargets(session *netconf.Session) []string {
+	if HasCapability(session, CapabilityCandidate) {
+		return []string{message.DatastoreRunning, message.DatastoreCandidate}
+	}
+	return []string{message.DatastoreRunning}
+}
+
+// Lock sends a <lock> RPC for the datastore. A lock held by another session
+// is reported as *LockDeniedError.
+func Lock(ctx context.Context, session *netconf.Session, datastore string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	reply, err := syncRPC(session, message.NewLock(datastore), rpcTimeout(ctx))
+	if err != nil {
+		return fmt.Errorf("lock RPC failed: %w", err)
+	}
+
+	if reply == nil || reply.RawReply == "" {
+		return fmt.Errorf("empty reply from lock")
+	}
+
+	for i := range reply.Errors {
+		if reply.Errors[i].Tag == "lock-denied" {
+			return &LockDeniedError{
+				Datastore: datastore,
+				SessionID: lockHolder(reply.Errors[i]),
+				Message:   reply.Errors[i].Message,
+			}
+		}
+	}
+
+	if len(reply.Errors) > 0 {
+		return fmt.Errorf("lock failed: %w", &reply.Errors[0])
+	}
+
+	if err := checkNetconfOKReply(reply.RawReply); err != nil {
+		return fmt.Errorf("lock failed: %w", err)
+	}
+
+	return nil
+}
+
+// Unlock sends an <unlock> RPC for the datastore.
+func Unlock(ctx context.Context, session *netconf.Session, datastore string) error {
+	return syncOK(ctx, session, message.NewUnlock(datastore), "unlock")
+}
+
+// lockHolder extracts <error-info><session-id> from a lock-denied rpc-error.
+func lockHolder(rpcErr message.RPCError) string {
+
+	var info struct {
+		SessionID string `xml:"error-info>session-id"`
+	}
+
+	if err := xml.Unmarshal([]byte("<rpc-error>"+rpcErr.Info+"</rpc-error>"), &info); err != nil {
+		return "unknown"
+	}
+
+	if info.SessionID == "" {
+		return "unknown"
+	}
+
+	return info.SessionID
+}
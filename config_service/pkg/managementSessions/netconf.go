@@ -5,48 +5,132 @@ package managementSessions
 // go run Connect_netconf.go  -s 192.168.4.64 -e -f config.xml
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"log/slog"
+	"math"
+	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/openshift-telco/go-netconf-client/netconf"
 	"github.com/openshift-telco/go-netconf-client/netconf/message"
 	"golang.org/x/crypto/ssh"
 )
 
+// defaultRPCTimeout is the RPC timeout in seconds used when the context carries no deadline.
+const defaultRPCTimeout int32 = 5
+
+// DefaultSSHPort is the NETCONF over SSH port (RFC 6242).
+const DefaultSSHPort = 830
+
+// SSHConfig describes how to reach a NETCONF server over SSH.
+type SSHConfig struct {
+	Host            string
+	Port            uint32 // DefaultSSHPort if zero
+	Credentials     Credentials
+	HostKeyCallback ssh.HostKeyCallback // verifies the server host key, required
+}
+
+// Address returns the host:port to dial.
+func (c SSHConfig) Address() string {
+	port := c.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+	return net.JoinHostPort(c.Host, strconv.FormatUint(uint64(port), 10))
+}
+
 // createSession connects to a NETCONF server and returns the session.
-func CreateSession(host, user, pass string) (*netconf.Session, error) {
+func CreateSession(config SSHConfig) (*netconf.Session, error) {
+	address := config.Address()
+
+	if config.HostKeyCallback == nil {
+		return nil, fmt.Errorf("no host key verification configured for %s", address)
+	}
+
+	auth, err := config.Credentials.SSHAuthMethods()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials for %s: %w", address, err)
+	}
+
 	sshConfig := &ssh.ClientConfig{
-		User:            user,
-		Auth:            []ssh.AuthMethod{ssh.Password(pass)},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		User:            config.Credentials.Username,
+		Auth:            auth,
+		HostKeyCallback: config.HostKeyCallback,
 	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	address := fmt.Sprintf("%s:830", host)
 
 	session, err := netconf.NewSessionFromSSHConfig(address, sshConfig, netconf.WithSessionLogger(logger))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
-	err = session.SendHello(&message.Hello{
-		Capabilities: netconf.DefaultCapabilities,
-	})
-	if err != nil {
-		session.Close()
+	if err := startSession(session); err != nil {
+		CloseSession(session)
 		return nil, fmt.Errorf("failed to send hello: %w", err)
 	}
 
 	return session, nil
 }
 
+// CreateSessionContext is CreateSession bounded by ctx. If ctx ends before the
+// session is established, the late session is closed in the background.
+func CreateSessionContext(ctx context.Context, config SSHConfig) (*netconf.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		session *netconf.Session
+		err     error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		session, err := CreateSession(config)
+		done <- result{session, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.session, res.err
+	case <-ctx.Done():
+		go func() {
+			if res := <-done; res.session != nil {
+				CloseSession(res.session)
+			}
+		}()
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.Address(), ctx.Err())
+	}
+}
+
+// rpcTimeout converts the remaining time before the ctx deadline into the
+// whole-second timeout expected by syncRPC.
+func rpcTimeout(ctx context.Context) int32 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return defaultRPCTimeout
+	}
+
+	remaining := math.Ceil(time.Until(deadline).Seconds())
+	if remaining < 1 {
+		return 1
+	}
+	if remaining > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(remaining)
+}
+
 // getRunningConfig retrieves the <running> config using a <get-config> RPC.
 func GetRunningConfig(session *netconf.Session) (string, error) {
 	rpc := message.NewGetConfig(message.DatastoreRunning, "", "")
-	reply, err := session.SyncRPC(rpc, 5)
+	reply, err := syncRPC(session, rpc, 5)
 	if err != nil {
 		return "", fmt.Errorf("RPC failed: %w", err)
 	}
@@ -58,14 +142,66 @@ func GetRunningConfig(session *netconf.Session) (string, error) {
 	return reply.RawReply, nil
 }
 
+// GetRunningDataContext retrieves the content of <data> from a <get-config> on
+// <running>. A non-empty filter is sent as a subtree filter.
+func GetRunningDataContext(ctx context.Context, session *netconf.Session, filter string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filterType := ""
+	if filter != "" {
+		filterType = message.FilterTypeSubtree
+	}
+
+	rpc := message.NewGetConfig(message.DatastoreRunning, filterType, filter)
+	reply, err := syncRPC(session, rpc, rpcTimeout(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("get-config RPC failed: %w", err)
+	}
+
+	if reply == nil || reply.RawReply == "" {
+		return nil, fmt.Errorf("empty reply from get-config")
+	}
+
+	if len(reply.Errors) > 0 {
+		return nil, fmt.Errorf("get-config failed: %w", &reply.Errors[0])
+	}
+
+	var rpcReply struct {
+		Data *struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"data"`
+	}
+
+	if err := xml.Unmarshal([]byte(reply.RawReply), &rpcReply); err != nil {
+		return nil, fmt.Errorf("failed parsing get-config reply: %w", err)
+	}
+
+	if rpcReply.Data == nil {
+		return nil, fmt.Errorf("get-config reply does not contain <data>")
+	}
+
+	return rpcReply.Data.Inner, nil
+}
+
 // editConfig sends an <edit-config> RPC with the provided XML payload to the <running> datastore.
 func EditConfig(session *netconf.Session, xmlData string) error {
+	return EditConfigContext(context.Background(), session, xmlData)
+}
+
+// EditConfigContext is EditConfig with the RPC timeout taken from the ctx deadline.
+func EditConfigContext(ctx context.Context, session *netconf.Session, xmlData string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	rpc := message.NewEditConfig(
 		message.DatastoreRunning,
 		message.DefaultOperationTypeMerge,
 		xmlData,
 	)
-	reply, err := session.SyncRPC(rpc, 5)
+	reply, err := syncRPC(session, rpc, rpcTimeout(ctx))
 	if err != nil {
 		return fmt.Errorf("edit-config RPC failed: %w", err)
 	}
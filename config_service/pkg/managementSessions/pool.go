@@ -0,0 +1,489 @@
+package managementSessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"OpenCNC_config_service/common/observability"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"github.com/openshift-telco/go-netconf-client/netconf/message"
+)
+
+// ErrDeviceUnreachable is returned by the pool while a device is backing off
+// after failed connection attempts or keepalive probes.
+var ErrDeviceUnreachable = errors.New("device unreachable")
+
+// Dialer opens a new NETCONF session to one device.
+type Dialer func(ctx context.Context) (*netconf.Session, error)
+
+// PoolConfig tunes a SessionPool. Zero values select the defaults.
+type PoolConfig struct {
+	MaxSessionsPerDevice int           // open sessions per device, default 2
+	KeepaliveInterval    time.Duration // idle session probe period, default 30s
+	ProbeTimeout         time.Duration // keepalive reply timeout, default 5s
+	BackoffMin           time.Duration // delay after the first failure, default 1s
+	BackoffMax           time.Duration // upper bound of the doubling delay, default 2m
+}
+
+func (c PoolConfig) withDefaults() PoolConfig {
+	if c.MaxSessionsPerDevice <= 0 {
+		c.MaxSessionsPerDevice = 2
+	}
+	if c.KeepaliveInterval <= 0 {
+		c.KeepaliveInterval = 30 * time.Second
+	}
+	if c.ProbeTimeout <= 0 {
+		c.ProbeTimeout = 5 * time.Second
+	}
+	if c.BackoffMin <= 0 {
+		c.BackoffMin = time.Second
+	}
+	if c.BackoffMax < c.BackoffMin {
+		c.BackoffMax = max(2*time.Minute, c.BackoffMin)
+	}
+	return c
+}
+
+// SessionHealth describes the reachability of one device as seen by the pool.
+type SessionHealth struct {
+	Failures   int       // consecutive failed dials or keepalive probes
+	LastError  error     // error of the last failure
+	RetryAt    time.Time // no session is dialed before this time
+	LastActive time.Time // last successful dial or probe
+	Open       int       // open sessions, idle or in use
+	Idle       int       // open sessions waiting in the pool
+}
+
+// Err returns a non-nil error while the device is backing off.
+func (h SessionHealth) Err() error {
+	if h.Failures == 0 || !time.Now().Before(h.RetryAt) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %d consecutive failures, next attempt at %s: %v",
+		ErrDeviceUnreachable,
+		h.Failures,
+		h.RetryAt.Format(time.RFC3339),
+		h.LastError,
+	)
+}
+
+// SessionPool keeps NETCONF sessions open per device key (the node name) and
+// hands them out for exclusive use. Idle sessions are probed periodically,
+// broken ones are replaced, and failing devices are retried with exponential
+// backoff; during backoff Acquire fails fast with ErrDeviceUnreachable.
+type SessionPool struct {
+	config PoolConfig
+	logger observability.Logger
+
+	mu      sync.Mutex
+	devices map[string]*devicePool
+	closed  bool
+
+	startOnce sync.Once
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+type devicePool struct {
+	dial     Dialer
+	slots    chan struct{} // one token per open session
+	idle     []*netconf.Session
+	health   SessionHealth
+	callHome bool          // sessions are registered by the device calling in, never dialed
+	idleSet  chan struct{} // closed and replaced whenever a session becomes idle
+}
+
+func NewSessionPool(logger observability.Logger, config PoolConfig) *SessionPool {
+	return &SessionPool{
+		config:  config.withDefaults(),
+		logger:  observability.NormalizeLogger(logger),
+		devices: make(map[string]*devicePool),
+		stop:    make(chan struct{}),
+	}
+}
+
+// PooledSession is a session leased from the pool. It must be handed back
+// exactly once with Release, or with Discard if its state cannot be reused.
+type PooledSession struct {
+	*netconf.Session
+
+	key    string
+	pool   *SessionPool
+	device *devicePool
+	done   bool
+}
+
+// Release returns the session to the pool for reuse.
+func (s *PooledSession) Release() {
+	if s.done {
+		return
+	}
+	s.done = true
+
+	s.pool.putIdle(s.device, s.Session)
+}
+
+// putIdle makes a session of d idle, or closes it and frees its slot if it is
+// closed or the pool was closed meanwhile.
+func (p *SessionPool) putIdle(d *devicePool, session *netconf.Session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if session.IsClosed || p.closed {
+		CloseSession(session)
+		<-d.slots
+		return
+	}
+
+	d.idle = append(d.idle, session)
+	d.notifyIdle()
+}
+
+// Discard closes the session and frees its slot.
+func (s *PooledSession) Discard() {
+	if s.done {
+		return
+	}
+	s.done = true
+
+	CloseSession(s.Session)
+	<-s.device.slots
+}
+
+// Acquire leases a session to the device identified by key, reusing an idle
+// one or dialing a new one. It blocks while the device has
+// MaxSessionsPerDevice sessions open and all of them are in use.
+func (p *SessionPool) Acquire(ctx context.Context, key string, dial Dialer) (*PooledSession, error) {
+
+	p.startOnce.Do(func() { go p.keepalive() })
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("session pool is closed")
+	}
+
+	d := p.device(key)
+	d.dial = dial
+
+	if session := p.popIdle(d); session != nil {
+		p.mu.Unlock()
+		return &PooledSession{Session: session, key: key, pool: p, device: d}, nil
+	}
+
+	if d.callHome {
+		p.mu.Unlock()
+		return p.awaitIdle(ctx, key, d)
+	}
+
+	if err := d.health.Err(); err != nil {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	p.mu.Unlock()
+
+	select {
+	case d.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a session to %s: %w", key, ctx.Err())
+	}
+
+	// An idle session may have been returned while waiting for the slot.
+	p.mu.Lock()
+	if session := p.popIdle(d); session != nil {
+		p.mu.Unlock()
+		<-d.slots
+		return &PooledSession{Session: session, key: key, pool: p, device: d}, nil
+	}
+	p.mu.Unlock()
+
+	session, err := dial(ctx)
+	if err != nil {
+		<-d.slots
+		p.recordFailure(key, d, err)
+		return nil, err
+	}
+
+	p.recordSuccess(d)
+
+	return &PooledSession{Session: session, key: key, pool: p, device: d}, nil
+}
+
+// Register adds a session the device identified by key opened itself, as
+// with NETCONF Call Home. From then on the pool waits for the device to call
+// again instead of dialing it. It fails if the device already has
+// MaxSessionsPerDevice sessions open.
+func (p *SessionPool) Register(key string, session *netconf.Session) error {
+
+	p.startOnce.Do(func() { go p.keepalive() })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return fmt.Errorf("session pool is closed")
+	}
+
+	d := p.device(key)
+
+	select {
+	case d.slots <- struct{}{}:
+	default:
+		return fmt.Errorf("%s already has %d sessions open", key, p.config.MaxSessionsPerDevice)
+	}
+
+	d.idle = append(d.idle, session)
+	d.callHome = true
+	d.health.Failures = 0
+	d.health.LastError = nil
+	d.health.RetryAt = time.Time{}
+	d.health.LastActive = time.Now()
+
+	d.notifyIdle()
+
+	return nil
+}
+
+// awaitIdle waits for a call home device to call again, or for one of its
+// sessions in use to be released.
+func (p *SessionPool) awaitIdle(ctx context.Context, key string, d *devicePool) (*PooledSession, error) {
+	for {
+		p.mu.Lock()
+		if session := p.popIdle(d); session != nil {
+			p.mu.Unlock()
+			return &PooledSession{Session: session, key: key, pool: p, device: d}, nil
+		}
+		idleSet := d.idleSet
+		p.mu.Unlock()
+
+		select {
+		case <-idleSet:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for %s to call home: %w", key, ctx.Err())
+		}
+	}
+}
+
+// notifyIdle wakes up awaitIdle. Callers hold p.mu.
+func (d *devicePool) notifyIdle() {
+	close(d.idleSet)
+	d.idleSet = make(chan struct{})
+}
+
+// device returns the pool of key, creating it. Callers hold p.mu.
+func (p *SessionPool) device(key string) *devicePool {
+	d, ok := p.devices[key]
+	if !ok {
+		d = &devicePool{
+			slots:   make(chan struct{}, p.config.MaxSessionsPerDevice),
+			idleSet: make(chan struct{}),
+		}
+		p.devices[key] = d
+	}
+	return d
+}
+
+// Health returns the reachability of the device identified by key. Devices
+// never seen by the pool are reported healthy.
+func (p *SessionPool) Health(key string) SessionHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	d, ok := p.devices[key]
+	if !ok {
+		return SessionHealth{}
+	}
+
+	health := d.health
+	health.Open = len(d.slots)
+	health.Idle = len(d.idle)
+	return health
+}
+
+// Close stops the keepalive loop and closes every idle session. Leased
+// sessions are closed when they are released or discarded.
+func (p *SessionPool) Close() {
+	p.stopOnce.Do(func() { close(p.stop) })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+
+	for _, d := range p.devices {
+		for _, session := range d.idle {
+			CloseSession(session)
+			<-d.slots
+		}
+		d.idle = nil
+	}
+}
+
+// popIdle takes an open idle session. Callers hold p.mu.
+func (p *SessionPool) popIdle(d *devicePool) *netconf.Session {
+	for len(d.idle) > 0 {
+		session := d.idle[len(d.idle)-1]
+		d.idle = d.idle[:len(d.idle)-1]
+
+		if !session.IsClosed {
+			return session
+		}
+		<-d.slots
+	}
+	return nil
+}
+
+func (p *SessionPool) recordSuccess(d *devicePool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	d.health.Failures = 0
+	d.health.LastError = nil
+	d.health.RetryAt = time.Time{}
+	d.health.LastActive = time.Now()
+}
+
+func (p *SessionPool) recordFailure(key string, d *devicePool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	d.health.Failures++
+	d.health.LastError = err
+
+	backoff := p.config.BackoffMin
+	for i := 1; i < d.health.Failures && backoff < p.config.BackoffMax; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.config.BackoffMax)
+
+	d.health.RetryAt = time.Now().Add(backoff)
+
+	p.logger.Printf(
+		"NETCONF session to %s failed (%d in a row), retrying in %v: %v",
+		key,
+		d.health.Failures,
+		backoff,
+		err,
+	)
+}
+
+// keepalive periodically probes idle sessions and reconnects devices whose
+// backoff expired, until Close.
+func (p *SessionPool) keepalive() {
+	ticker := time.NewTicker(p.config.KeepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		keys := make([]string, 0, len(p.devices))
+		for key := range p.devices {
+			keys = append(keys, key)
+		}
+		p.mu.Unlock()
+
+		for _, key := range keys {
+			p.keepaliveDevice(key)
+		}
+	}
+}
+
+func (p *SessionPool) keepaliveDevice(key string) {
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	d := p.devices[key]
+	dial := d.dial
+	probes := len(d.idle)
+	reconnect := probes == 0 && d.health.Failures > 0 && d.health.Err() == nil && !d.callHome && dial != nil
+	p.mu.Unlock()
+
+	// Probe one session at a time, least recently used first, so the others
+	// stay available to Acquire meanwhile.
+	for i := 0; i < probes; i++ {
+
+		p.mu.Lock()
+		if p.closed || len(d.idle) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		session := d.idle[0]
+		d.idle = d.idle[1:]
+		redial := !d.callHome && dial != nil && d.health.Err() == nil
+		p.mu.Unlock()
+
+		if err := p.probe(session); err != nil {
+			// A stale session says little about the device: only a failed
+			// redial counts as a failure.
+			CloseSession(session)
+			<-d.slots
+			p.logger.Printf("NETCONF session to %s dropped: keepalive: %v", key, err)
+			if redial {
+				p.redial(key, d, dial)
+			}
+			continue
+		}
+
+		p.recordSuccess(d)
+		p.putIdle(d, session)
+	}
+
+	if reconnect && p.redial(key, d, dial) {
+		p.logger.Printf("NETCONF session to %s re-established", key)
+	}
+}
+
+// redial opens an idle session to the device, recording a failure and backing
+// off if it cannot be reached. It reports whether a session was opened.
+func (p *SessionPool) redial(key string, d *devicePool, dial Dialer) bool {
+
+	select {
+	case d.slots <- struct{}{}:
+	default:
+		return false // sessions are in use, the device is reachable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.ProbeTimeout)
+	defer cancel()
+
+	session, err := dial(ctx)
+	if err != nil {
+		<-d.slots
+		p.recordFailure(key, d, err)
+		return false
+	}
+
+	p.recordSuccess(d)
+	p.putIdle(d, session)
+
+	return true
+}
+
+// probe sends a <get-config> with an empty subtree filter, which selects
+// nothing: any reply proves the session is alive.
+func (p *SessionPool) probe(session *netconf.Session) error {
+	if session.IsClosed {
+		return fmt.Errorf("session closed")
+	}
+
+	rpc := message.NewRPC(`<get-config><source><running/></source><filter type="subtree"/></get-config>`)
+
+	timeout := int32(max(1, p.config.ProbeTimeout/time.Second))
+
+	_, err := syncRPC(session, rpc, timeout)
+	return err
+}
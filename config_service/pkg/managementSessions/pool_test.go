@@ -0,0 +1,320 @@
+package managementSessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+// fakeTransport is an in-memory NETCONF server answering every RPC with <ok/>,
+// or body if set, until it is made unresponsive.
+type fakeTransport struct {
+	replies chan []byte
+	closed  chan struct{}
+	once    sync.Once
+	silent  atomic.Bool
+	body    string
+}
+
+var messageID = regexp.MustCompile(`message-id="([^"]+)"`)
+
+func newFakeTransport() *fakeTransport {
+	t := &fakeTransport{
+		replies: make(chan []byte, 16),
+		closed:  make(chan struct{}),
+	}
+	t.replies <- []byte(`<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>urn:ietf:params:netconf:base:1.0</capability></capabilities>` +
+		`<session-id>7</session-id></hello>`)
+	return t
+}
+
+func (t *fakeTransport) Send(data []byte) error {
+	select {
+	case <-t.closed:
+		return errors.New("transport closed")
+	default:
+	}
+
+	if t.silent.Load() {
+		return nil
+	}
+
+	body := t.body
+	if body == "" {
+		body = "<ok/>"
+	}
+
+	if m := messageID.FindSubmatch(data); m != nil {
+		t.replies <- []byte(fmt.Sprintf(
+			`<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" message-id="%s">%s</rpc-reply>`, m[1], body))
+	}
+	return nil
+}
+
+func (t *fakeTransport) Receive() ([]byte, error) {
+	select {
+	case reply := <-t.replies:
+		return reply, nil
+	case <-t.closed:
+		return nil, errors.New("transport closed")
+	}
+}
+
+func (t *fakeTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+func (t *fakeTransport) SetVersion(string) {}
+
+type fakeDevice struct {
+	dials      atomic.Int32
+	fail       atomic.Bool
+	transports []*fakeTransport
+	mu         sync.Mutex
+}
+
+func (d *fakeDevice) dial(ctx context.Context) (*netconf.Session, error) {
+	d.dials.Add(1)
+	if d.fail.Load() {
+		return nil, errors.New("connection refused")
+	}
+
+	transport := newFakeTransport()
+	session, err := NewSession(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.transports = append(d.transports, transport)
+	d.mu.Unlock()
+
+	return session, nil
+}
+
+func TestSessionPool_ReusesReleasedSessions(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+
+	for i := 0; i < 3; i++ {
+		lease, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+		if err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+		lease.Release()
+	}
+
+	if n := device.dials.Load(); n != 1 {
+		t.Fatalf("expected a single dial, got %d", n)
+	}
+}
+
+func TestSessionPool_CapsSessionsPerDevice(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{MaxSessionsPerDevice: 1})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+
+	first, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := pool.Acquire(ctx, "bridge-1", device.dial); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected second acquire to wait for the cap, got %v", err)
+	}
+
+	first.Release()
+
+	second, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	second.Release()
+
+	if n := device.dials.Load(); n != 1 {
+		t.Fatalf("expected a single dial, got %d", n)
+	}
+}
+
+func TestSessionPool_FailsFastDuringBackoff(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{BackoffMin: time.Hour})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+	device.fail.Store(true)
+
+	if _, err := pool.Acquire(context.Background(), "bridge-1", device.dial); err == nil {
+		t.Fatalf("expected dial failure")
+	}
+
+	_, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if !errors.Is(err, ErrDeviceUnreachable) {
+		t.Fatalf("expected ErrDeviceUnreachable, got %v", err)
+	}
+	if n := device.dials.Load(); n != 1 {
+		t.Fatalf("expected no dial during backoff, got %d", n)
+	}
+	if pool.Health("bridge-1").Err() == nil {
+		t.Fatalf("expected unhealthy device")
+	}
+}
+
+func TestSessionPool_KeepaliveReplacesDeadSession(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{
+		KeepaliveInterval: 10 * time.Millisecond,
+		ProbeTimeout:      time.Second,
+		BackoffMin:        time.Millisecond,
+	})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+
+	lease, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	lease.Release()
+
+	device.mu.Lock()
+	device.transports[0].silent.Store(true)
+	device.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for device.dials.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("dead session was not replaced, health: %+v", pool.Health("bridge-1"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for pool.Health("bridge-1").Idle != 1 || pool.Health("bridge-1").Failures != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("device did not recover, health: %+v", pool.Health("bridge-1"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSessionPool_KeepaliveBacksOffOnlyWhenRedialFails(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{KeepaliveInterval: time.Hour, ProbeTimeout: time.Second, BackoffMin: time.Hour})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+
+	lease, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	lease.Release()
+
+	device.mu.Lock()
+	device.transports[0].silent.Store(true)
+	device.mu.Unlock()
+
+	pool.keepaliveDevice("bridge-1")
+
+	health := pool.Health("bridge-1")
+	if health.Failures != 0 || health.Idle != 1 || device.dials.Load() != 2 {
+		t.Fatalf("expected the stale session replaced without failure, health: %+v, dials: %d", health, device.dials.Load())
+	}
+
+	device.mu.Lock()
+	device.transports[1].silent.Store(true)
+	device.mu.Unlock()
+	device.fail.Store(true)
+
+	pool.keepaliveDevice("bridge-1")
+
+	health = pool.Health("bridge-1")
+	if health.Failures != 1 || health.Open != 0 || !errors.Is(health.Err(), ErrDeviceUnreachable) {
+		t.Fatalf("expected backoff after a failed redial, health: %+v", health)
+	}
+}
+
+func TestSessionPool_KeepaliveAfterCloseOpensNothing(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{BackoffMin: time.Nanosecond, BackoffMax: time.Nanosecond})
+
+	device := &fakeDevice{}
+	device.fail.Store(true)
+
+	if _, err := pool.Acquire(context.Background(), "bridge-1", device.dial); err == nil {
+		t.Fatalf("expected dial failure")
+	}
+	device.fail.Store(false)
+
+	pool.Close()
+
+	// The backoff expired: a keepalive still running would reconnect.
+	pool.keepaliveDevice("bridge-1")
+
+	if n := device.dials.Load(); n != 1 {
+		t.Fatalf("expected no dial after Close, got %d", n)
+	}
+	if open := pool.Health("bridge-1").Open; open != 0 {
+		t.Fatalf("expected no session open after Close, got %d", open)
+	}
+}
+
+func TestSessionPool_KeepaliveKeepsIdleSessionsAvailable(t *testing.T) {
+	pool := NewSessionPool(nil, PoolConfig{ProbeTimeout: time.Second})
+	defer pool.Close()
+
+	device := &fakeDevice{}
+
+	first, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	second, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	first.Release()
+	second.Release()
+
+	// The probe of the first session blocks until the device answers again.
+	device.mu.Lock()
+	device.transports[0].silent.Store(true)
+	device.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pool.keepaliveDevice("bridge-1")
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Health("bridge-1").Idle != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("probe did not start, health: %+v", pool.Health("bridge-1"))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	lease, err := pool.Acquire(context.Background(), "bridge-1", device.dial)
+	if err != nil {
+		t.Fatalf("acquire during probe: %v", err)
+	}
+	lease.Release()
+
+	if n := device.dials.Load(); n != 2 {
+		t.Fatalf("expected the idle session reused during the probe, got %d dials", n)
+	}
+
+	<-done
+}
@@ -0,0 +1,137 @@
+package managementSessions
+
+import (
+	"encoding/xml"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+	"github.com/openshift-telco/go-netconf-client/netconf/message"
+)
+
+// The listen goroutine of go-netconf-client reads Session.IsClosed and the
+// reply callbacks without synchronization. Sessions started by this package
+// run it over a syncTransport, and are used with syncRPC and CloseSession,
+// which touch that state only while the goroutine waits in Receive.
+
+// syncTransport serializes the listen goroutine of a session with its users.
+type syncTransport struct {
+	netconf.Transport
+
+	mu     sync.Mutex    // held by the listen goroutine, except while it waits in Receive
+	closed bool          // guarded by mu
+	done   chan struct{} // closed when the listen goroutine stops receiving
+}
+
+func newSyncTransport(transport netconf.Transport) *syncTransport {
+	t := &syncTransport{Transport: transport, done: make(chan struct{})}
+	t.mu.Lock() // released by the first Receive of the listen goroutine
+	return t
+}
+
+func (t *syncTransport) Receive() ([]byte, error) {
+	t.mu.Unlock()
+	data, err := t.Transport.Receive()
+	t.mu.Lock()
+
+	if t.closed {
+		// The listen goroutine sees IsClosed and returns.
+		close(t.done)
+		t.mu.Unlock()
+	}
+
+	return data, err
+}
+
+// NewSession establishes a NETCONF session over the transport, exchanging
+// hellos. The transport is left open on error before the server hello.
+func NewSession(transport netconf.Transport) (*netconf.Session, error) {
+	session, err := netconf.NewSession(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := startSession(session); err != nil {
+		CloseSession(session)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// startSession sends the client hello on a session whose server hello was
+// received, which starts its listen goroutine.
+func startSession(session *netconf.Session) error {
+	session.Transport = newSyncTransport(session.Transport)
+
+	return session.SendHello(&message.Hello{
+		Capabilities: netconf.DefaultCapabilities,
+	})
+}
+
+// CloseSession closes the session and waits for its listen goroutine to stop.
+// Sessions of this package must be closed with it rather than Session.Close.
+func CloseSession(session *netconf.Session) error {
+	t, ok := session.Transport.(*syncTransport)
+	if !ok {
+		return session.Close()
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		<-t.done
+		return nil
+	}
+	t.closed = true
+	err := session.Close()
+	t.mu.Unlock()
+
+	<-t.done
+
+	return err
+}
+
+// syncRPC is Session.SyncRPC registering its reply callback while the listen
+// goroutine waits in Receive. The callback is removed on timeout.
+func syncRPC(session *netconf.Session, operation message.RPCMethod, timeout int32) (*message.RPCReply, error) {
+	t, ok := session.Transport.(*syncTransport)
+	if !ok {
+		return session.SyncRPC(operation, timeout)
+	}
+
+	request, err := xml.Marshal(operation)
+	if err != nil {
+		return nil, err
+	}
+	request = append([]byte(xml.Header), request...)
+
+	id := operation.GetMessageID()
+	reply := make(chan message.RPCReply, 1)
+
+	t.mu.Lock()
+	session.Listener.Register(id, func(event netconf.Event) {
+		reply <- *event.RPCReply()
+	})
+	t.mu.Unlock()
+
+	remove := func() {
+		t.mu.Lock()
+		session.Listener.Remove(id)
+		t.mu.Unlock()
+	}
+
+	if err := session.Transport.Send(request); err != nil {
+		remove()
+		return nil, err
+	}
+
+	select {
+	case res := <-reply:
+		return &res, nil
+	case <-time.After(time.Duration(timeout) * time.Second):
+		remove()
+		return nil, errors.New("timeout while executing request")
+	}
+}
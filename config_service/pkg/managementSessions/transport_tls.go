@@ -0,0 +1,246 @@
+package managementSessions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+// DefaultTLSPort is the NETCONF over TLS port (RFC 7589).
+const DefaultTLSPort = 6513
+
+// TLSConfig describes how to reach a NETCONF server over TLS.
+type TLSConfig struct {
+	Host        string
+	Port        uint32      // DefaultTLSPort if zero
+	Credentials Credentials // Certificates and PrivateKeys form the client certificate
+	RootCAs     *x509.CertPool
+	ServerName  string // name checked against the server certificate, Host if empty
+}
+
+// Address returns the host:port to dial.
+func (c TLSConfig) Address() string {
+	port := c.Port
+	if port == 0 {
+		port = DefaultTLSPort
+	}
+	return net.JoinHostPort(c.Host, strconv.FormatUint(uint64(port), 10))
+}
+
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := c.Credentials.TLSCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	serverName := c.ServerName
+	if serverName == "" {
+		serverName = c.Host
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      c.RootCAs,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// LoadCABundle reads the PEM encoded CA certificates that sign device
+// certificates.
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA bundle %s holds no PEM encoded certificate", path)
+	}
+
+	return pool, nil
+}
+
+// CreateTLSSession connects to a NETCONF server over mutually authenticated
+// TLS and returns the session.
+func CreateTLSSession(config TLSConfig) (*netconf.Session, error) {
+	return CreateTLSSessionContext(context.Background(), config)
+}
+
+// CreateTLSSessionContext is CreateTLSSession bounded by ctx.
+func CreateTLSSessionContext(ctx context.Context, config TLSConfig) (*netconf.Session, error) {
+	address := config.Address()
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials for %s: %w", address, err)
+	}
+
+	dialer := &tls.Dialer{Config: tlsConfig}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	return startTLSSession(ctx, conn)
+}
+
+// startTLSSession exchanges hellos over an established TLS connection.
+func startTLSSession(ctx context.Context, conn net.Conn) (*netconf.Session, error) {
+
+	// The server hello is read while creating the session; bound it by ctx.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	session, err := netconf.NewSession(NewTLSTransport(conn))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	if err := startSession(session); err != nil {
+		CloseSession(session)
+		return nil, fmt.Errorf("failed to send hello: %w", err)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return session, nil
+}
+
+// TLSTransport carries NETCONF messages over a TLS connection, with
+// end-of-message framing until base:1.1 chunked framing is negotiated.
+type TLSTransport struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu      sync.Mutex // guards version and serializes writes
+	version string
+
+	maxChunk   int // largest chunk accepted in chunked framing
+	maxMessage int // largest message accepted
+}
+
+// Receive limits, so a peer cannot make the transport allocate without bound.
+const (
+	maxTLSChunkSize   = 16 << 20
+	maxTLSMessageSize = 64 << 20
+)
+
+func NewTLSTransport(conn net.Conn) *TLSTransport {
+	return &TLSTransport{
+		conn:       conn,
+		reader:     bufio.NewReader(conn),
+		version:    "v1.0",
+		maxChunk:   maxTLSChunkSize,
+		maxMessage: maxTLSMessageSize,
+	}
+}
+
+func (t *TLSTransport) SetVersion(version string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.version = version
+}
+
+func (t *TLSTransport) chunked() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.version == "v1.1"
+}
+
+func (t *TLSTransport) Send(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var frame bytes.Buffer
+	if t.version == "v1.1" {
+		fmt.Fprintf(&frame, "\n#%d\n", len(data))
+		frame.Write(data)
+		frame.WriteString("\n##\n")
+	} else {
+		frame.Write(data)
+		frame.WriteString("]]>]]>")
+	}
+
+	_, err := t.conn.Write(frame.Bytes())
+	return err
+}
+
+func (t *TLSTransport) Receive() ([]byte, error) {
+	if t.chunked() {
+		return t.receiveChunked()
+	}
+
+	var msg []byte
+	for {
+		line, err := t.reader.ReadSlice('>')
+		msg = append(msg, line...)
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, err
+		}
+		if len(msg) > t.maxMessage+len("]]>]]>") {
+			return nil, fmt.Errorf("message exceeds %d bytes", t.maxMessage)
+		}
+		if bytes.HasSuffix(msg, []byte("]]>]]>")) {
+			return msg[:len(msg)-len("]]>]]>")], nil
+		}
+	}
+}
+
+// receiveChunked decodes one message in RFC 6242 chunked framing.
+func (t *TLSTransport) receiveChunked() ([]byte, error) {
+	var msg []byte
+
+	for {
+		header, err := t.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if header == "\n" {
+			continue // the LF opening the chunk header
+		}
+		if len(header) < 3 || header[0] != '#' {
+			return nil, fmt.Errorf("%w: unexpected header %q", netconf.ErrBadChunk, header)
+		}
+		if header == "##\n" {
+			return msg, nil
+		}
+
+		size, err := strconv.Atoi(header[1 : len(header)-1])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("%w: invalid chunk size in %q", netconf.ErrBadChunk, header)
+		}
+		if size > t.maxChunk {
+			return nil, fmt.Errorf("%w: chunk of %d bytes exceeds %d", netconf.ErrBadChunk, size, t.maxChunk)
+		}
+		if len(msg)+size > t.maxMessage {
+			return nil, fmt.Errorf("%w: message exceeds %d bytes", netconf.ErrBadChunk, t.maxMessage)
+		}
+
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(t.reader, chunk); err != nil {
+			return nil, err
+		}
+		msg = append(msg, chunk...)
+	}
+}
+
+func (t *TLSTransport) Close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,305 @@
+package managementSessions
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"OpenCNC_config_service/common/structures/topology"
+)
+
+type testPKI struct {
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caPool *x509.CertPool
+	serial int64
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(der)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	return &testPKI{ca: ca, caKey: key, caPool: pool, serial: 1}
+}
+
+// issue returns a PEM certificate and key for name.
+func (p *testPKI) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(p.serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, p.ca, &key.PublicKey, p.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// serveTLSNetconf is a NETCONF over TLS stand-in: it requires a client
+// certificate, negotiates base:1.1 and answers every RPC with <ok/>.
+func serveTLSNetconf(t *testing.T, pki *testPKI) (port uint32) {
+	t.Helper()
+
+	certPEM, keyPEM := pki.issue(t, "bridge-1.test", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pki.caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTLSConn(conn)
+		}
+	}()
+
+	return uint32(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func serveTLSConn(conn net.Conn) {
+	defer conn.Close()
+
+	transport := NewTLSTransport(conn)
+
+	err := transport.Send([]byte(`<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>urn:ietf:params:netconf:base:1.0</capability>` +
+		`<capability>urn:ietf:params:netconf:base:1.1</capability></capabilities>` +
+		`<session-id>9</session-id></hello>`))
+	if err != nil {
+		return
+	}
+
+	if _, err := transport.Receive(); err != nil {
+		return
+	}
+	transport.SetVersion("v1.1")
+
+	for {
+		rpc, err := transport.Receive()
+		if err != nil {
+			return
+		}
+		m := messageID.FindSubmatch(rpc)
+		if m == nil {
+			continue
+		}
+		reply := fmt.Sprintf(`<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" message-id="%s"><ok/></rpc-reply>`, m[1])
+		if err := transport.Send([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestConnect_TLSWithClientCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	port := serveTLSNetconf(t, pki)
+
+	certPEM, keyPEM := pki.issue(t, "controller", x509.ExtKeyUsageClientAuth)
+
+	node := &topology.Node{
+		Name: "bridge-1",
+		ManagementInfo: &topology.ManagementInfo{
+			IpAddress:       "127.0.0.1",
+			ManagementPort:  port,
+			Transport:       topology.ManagementTransport_TLS,
+			TlsServerName:   "bridge-1.test",
+			KeyCertificates: []string{string(certPEM) + string(keyPEM)},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	creds, err := KeyCertificates{}.Credentials(ctx, node)
+	if err != nil {
+		t.Fatalf("credentials: %v", err)
+	}
+
+	session, err := Connect(ctx, node.ManagementInfo, creds, Verification{RootCAs: pki.caPool})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer CloseSession(session)
+
+	if session.SessionID != 9 {
+		t.Fatalf("expected session-id 9, got %d", session.SessionID)
+	}
+
+	// Replies arrive in chunked framing once base:1.1 is negotiated.
+	for i := 0; i < 2; i++ {
+		if err := Lock(ctx, session, "running"); err != nil {
+			t.Fatalf("lock %d: %v", i, err)
+		}
+	}
+}
+
+func TestConnect_TLSRejectsWrongServerName(t *testing.T) {
+	pki := newTestPKI(t)
+	port := serveTLSNetconf(t, pki)
+
+	certPEM, keyPEM := pki.issue(t, "controller", x509.ExtKeyUsageClientAuth)
+
+	info := &topology.ManagementInfo{
+		IpAddress:      "127.0.0.1",
+		ManagementPort: port,
+		Transport:      topology.ManagementTransport_TLS,
+		TlsServerName:  "bridge-2.test",
+	}
+	creds := Credentials{Certificates: [][]byte{certPEM}, PrivateKeys: [][]byte{keyPEM}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := Connect(ctx, info, creds, Verification{RootCAs: pki.caPool})
+
+	var hostnameErr x509.HostnameError
+	if !errors.As(err, &hostnameErr) {
+		t.Fatalf("expected a server name error, got %v", err)
+	}
+}
+
+func TestConnect_TLSRequiresClientCertificate(t *testing.T) {
+	info := &topology.ManagementInfo{
+		IpAddress: "127.0.0.1",
+		Transport: topology.ManagementTransport_TLS,
+	}
+
+	_, err := Connect(context.Background(), info, Credentials{Password: "secret"}, Verification{})
+	if err == nil || !strings.Contains(err.Error(), "no client certificate") {
+		t.Fatalf("expected missing client certificate error, got %v", err)
+	}
+}
+
+func TestTLSTransport_ReceiveLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "chunks within limits",
+			version: "v1.1",
+			input:   "\n#4\nabcd\n#2\nef\n##\n",
+			want:    "abcdef",
+		},
+		{
+			name:    "chunk over limit",
+			version: "v1.1",
+			input:   "\n#9\n123456789\n##\n",
+			wantErr: "chunk of 9 bytes exceeds 8",
+		},
+		{
+			name:    "chunked message over limit",
+			version: "v1.1",
+			input:   "\n#8\n12345678\n#8\n12345678\n##\n",
+			wantErr: "message exceeds 12 bytes",
+		},
+		{
+			name:    "end-of-message framed message over limit",
+			version: "v1.0",
+			input:   "<ok>0123456789</ok>]]>]]>",
+			wantErr: "message exceeds 12 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			t.Cleanup(func() {
+				client.Close()
+				server.Close()
+			})
+
+			go server.Write([]byte(tt.input))
+
+			transport := NewTLSTransport(client)
+			transport.SetVersion(tt.version)
+			transport.maxChunk = 8
+			transport.maxMessage = 12
+
+			got, err := transport.Receive()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Receive failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
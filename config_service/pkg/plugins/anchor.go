@@ -0,0 +1,105 @@
+package plugins
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// AnchorStep is one node on the path from the datastore root to the anchor of
+// a feature. Steps selecting a list entry name its key leaf and value; the
+// entry is created with them when missing.
+type AnchorStep struct {
+	Name      string
+	Namespace string // set where the namespace changes, always on the first step
+	Key       string // key leaf of a list entry, empty for containers
+	Value     string // key value; empty on an interface entry selects the target interface
+}
+
+const (
+	nsInterfaces = "urn:ietf:params:xml:ns:yang:ietf-interfaces"
+	nsBridge     = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge"
+)
+
+// BridgeComponentAnchor anchors features in a component of a bridge.
+func BridgeComponentAnchor(bridge, component string) []AnchorStep {
+	return []AnchorStep{
+		{Name: "bridges", Namespace: nsBridge},
+		{Name: "bridge", Key: "name", Value: bridge},
+		{Name: "component", Key: "name", Value: component},
+	}
+}
+
+// TopLevelAnchor anchors features whose root elements are top-level nodes of
+// the datastore. It is empty but not nil, unlike the anchor of features
+// placed by Container.
+func TopLevelAnchor() []AnchorStep {
+	return []AnchorStep{}
+}
+
+// InterfaceAnchor anchors features below an interface, the target interface
+// if name is empty, followed by the steps.
+func InterfaceAnchor(name string, steps ...AnchorStep) []AnchorStep {
+	return append([]AnchorStep{
+		{Name: "interfaces", Namespace: nsInterfaces},
+		{Name: "interface", Key: "name", Value: name},
+	}, steps...)
+}
+
+// ResolveAnchor returns the anchor with the target interface filled in.
+func ResolveAnchor(anchor []AnchorStep, interfaceName string) ([]AnchorStep, error) {
+	resolved := make([]AnchorStep, len(anchor))
+
+	for i, step := range anchor {
+		if step.Name == "" {
+			return nil, fmt.Errorf("anchor step %d has no name", i)
+		}
+
+		if step.Key != "" && step.Value == "" {
+			if step.Name != "interface" {
+				return nil, fmt.Errorf("anchor step %s has no %s", step.Name, step.Key)
+			}
+			if interfaceName == "" {
+				return nil, fmt.Errorf("feature is anchored in the target interface but there is none")
+			}
+			step.Value = interfaceName
+		}
+
+		resolved[i] = step
+	}
+
+	return resolved, nil
+}
+
+// Wrap returns the feature enclosed in its anchor, ready for edit-config.
+// Features without anchor are returned as is.
+func (f *FeatureXML) Wrap(interfaceName string) ([]byte, error) {
+	if len(f.Anchor) == 0 {
+		return f.XML, nil
+	}
+
+	anchor, err := ResolveAnchor(f.Anchor, interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	for _, step := range anchor {
+		if step.Namespace != "" {
+			buf.WriteString(fmt.Sprintf(`<%s xmlns="%s">`, step.Name, step.Namespace))
+		} else {
+			buf.WriteString(fmt.Sprintf(`<%s>`, step.Name))
+		}
+		if step.Key != "" {
+			buf.WriteString(fmt.Sprintf(`<%s>%s</%s>`, step.Key, step.Value, step.Key))
+		}
+	}
+
+	buf.Write(f.XML)
+
+	for i := len(anchor) - 1; i >= 0; i-- {
+		buf.WriteString(fmt.Sprintf(`</%s>`, anchor[i].Name))
+	}
+
+	return buf.Bytes(), nil
+}
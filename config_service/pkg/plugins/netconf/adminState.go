@@ -0,0 +1,164 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin       = (*AdminStateNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner = (*AdminStateNetconfPlugin)(nil)
+)
+
+// AdminStateNetconfPlugin sets the administrative state of a port, the
+// enabled leaf of its ietf-interfaces interface. The interface has no state
+// per direction: a port is disabled by its admin state, or by disabling both
+// ingress and egress.
+type AdminStateNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewAdminStateNetconfPlugin(logger observability.Logger) *AdminStateNetconfPlugin {
+	return &AdminStateNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewAdminStateNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *AdminStateNetconfPlugin) Name() string {
+	return "admin-state-netconf"
+}
+
+func (p *AdminStateNetconfPlugin) FeatureName() string {
+	return "admin-state"
+}
+
+func (p *AdminStateNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
+func (p *AdminStateNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		// The enabled leaf is in every revision.
+		{Name: "ietf-interfaces.yang"},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *AdminStateNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	if _, ok := msg.(*topology_config.PortConfig); !ok {
+		return nil
+	}
+
+	return []string{
+		"AdminState",
+		"IngressEnabled",
+		"EgressEnabled",
+	}
+}
+
+func (p *AdminStateNetconfPlugin) Map(msg proto.Message) (any, error) {
+	portCfg, ok := msg.(*topology_config.PortConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid message type for AdminStateNetconfPlugin: %T", msg)
+	}
+
+	enabled, err := portEnabled(portCfg)
+	if err != nil {
+		return nil, fmt.Errorf("AdminStateNetconfPlugin: port %s: %w", portCfg.GetPortId(), err)
+	}
+
+	p.logger.Printf("[AdminState] Port %s enabled: %t", portCfg.GetPortId(), enabled)
+
+	return &opencncModel.IETFInterfaces_Interfaces_Interface{
+		Name:    ygot.String(portCfg.GetPortId()),
+		Enabled: ygot.Bool(enabled),
+	}, nil
+}
+
+// portEnabled resolves the admin state and the directions of the port into
+// the enabled leaf. Unset values leave the port enabled.
+func portEnabled(portCfg *topology_config.PortConfig) (bool, error) {
+	if portCfg.IngressEnabled != nil && portCfg.EgressEnabled != nil &&
+		portCfg.GetIngressEnabled() != portCfg.GetEgressEnabled() {
+		return false, fmt.Errorf("ingress and egress cannot be enabled separately")
+	}
+	if (portCfg.IngressEnabled == nil) != (portCfg.EgressEnabled == nil) {
+		return false, fmt.Errorf("ingress and egress must be set together")
+	}
+
+	directions := portCfg.IngressEnabled == nil || portCfg.GetIngressEnabled()
+
+	switch portCfg.GetAdminState() {
+	case topology_config.AdminState_ADMIN_STATE_DISABLED:
+		return false, nil
+	case topology_config.AdminState_ADMIN_STATE_ENABLED:
+		if !directions {
+			return false, fmt.Errorf("admin state is enabled but ingress and egress are disabled")
+		}
+		return true, nil
+	default:
+		return directions, nil
+	}
+}
+
+func (p *AdminStateNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*opencncModel.IETFInterfaces_Interfaces_Interface)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for AdminStateNetconfPlugin: %T", mapped)
+	}
+
+	if root.Enabled == nil {
+		return nil, fmt.Errorf("AdminStateNetconfPlugin: enabled is not set")
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(fmt.Sprintf(`<enabled>%t</enabled>`, *root.Enabled))
+
+	return &plugins.FeatureXML{Container: "enabled", XML: buf.Bytes()}, nil
+}
+
+func (p *AdminStateNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushInterfaceFeature(featurexml, target)
+}
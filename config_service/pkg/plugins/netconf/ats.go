@@ -0,0 +1,294 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+
+	"OpenCNC_config_service/common/observability"
+	ats "OpenCNC_config_service/common/structures/ats"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*AtsNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*AtsNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*AtsNetconfPlugin)(nil)
+)
+
+const nsAts = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-ats"
+
+// AtsNetconfPlugin configures IEEE 802.1Qcr asynchronous traffic shaping: the
+// schedulers and scheduler groups of a bridge component. Streams are assigned
+// to their scheduler by the PSFP stream filters, see PsfpNetconfPlugin.
+type AtsNetconfPlugin struct {
+	logger observability.Logger
+}
+
+// bridgeAtsPayload is a bridge component holding ATS configuration only.
+type bridgeAtsPayload struct {
+	BridgeName string
+	Component  *opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component
+}
+
+type (
+	atsGroup     = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Ats_SchedulerGroupInstanceTable
+	atsScheduler = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Ats_SchedulerInstanceTable
+)
+
+func NewAtsNetconfPlugin(logger observability.Logger) *AtsNetconfPlugin {
+	return &AtsNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewAtsNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *AtsNetconfPlugin) Name() string {
+	return "ats-netconf"
+}
+
+func (p *AtsNetconfPlugin) FeatureName() string {
+	return "ats"
+}
+
+func (p *AtsNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeBridges}
+}
+
+func (p *AtsNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{
+			Name:     "ieee802-dot1q-bridge.yang",
+			Revision: "2018-03-07",
+		},
+		{
+			// Vendor revisions only, any is accepted.
+			Name: "ieee802-dot1q-ats.yang",
+		},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *AtsNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	switch msg.(type) {
+	case *topology_config.BridgeConfig:
+		return []string{"Ats"}
+	case *ats.AtsConfig:
+		return []string{"SchedulerGroups", "Schedulers"}
+	default:
+		return nil
+	}
+}
+
+// ValidateNode rejects stream filters of the node, on the bridge or on its
+// ports, referencing a scheduler the bridge does not configure.
+func (p *AtsNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	schedulers := make(map[uint32]struct{})
+	for _, scheduler := range cfg.GetBridge().GetAts().GetSchedulers() {
+		schedulers[scheduler.GetSchedulerId()] = struct{}{}
+	}
+
+	filters := cfg.GetBridge().GetPsfp().GetStreamFilters()
+	for _, portCfg := range cfg.GetPortConfigs() {
+		filters = append(filters, portCfg.GetPsfp().GetStreamFilters()...)
+	}
+
+	for _, filter := range filters {
+		if filter.AtsSchedulerId == nil {
+			continue
+		}
+		if _, ok := schedulers[filter.GetAtsSchedulerId()]; !ok {
+			return fmt.Errorf("stream filter %d references unknown ATS scheduler %d", filter.GetIndex(), filter.GetAtsSchedulerId())
+		}
+	}
+
+	return nil
+}
+
+func (p *AtsNetconfPlugin) Map(msg proto.Message) (any, error) {
+	var cfg *ats.AtsConfig
+
+	switch typed := msg.(type) {
+	case *ats.AtsConfig:
+		cfg = typed
+	case *topology_config.BridgeConfig:
+		cfg = typed.GetAts()
+	default:
+		return nil, fmt.Errorf("invalid message type for AtsNetconfPlugin: %T", msg)
+	}
+
+	if cfg == nil {
+		return nil, fmt.Errorf("AtsNetconfPlugin: %T has no ATS config", msg)
+	}
+
+	p.logger.Printf(
+		"[ATS] Mapping %d scheduler groups, %d schedulers",
+		len(cfg.GetSchedulerGroups()),
+		len(cfg.GetSchedulers()),
+	)
+
+	container := &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Ats{
+		SchedulerGroupInstanceTable: make(map[uint32]*atsGroup),
+		SchedulerInstanceTable:      make(map[uint32]*atsScheduler),
+	}
+
+	for _, group := range cfg.GetSchedulerGroups() {
+		if group == nil {
+			continue
+		}
+
+		id := group.GetGroupId()
+		if _, dup := container.SchedulerGroupInstanceTable[id]; dup {
+			return nil, fmt.Errorf("AtsNetconfPlugin: duplicate scheduler group instance id %d", id)
+		}
+
+		if group.GetMaxResidenceTimeNs() == 0 {
+			return nil, fmt.Errorf("AtsNetconfPlugin: scheduler group %d: max residence time is not set", id)
+		}
+		residence, err := toUint32(group.GetMaxResidenceTimeNs(), "max residence time")
+		if err != nil {
+			return nil, fmt.Errorf("AtsNetconfPlugin: scheduler group %d: %w", id, err)
+		}
+
+		container.SchedulerGroupInstanceTable[id] = &atsGroup{
+			SchedulerGroupInstanceId: ygot.Uint32(id),
+			MaxResidenceTime:         ygot.Uint32(residence),
+		}
+	}
+
+	for _, scheduler := range cfg.GetSchedulers() {
+		if scheduler == nil {
+			continue
+		}
+
+		id := scheduler.GetSchedulerId()
+		if _, dup := container.SchedulerInstanceTable[id]; dup {
+			return nil, fmt.Errorf("AtsNetconfPlugin: duplicate scheduler instance id %d", id)
+		}
+
+		mapped, err := mapAtsScheduler(scheduler, container.SchedulerGroupInstanceTable)
+		if err != nil {
+			return nil, fmt.Errorf("AtsNetconfPlugin: scheduler %d: %w", id, err)
+		}
+
+		container.SchedulerInstanceTable[id] = mapped
+	}
+
+	return &bridgeAtsPayload{
+		BridgeName: "br0",
+		Component: &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component{
+			Name: ygot.String("br0"),
+			Ats:  container,
+		},
+	}, nil
+}
+
+func mapAtsScheduler(scheduler *ats.AtsScheduler, groups map[uint32]*atsGroup) (*atsScheduler, error) {
+	if _, ok := groups[scheduler.GetGroupId()]; !ok {
+		return nil, fmt.Errorf("unknown scheduler group %d", scheduler.GetGroupId())
+	}
+
+	// An empty bucket or no tokens would never let a frame through.
+	if scheduler.GetCommittedInformationRate() == 0 {
+		return nil, fmt.Errorf("committed information rate is not set")
+	}
+	if scheduler.GetCommittedBurstSize() == 0 {
+		return nil, fmt.Errorf("committed burst size is not set")
+	}
+
+	return &atsScheduler{
+		SchedulerInstanceId:      ygot.Uint32(scheduler.GetSchedulerId()),
+		SchedulerGroupRef:        ygot.Uint32(scheduler.GetGroupId()),
+		CommittedInformationRate: ygot.Uint64(scheduler.GetCommittedInformationRate()),
+		CommittedBurstSize:       ygot.Uint32(scheduler.GetCommittedBurstSize()),
+	}, nil
+}
+
+func (p *AtsNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	payload, ok := mapped.(*bridgeAtsPayload)
+	if !ok || payload.Component == nil || payload.Component.Ats == nil {
+		return nil, fmt.Errorf("invalid mapped type for AtsNetconfPlugin: %T", mapped)
+	}
+
+	bridgeName := payload.BridgeName
+	if bridgeName == "" {
+		bridgeName = "br0"
+	}
+	componentName := bridgeName
+	if payload.Component.Name != nil {
+		componentName = *payload.Component.Name
+	}
+
+	container := payload.Component.Ats
+
+	var buf bytes.Buffer
+
+	// The feature is anchored in the component, ats is its root.
+	buf.WriteString(fmt.Sprintf(`<ats xmlns="%s">`, nsAts))
+
+	for _, id := range sortedKeys(container.SchedulerGroupInstanceTable) {
+		group := container.SchedulerGroupInstanceTable[id]
+		buf.WriteString(`<scheduler-group-instance-table>`)
+		buf.WriteString(fmt.Sprintf(`<scheduler-group-instance-id>%d</scheduler-group-instance-id>`, *group.SchedulerGroupInstanceId))
+		buf.WriteString(fmt.Sprintf(`<max-residence-time>%d</max-residence-time>`, *group.MaxResidenceTime))
+		buf.WriteString(`</scheduler-group-instance-table>`)
+	}
+
+	for _, id := range sortedKeys(container.SchedulerInstanceTable) {
+		scheduler := container.SchedulerInstanceTable[id]
+		buf.WriteString(`<scheduler-instance-table>`)
+		buf.WriteString(fmt.Sprintf(`<scheduler-instance-id>%d</scheduler-instance-id>`, *scheduler.SchedulerInstanceId))
+		buf.WriteString(fmt.Sprintf(`<scheduler-group-ref>%d</scheduler-group-ref>`, *scheduler.SchedulerGroupRef))
+		buf.WriteString(fmt.Sprintf(`<committed-information-rate>%d</committed-information-rate>`, *scheduler.CommittedInformationRate))
+		buf.WriteString(fmt.Sprintf(`<committed-burst-size>%d</committed-burst-size>`, *scheduler.CommittedBurstSize))
+		buf.WriteString(`</scheduler-instance-table>`)
+	}
+
+	buf.WriteString(`</ats>`)
+
+	return &plugins.FeatureXML{
+		Container: "ats",
+		XML:       buf.Bytes(),
+		Anchor:    plugins.BridgeComponentAnchor(bridgeName, componentName),
+	}, nil
+}
+
+func (p *AtsNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
@@ -0,0 +1,271 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	qav "OpenCNC_config_service/common/structures/qav"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*CbsNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*CbsNetconfPlugin)(nil)
+	_ plugins.PortValidator = (*CbsNetconfPlugin)(nil)
+)
+
+// CbsNetconfPlugin configures the IEEE 802.1Qav credit-based shaper of the
+// traffic classes of a port through ieee802-dot1q-cbs.
+type CbsNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewCbsNetconfPlugin(logger observability.Logger) *CbsNetconfPlugin {
+	return &CbsNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewCbsNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *CbsNetconfPlugin) Name() string {
+	return "cbs-netconf"
+}
+
+func (p *CbsNetconfPlugin) FeatureName() string {
+	return "cbs"
+}
+
+func (p *CbsNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
+func (p *CbsNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{
+			// Only draft revisions exist, any is accepted.
+			Name: "ieee802-dot1q-cbs.yang",
+		},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *CbsNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	if _, ok := msg.(*topology_config.PortConfig); !ok {
+		return nil
+	}
+
+	return []string{
+		"QueueConfigs",
+	}
+}
+
+func (p *CbsNetconfPlugin) ValidatePort(cfg *topology_config.PortConfig, port *topology.Port) error {
+	return validateCbsPort(cfg, port, p.logger)
+}
+
+func (p *CbsNetconfPlugin) Map(msg proto.Message) (any, error) {
+	portCfg, ok := msg.(*topology_config.PortConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid message type for CbsNetconfPlugin: %T", msg)
+	}
+
+	queues, err := cbsQueues(portCfg)
+	if err != nil {
+		return nil, fmt.Errorf("CbsNetconfPlugin: %w", err)
+	}
+
+	p.logger.Printf("[CBS] Mapping %d traffic classes", len(queues))
+
+	cbs := &opencncModel.IETFInterfaces_Interfaces_Interface_Cbs{
+		IdleSlopeTable: make(map[uint8]*opencncModel.IETFInterfaces_Interfaces_Interface_Cbs_IdleSlopeTable),
+	}
+
+	for tc, queue := range queues {
+		if queue.GetAdminState() != qav.AdminState_ENABLED {
+			// Unshaped traffic classes use strict priority.
+			continue
+		}
+
+		cbs.IdleSlopeTable[tc] = &opencncModel.IETFInterfaces_Interfaces_Interface_Cbs_IdleSlopeTable{
+			TrafficClass: ygot.Uint8(tc),
+			IdleSlope:    ygot.Uint64(queue.GetAdminIdleSlopeBps()),
+		}
+	}
+
+	return cbs, nil
+}
+
+func (p *CbsNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*opencncModel.IETFInterfaces_Interfaces_Interface_Cbs)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for CbsNetconfPlugin: %T", mapped)
+	}
+
+	var buf bytes.Buffer
+	nsCbs := "urn:ieee:std:802.1Q:yang:ieee802-dot1q-cbs"
+
+	buf.WriteString(fmt.Sprintf(`<cbs xmlns="%s">`, nsCbs))
+
+	for _, tc := range sortedTrafficClasses(root.IdleSlopeTable) {
+		entry := root.IdleSlopeTable[tc]
+		buf.WriteString(`<idle-slope-table>`)
+		buf.WriteString(fmt.Sprintf(`<traffic-class>%d</traffic-class>`, *entry.TrafficClass))
+		buf.WriteString(fmt.Sprintf(`<idle-slope>%d</idle-slope>`, *entry.IdleSlope))
+		buf.WriteString(`</idle-slope-table>`)
+	}
+
+	buf.WriteString(`</cbs>`)
+
+	return &plugins.FeatureXML{Container: "cbs", XML: buf.Bytes()}, nil
+}
+
+func (p *CbsNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushInterfaceFeature(featurexml, target)
+}
+
+// cbsQueues collects the credit-based shaper parameters of the port's queues
+// by traffic class.
+func cbsQueues(portCfg *topology_config.PortConfig) (map[uint8]*qav.CbsQueueConfig, error) {
+	queues := make(map[uint8]*qav.CbsQueueConfig)
+
+	for _, queueCfg := range portCfg.GetQueueConfigs() {
+		if queueCfg == nil || len(queueCfg.GetCbs()) == 0 {
+			continue
+		}
+
+		if queueCfg.Scheduling != nil &&
+			queueCfg.GetScheduling() != topology_config.SchedulingModel_SCHEDULING_UNSPECIFIED &&
+			queueCfg.GetScheduling() != topology_config.SchedulingModel_CREDIT_BASED_SHAPER {
+			return nil, fmt.Errorf(
+				"queue %d has CBS parameters but uses %s scheduling",
+				queueCfg.GetQueueId(),
+				queueCfg.GetScheduling(),
+			)
+		}
+
+		for _, cbs := range queueCfg.GetCbs() {
+			if cbs == nil {
+				continue
+			}
+
+			if cbs.GetEgressQueueId() > 7 {
+				return nil, fmt.Errorf("traffic class %d out of range 0-7", cbs.GetEgressQueueId())
+			}
+
+			tc := uint8(cbs.GetEgressQueueId())
+			if _, dup := queues[tc]; dup {
+				return nil, fmt.Errorf("traffic class %d is shaped twice", tc)
+			}
+
+			queues[tc] = cbs
+		}
+	}
+
+	return queues, nil
+}
+
+// validateCbsPort rejects idle slopes that reserve more than the port speed.
+// Ports of unknown speed are not checked.
+func validateCbsPort(cfg *topology_config.PortConfig, port *topology.Port, logger observability.Logger) error {
+	queues, err := cbsQueues(cfg)
+	if err != nil || len(queues) == 0 {
+		return err
+	}
+
+	speedMbps := port.GetCapabilities().GetPortSpeed()
+	if speedMbps <= 0 {
+		logger.Printf("[CBS] Port %s has no known speed, idle slopes not checked", cfg.GetPortId())
+		return nil
+	}
+
+	var reserved uint64
+	for _, queue := range queues {
+		if queue.GetAdminState() == qav.AdminState_ENABLED {
+			reserved += queue.GetAdminIdleSlopeBps()
+		}
+	}
+
+	if speed := uint64(speedMbps) * 1_000_000; reserved > speed {
+		return fmt.Errorf(
+			"idle slopes reserve %d bit/s, more than the port speed of %d Mbit/s",
+			reserved,
+			speedMbps,
+		)
+	}
+
+	return nil
+}
+
+func sortedTrafficClasses[V any](m map[uint8]V) []uint8 {
+	tcs := make([]uint8, 0, len(m))
+	for tc := uint8(0); tc <= 7; tc++ {
+		if _, ok := m[tc]; ok {
+			tcs = append(tcs, tc)
+		}
+	}
+	return tcs
+}
+
+// pushInterfaceFeature edits the feature into the target interface.
+func pushInterfaceFeature(featurexml *plugins.FeatureXML, target managementSessions.DeviceTarget) error {
+	var buf bytes.Buffer
+
+	nsIf := "urn:ietf:params:xml:ns:yang:ietf-interfaces"
+
+	buf.WriteString(fmt.Sprintf(`<interfaces xmlns="%s">`, nsIf))
+	buf.WriteString(`<interface>`)
+	buf.WriteString(fmt.Sprintf(`<name>%s</name>`, target.InterfaceName))
+	buf.WriteString(string(featurexml.XML))
+	buf.WriteString(`</interface>`)
+	buf.WriteString(`</interfaces>`)
+
+	session, err := target.Connect()
+	if err != nil {
+		return fmt.Errorf("NETCONF session failed: %w", err)
+	}
+	defer managementSessions.CloseSession(session)
+
+	if err := managementSessions.EditConfig(session, buf.String()); err != nil {
+		return fmt.Errorf("edit-config failed: %w", err)
+	}
+
+	return nil
+}
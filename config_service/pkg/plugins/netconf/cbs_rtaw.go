@@ -0,0 +1,192 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	qav "OpenCNC_config_service/common/structures/qav"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*RtawCbsNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*RtawCbsNetconfPlugin)(nil)
+	_ plugins.PortValidator = (*RtawCbsNetconfPlugin)(nil)
+)
+
+// RtawCbsNetconfPlugin configures the credit-based shaper of the RELY-TSN
+// bridges through their vendor module rtaw-cbs, which also takes the send
+// slope and the credit bounds.
+type RtawCbsNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewCbsNetconfPlugin_rtaw(logger observability.Logger) *RtawCbsNetconfPlugin {
+	return &RtawCbsNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewCbsNetconfPlugin_rtaw(logger)
+		},
+	})
+}
+
+func (p *RtawCbsNetconfPlugin) Name() string {
+	return "cbs-netconf"
+}
+
+func (p *RtawCbsNetconfPlugin) FeatureName() string {
+	return "cbs"
+}
+
+func (p *RtawCbsNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
+func (p *RtawCbsNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{Name: "rtaw-cbs.yang", Revision: "2021-10-12"},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && yf.Revision == req.Revision {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *RtawCbsNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	if _, ok := msg.(*topology_config.PortConfig); !ok {
+		return nil
+	}
+
+	return []string{
+		"QueueConfigs",
+	}
+}
+
+func (p *RtawCbsNetconfPlugin) ValidatePort(cfg *topology_config.PortConfig, port *topology.Port) error {
+	return validateCbsPort(cfg, port, p.logger)
+}
+
+func (p *RtawCbsNetconfPlugin) Map(msg proto.Message) (any, error) {
+	portCfg, ok := msg.(*topology_config.PortConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid message type for RtawCbsNetconfPlugin: %T", msg)
+	}
+
+	queues, err := cbsQueues(portCfg)
+	if err != nil {
+		return nil, fmt.Errorf("RtawCbsNetconfPlugin: %w", err)
+	}
+
+	p.logger.Printf("[CBS] Mapping %d traffic classes", len(queues))
+
+	shaper := &opencncModel.IETFInterfaces_Interfaces_Interface_CreditBasedShaper{
+		Queue: make(map[uint8]*opencncModel.IETFInterfaces_Interfaces_Interface_CreditBasedShaper_Queue),
+	}
+
+	for tc, queue := range queues {
+		entry := &opencncModel.IETFInterfaces_Interfaces_Interface_CreditBasedShaper_Queue{
+			TrafficClass: ygot.Uint8(tc),
+			Enabled:      ygot.Bool(queue.GetAdminState() == qav.AdminState_ENABLED),
+			IdleSlope:    ygot.Uint64(queue.GetAdminIdleSlopeBps()),
+		}
+
+		// Slopes and credits are carried as magnitudes; the module takes
+		// the send slope and the low credit as negative values.
+		if v := queue.GetSendSlopeBps(); v != 0 {
+			if entry.SendSlope, err = negativeInt64(v, "send slope"); err != nil {
+				return nil, fmt.Errorf("RtawCbsNetconfPlugin: traffic class %d: %w", tc, err)
+			}
+		}
+		if v := queue.GetHiCreditBytes(); v != 0 {
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("RtawCbsNetconfPlugin: traffic class %d: hi credit %d out of range", tc, v)
+			}
+			entry.HiCredit = ygot.Int64(int64(v))
+		}
+		if v := queue.GetLoCreditBytes(); v != 0 {
+			if entry.LoCredit, err = negativeInt64(v, "lo credit"); err != nil {
+				return nil, fmt.Errorf("RtawCbsNetconfPlugin: traffic class %d: %w", tc, err)
+			}
+		}
+
+		shaper.Queue[tc] = entry
+	}
+
+	return shaper, nil
+}
+
+func negativeInt64(v uint64, what string) (*int64, error) {
+	if v > math.MaxInt64 {
+		return nil, fmt.Errorf("%s %d out of range", what, v)
+	}
+	return ygot.Int64(-int64(v)), nil
+}
+
+func (p *RtawCbsNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*opencncModel.IETFInterfaces_Interfaces_Interface_CreditBasedShaper)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for RtawCbsNetconfPlugin: %T", mapped)
+	}
+
+	var buf bytes.Buffer
+	nsRtawCbs := "urn:rtaw:yang:rtaw-cbs"
+
+	buf.WriteString(fmt.Sprintf(`<credit-based-shaper xmlns="%s">`, nsRtawCbs))
+
+	for _, tc := range sortedTrafficClasses(root.Queue) {
+		entry := root.Queue[tc]
+		buf.WriteString(`<queue>`)
+		buf.WriteString(fmt.Sprintf(`<traffic-class>%d</traffic-class>`, *entry.TrafficClass))
+		buf.WriteString(fmt.Sprintf(`<enabled>%t</enabled>`, *entry.Enabled))
+		buf.WriteString(fmt.Sprintf(`<idle-slope>%d</idle-slope>`, *entry.IdleSlope))
+		if entry.SendSlope != nil {
+			buf.WriteString(fmt.Sprintf(`<send-slope>%d</send-slope>`, *entry.SendSlope))
+		}
+		if entry.HiCredit != nil {
+			buf.WriteString(fmt.Sprintf(`<hi-credit>%d</hi-credit>`, *entry.HiCredit))
+		}
+		if entry.LoCredit != nil {
+			buf.WriteString(fmt.Sprintf(`<lo-credit>%d</lo-credit>`, *entry.LoCredit))
+		}
+		buf.WriteString(`</queue>`)
+	}
+
+	buf.WriteString(`</credit-based-shaper>`)
+
+	return &plugins.FeatureXML{Container: "credit-based-shaper", XML: buf.Bytes()}, nil
+}
+
+func (p *RtawCbsNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushInterfaceFeature(featurexml, target)
+}
@@ -0,0 +1,532 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	frer "OpenCNC_config_service/common/structures/frer"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin       = (*FrerNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner = (*FrerNetconfPlugin)(nil)
+)
+
+const (
+	nsFrer = "urn:ieee:std:802.1Q:yang:ieee802-dot1cb-frer"
+	nsSid  = "urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification"
+)
+
+// FrerNetconfPlugin configures IEEE 802.1CB frame replication and elimination:
+// the stream identification of a bridge and the sequence generation, recovery
+// and identification functions applied to the identified streams.
+type FrerNetconfPlugin struct {
+	logger observability.Logger
+}
+
+type (
+	frerStreamIdentity = opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdentity
+	frerGeneration     = opencncModel.Ieee802Dot1CbFrer_Frer_SequenceGeneration
+	frerRecovery       = opencncModel.Ieee802Dot1CbFrer_Frer_SequenceRecovery
+	frerIdentification = opencncModel.Ieee802Dot1CbFrer_Frer_SequenceIdentification
+	frerIdentKey       = opencncModel.Ieee802Dot1CbFrer_Frer_SequenceIdentification_Key
+)
+
+func NewFrerNetconfPlugin(logger observability.Logger) *FrerNetconfPlugin {
+	return &FrerNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewFrerNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *FrerNetconfPlugin) Name() string {
+	return "frer-netconf"
+}
+
+func (p *FrerNetconfPlugin) FeatureName() string {
+	return "frer"
+}
+
+func (p *FrerNetconfPlugin) OwnedSubtrees() []string {
+	return []string{
+		fmt.Sprintf(`<stream-identity xmlns="%s"/>`, nsSid),
+		fmt.Sprintf(`<frer xmlns="%s"/>`, nsFrer),
+	}
+}
+
+func (p *FrerNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{
+			Name:     "ieee802-dot1cb-stream-identification.yang",
+			Revision: "2021-05-06",
+		},
+		{
+			Name:     "ieee802-dot1cb-frer.yang",
+			Revision: "2021-05-06",
+		},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && yf.Revision == req.Revision {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *FrerNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	switch msg.(type) {
+	case *topology_config.BridgeConfig:
+		return []string{"Frer"}
+	case *frer.FrerBridgeConfig:
+		return []string{"StreamIdentities", "SequenceGenerations", "SequenceRecoveries", "SequenceIdentifications"}
+	default:
+		return nil
+	}
+}
+
+// Map maps the FRER configuration to a device root holding only the stream
+// identity list and the frer container.
+func (p *FrerNetconfPlugin) Map(msg proto.Message) (any, error) {
+	var cfg *frer.FrerBridgeConfig
+
+	switch typed := msg.(type) {
+	case *frer.FrerBridgeConfig:
+		cfg = typed
+	case *topology_config.BridgeConfig:
+		cfg = typed.GetFrer()
+	default:
+		return nil, fmt.Errorf("invalid message type for FrerNetconfPlugin: %T", msg)
+	}
+
+	if cfg == nil {
+		return nil, fmt.Errorf("FrerNetconfPlugin: %T has no FRER config", msg)
+	}
+
+	p.logger.Printf(
+		"[FRER] Mapping %d stream identities, %d generation, %d recovery, %d identification functions",
+		len(cfg.GetStreamIdentities()),
+		len(cfg.GetSequenceGenerations()),
+		len(cfg.GetSequenceRecoveries()),
+		len(cfg.GetSequenceIdentifications()),
+	)
+
+	device := &opencncModel.Device{
+		StreamIdentity: make(map[uint32]*frerStreamIdentity),
+	}
+
+	// The sequence functions may only reference streams identified here.
+	handles := make(map[uint32]struct{})
+
+	for _, identity := range cfg.GetStreamIdentities() {
+		if identity == nil {
+			continue
+		}
+
+		idx := identity.GetIndex()
+		if _, dup := device.StreamIdentity[idx]; dup {
+			return nil, fmt.Errorf("FrerNetconfPlugin: duplicate stream identity index %d", idx)
+		}
+
+		mapped, err := mapStreamIdentity(identity)
+		if err != nil {
+			return nil, fmt.Errorf("FrerNetconfPlugin: stream identity %d: %w", idx, err)
+		}
+
+		device.StreamIdentity[idx] = mapped
+		handles[*mapped.Handle] = struct{}{}
+	}
+
+	checkHandles := func(what string, streams []uint32) error {
+		if len(streams) == 0 {
+			return fmt.Errorf("%s references no stream", what)
+		}
+		for _, h := range streams {
+			if _, ok := handles[h]; !ok {
+				return fmt.Errorf("%s references stream handle %d that is not identified", what, h)
+			}
+		}
+		return nil
+	}
+
+	root := &opencncModel.Ieee802Dot1CbFrer_Frer{
+		SequenceGeneration:     make(map[uint32]*frerGeneration),
+		SequenceRecovery:       make(map[uint32]*frerRecovery),
+		SequenceIdentification: make(map[frerIdentKey]*frerIdentification),
+	}
+
+	for _, gen := range cfg.GetSequenceGenerations() {
+		if gen == nil {
+			continue
+		}
+
+		idx := gen.GetIndex()
+		if _, dup := root.SequenceGeneration[idx]; dup {
+			return nil, fmt.Errorf("FrerNetconfPlugin: duplicate sequence generation index %d", idx)
+		}
+
+		if err := checkHandles(fmt.Sprintf("sequence generation %d", idx), gen.GetStreamHandles()); err != nil {
+			return nil, fmt.Errorf("FrerNetconfPlugin: %w", err)
+		}
+
+		root.SequenceGeneration[idx] = &frerGeneration{
+			Index:              ygot.Uint32(idx),
+			Stream:             gen.GetStreamHandles(),
+			DirectionOutFacing: ygot.Bool(gen.GetDirectionOutFacing()),
+		}
+	}
+
+	for _, rcvy := range cfg.GetSequenceRecoveries() {
+		if rcvy == nil {
+			continue
+		}
+
+		idx := rcvy.GetIndex()
+		if _, dup := root.SequenceRecovery[idx]; dup {
+			return nil, fmt.Errorf("FrerNetconfPlugin: duplicate sequence recovery index %d", idx)
+		}
+
+		if err := checkHandles(fmt.Sprintf("sequence recovery %d", idx), rcvy.GetStreamHandles()); err != nil {
+			return nil, fmt.Errorf("FrerNetconfPlugin: %w", err)
+		}
+
+		mapped, err := mapSequenceRecovery(rcvy)
+		if err != nil {
+			return nil, fmt.Errorf("FrerNetconfPlugin: sequence recovery %d: %w", idx, err)
+		}
+
+		root.SequenceRecovery[idx] = mapped
+	}
+
+	for _, ident := range cfg.GetSequenceIdentifications() {
+		if ident == nil {
+			continue
+		}
+
+		if ident.GetPortId() == "" {
+			return nil, fmt.Errorf("FrerNetconfPlugin: sequence identification without port")
+		}
+
+		key := frerIdentKey{Port: ident.GetPortId(), DirectionOutFacing: ident.GetDirectionOutFacing()}
+		if _, dup := root.SequenceIdentification[key]; dup {
+			return nil, fmt.Errorf(
+				"FrerNetconfPlugin: duplicate sequence identification on port %s (out-facing %t)",
+				key.Port,
+				key.DirectionOutFacing,
+			)
+		}
+
+		what := fmt.Sprintf("sequence identification on port %s", key.Port)
+		if err := checkHandles(what, ident.GetStreamHandles()); err != nil {
+			return nil, fmt.Errorf("FrerNetconfPlugin: %w", err)
+		}
+
+		mapped := &frerIdentification{
+			Port:               ygot.String(key.Port),
+			DirectionOutFacing: ygot.Bool(key.DirectionOutFacing),
+			Stream:             ident.GetStreamHandles(),
+			Active:             ygot.Bool(ident.GetActive()),
+		}
+
+		switch ident.GetEncapsulation() {
+		case frer.SequenceEncapsulation_SEQUENCE_ENCAPSULATION_UNSPECIFIED, frer.SequenceEncapsulation_R_TAG:
+			mapped.Encapsulation = opencncModel.Ieee802Dot1CbFrer_SequenceEncapsulation_r_tag
+		case frer.SequenceEncapsulation_HSR_SEQUENCE_TAG:
+			mapped.Encapsulation = opencncModel.Ieee802Dot1CbFrer_SequenceEncapsulation_hsr_sequence_tag
+		case frer.SequenceEncapsulation_PRP_SEQUENCE_TRAILER:
+			mapped.Encapsulation = opencncModel.Ieee802Dot1CbFrer_SequenceEncapsulation_prp_sequence_trailer
+		default:
+			return nil, fmt.Errorf("FrerNetconfPlugin: %s: unknown encapsulation %s", what, ident.GetEncapsulation())
+		}
+
+		root.SequenceIdentification[key] = mapped
+	}
+
+	device.Frer = root
+
+	return device, nil
+}
+
+func mapStreamIdentity(identity *frer.StreamIdentity) (*frerStreamIdentity, error) {
+	if identity.GetStream() == nil {
+		return nil, fmt.Errorf("no stream handle")
+	}
+
+	mapped := &frerStreamIdentity{
+		Index:  ygot.Uint32(identity.GetIndex()),
+		Handle: ygot.Uint32(identity.GetStream().GetHandle()),
+	}
+
+	mac, err := net.ParseMAC(identity.GetMacAddress())
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q", identity.GetMacAddress())
+	}
+	// ieee:mac-address is written as six dash-separated upper-case octets.
+	macAddress := strings.ToUpper(strings.ReplaceAll(mac.String(), ":", "-"))
+
+	if identity.GetVlanId() > 4094 {
+		return nil, fmt.Errorf("VLAN %d out of range 0-4094", identity.GetVlanId())
+	}
+	vlan := ygot.Uint16(uint16(identity.GetVlanId()))
+
+	var tagged opencncModel.E_Ieee802Dot1CbStreamIdentification_StreamIdTagged
+	switch identity.GetTagging() {
+	case frer.VlanTagging_VLAN_TAGGING_UNSPECIFIED, frer.VlanTagging_TAGGED:
+		tagged = opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdTagged_tagged
+	case frer.VlanTagging_PRIORITY:
+		tagged = opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdTagged_priority
+	case frer.VlanTagging_ALL:
+		tagged = opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdTagged_all
+	default:
+		return nil, fmt.Errorf("unknown VLAN tagging %s", identity.GetTagging())
+	}
+
+	switch identity.GetType() {
+	case frer.StreamIdentificationType_NULL_STREAM:
+		mapped.NullStreamIdentification = &opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdentity_NullStreamIdentification{
+			DestinationMac: ygot.String(macAddress),
+			Tagged:         tagged,
+			Vlan:           vlan,
+		}
+	case frer.StreamIdentificationType_SOURCE_MAC_VLAN:
+		mapped.SmacVlanStreamIdentification = &opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdentity_SmacVlanStreamIdentification{
+			SourceMac: ygot.String(macAddress),
+			Tagged:    tagged,
+			Vlan:      vlan,
+		}
+	default:
+		return nil, fmt.Errorf("stream identification type is %s", identity.GetType())
+	}
+
+	if len(identity.GetInFacingInputPorts()) > 0 || len(identity.GetInFacingOutputPorts()) > 0 {
+		mapped.InFacing = &opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdentity_InFacing{
+			InputPort:  identity.GetInFacingInputPorts(),
+			OutputPort: identity.GetInFacingOutputPorts(),
+		}
+	}
+
+	if len(identity.GetOutFacingInputPorts()) > 0 || len(identity.GetOutFacingOutputPorts()) > 0 {
+		mapped.OutFacing = &opencncModel.Ieee802Dot1CbStreamIdentification_StreamIdentity_OutFacing{
+			InputPort:  identity.GetOutFacingInputPorts(),
+			OutputPort: identity.GetOutFacingOutputPorts(),
+		}
+	}
+
+	return mapped, nil
+}
+
+func mapSequenceRecovery(rcvy *frer.SequenceRecovery) (*frerRecovery, error) {
+	mapped := &frerRecovery{
+		Index:                ygot.Uint32(rcvy.GetIndex()),
+		Stream:               rcvy.GetStreamHandles(),
+		Port:                 rcvy.GetPortIds(),
+		DirectionOutFacing:   ygot.Bool(rcvy.GetDirectionOutFacing()),
+		TakeNoSequence:       ygot.Bool(rcvy.GetTakeNoSequence()),
+		IndividualRecovery:   ygot.Bool(rcvy.GetIndividualRecovery()),
+		LatentErrorDetection: ygot.Bool(rcvy.GetLatentErrorDetection()),
+	}
+
+	switch rcvy.GetAlgorithm() {
+	case frer.RecoveryAlgorithm_RECOVERY_ALGORITHM_UNSPECIFIED, frer.RecoveryAlgorithm_VECTOR:
+		mapped.Algorithm = opencncModel.Ieee802Dot1CbFrer_SequenceRecoveryAlgorithm_vector
+	case frer.RecoveryAlgorithm_MATCH:
+		mapped.Algorithm = opencncModel.Ieee802Dot1CbFrer_SequenceRecoveryAlgorithm_match
+	default:
+		return nil, fmt.Errorf("unknown recovery algorithm %s", rcvy.GetAlgorithm())
+	}
+
+	if params := rcvy.GetParameters(); params != nil {
+		if n := params.GetSequenceHistoryLength(); n > 0 {
+			if mapped.Algorithm == opencncModel.Ieee802Dot1CbFrer_SequenceRecoveryAlgorithm_match {
+				return nil, fmt.Errorf("history length %d set for the match algorithm", n)
+			}
+			mapped.HistoryLength = ygot.Uint32(n)
+		}
+
+		if ns := params.GetHoldOffTimeNs(); ns > 0 {
+			// The reset timeout is configured in milliseconds.
+			ms, err := toUint32((ns+999_999)/1_000_000, "reset timeout")
+			if err != nil {
+				return nil, err
+			}
+			mapped.ResetTimeout = ygot.Uint32(ms)
+		}
+	}
+
+	return mapped, nil
+}
+
+func (p *FrerNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	device, ok := mapped.(*opencncModel.Device)
+	if !ok || device.Frer == nil {
+		return nil, fmt.Errorf("invalid mapped type for FrerNetconfPlugin: %T", mapped)
+	}
+
+	var buf bytes.Buffer
+
+	// The stream identity list and the frer container are both top-level
+	// nodes, the feature therefore has several roots.
+	for _, idx := range sortedKeys(device.StreamIdentity) {
+		writeStreamIdentityXML(&buf, device.StreamIdentity[idx])
+	}
+
+	root := device.Frer
+
+	buf.WriteString(fmt.Sprintf(`<frer xmlns="%s">`, nsFrer))
+
+	for _, idx := range sortedKeys(root.SequenceGeneration) {
+		gen := root.SequenceGeneration[idx]
+		buf.WriteString(`<sequence-generation>`)
+		buf.WriteString(fmt.Sprintf(`<index>%d</index>`, *gen.Index))
+		for _, h := range gen.Stream {
+			buf.WriteString(fmt.Sprintf(`<stream>%d</stream>`, h))
+		}
+		buf.WriteString(fmt.Sprintf(`<direction-out-facing>%t</direction-out-facing>`, *gen.DirectionOutFacing))
+		buf.WriteString(`</sequence-generation>`)
+	}
+
+	for _, idx := range sortedKeys(root.SequenceRecovery) {
+		writeSequenceRecoveryXML(&buf, root.SequenceRecovery[idx])
+	}
+
+	keys := make([]frerIdentKey, 0, len(root.SequenceIdentification))
+	for key := range root.SequenceIdentification {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Port != keys[j].Port {
+			return keys[i].Port < keys[j].Port
+		}
+		return !keys[i].DirectionOutFacing && keys[j].DirectionOutFacing
+	})
+
+	for _, key := range keys {
+		ident := root.SequenceIdentification[key]
+		buf.WriteString(`<sequence-identification>`)
+		buf.WriteString(fmt.Sprintf(`<port>%s</port>`, *ident.Port))
+		buf.WriteString(fmt.Sprintf(`<direction-out-facing>%t</direction-out-facing>`, *ident.DirectionOutFacing))
+		for _, h := range ident.Stream {
+			buf.WriteString(fmt.Sprintf(`<stream>%d</stream>`, h))
+		}
+		buf.WriteString(fmt.Sprintf(`<active>%t</active>`, *ident.Active))
+		buf.WriteString(fmt.Sprintf(
+			`<encapsulation xmlns:dot1cb-frer="%s">dot1cb-frer:%s</encapsulation>`,
+			nsFrer,
+			ident.Encapsulation.String(),
+		))
+		buf.WriteString(`</sequence-identification>`)
+	}
+
+	buf.WriteString(`</frer>`)
+
+	return &plugins.FeatureXML{Container: "frer", XML: buf.Bytes(), Anchor: plugins.TopLevelAnchor()}, nil
+}
+
+func writeStreamIdentityXML(buf *bytes.Buffer, identity *frerStreamIdentity) {
+	buf.WriteString(fmt.Sprintf(`<stream-identity xmlns="%s">`, nsSid))
+	buf.WriteString(fmt.Sprintf(`<index>%d</index>`, *identity.Index))
+	buf.WriteString(fmt.Sprintf(`<handle>%d</handle>`, *identity.Handle))
+
+	writePorts := func(container string, inputs, outputs []string) {
+		buf.WriteString(fmt.Sprintf(`<%s>`, container))
+		for _, port := range inputs {
+			buf.WriteString(fmt.Sprintf(`<input-port>%s</input-port>`, port))
+		}
+		for _, port := range outputs {
+			buf.WriteString(fmt.Sprintf(`<output-port>%s</output-port>`, port))
+		}
+		buf.WriteString(fmt.Sprintf(`</%s>`, container))
+	}
+
+	if identity.InFacing != nil {
+		writePorts("in-facing", identity.InFacing.InputPort, identity.InFacing.OutputPort)
+	}
+	if identity.OutFacing != nil {
+		writePorts("out-facing", identity.OutFacing.InputPort, identity.OutFacing.OutputPort)
+	}
+
+	if null := identity.NullStreamIdentification; null != nil {
+		buf.WriteString(`<null-stream-identification>`)
+		buf.WriteString(fmt.Sprintf(`<destination-mac>%s</destination-mac>`, *null.DestinationMac))
+		buf.WriteString(fmt.Sprintf(`<tagged>%s</tagged>`, null.Tagged.String()))
+		buf.WriteString(fmt.Sprintf(`<vlan>%d</vlan>`, *null.Vlan))
+		buf.WriteString(`</null-stream-identification>`)
+	}
+
+	if smac := identity.SmacVlanStreamIdentification; smac != nil {
+		buf.WriteString(`<smac-vlan-stream-identification>`)
+		buf.WriteString(fmt.Sprintf(`<source-mac>%s</source-mac>`, *smac.SourceMac))
+		buf.WriteString(fmt.Sprintf(`<tagged>%s</tagged>`, smac.Tagged.String()))
+		buf.WriteString(fmt.Sprintf(`<vlan>%d</vlan>`, *smac.Vlan))
+		buf.WriteString(`</smac-vlan-stream-identification>`)
+	}
+
+	buf.WriteString(`</stream-identity>`)
+}
+
+func writeSequenceRecoveryXML(buf *bytes.Buffer, rcvy *frerRecovery) {
+	buf.WriteString(`<sequence-recovery>`)
+	buf.WriteString(fmt.Sprintf(`<index>%d</index>`, *rcvy.Index))
+	for _, h := range rcvy.Stream {
+		buf.WriteString(fmt.Sprintf(`<stream>%d</stream>`, h))
+	}
+	for _, port := range rcvy.Port {
+		buf.WriteString(fmt.Sprintf(`<port>%s</port>`, port))
+	}
+	buf.WriteString(fmt.Sprintf(`<direction-out-facing>%t</direction-out-facing>`, *rcvy.DirectionOutFacing))
+	buf.WriteString(fmt.Sprintf(
+		`<algorithm xmlns:dot1cb-frer="%s">dot1cb-frer:%s</algorithm>`,
+		nsFrer,
+		rcvy.Algorithm.String(),
+	))
+	if rcvy.HistoryLength != nil {
+		buf.WriteString(fmt.Sprintf(`<history-length>%d</history-length>`, *rcvy.HistoryLength))
+	}
+	if rcvy.ResetTimeout != nil {
+		buf.WriteString(fmt.Sprintf(`<reset-timeout>%d</reset-timeout>`, *rcvy.ResetTimeout))
+	}
+	buf.WriteString(fmt.Sprintf(`<take-no-sequence>%t</take-no-sequence>`, *rcvy.TakeNoSequence))
+	buf.WriteString(fmt.Sprintf(`<individual-recovery>%t</individual-recovery>`, *rcvy.IndividualRecovery))
+	buf.WriteString(fmt.Sprintf(`<latent-error-detection>%t</latent-error-detection>`, *rcvy.LatentErrorDetection))
+	buf.WriteString(`</sequence-recovery>`)
+}
+
+func (p *FrerNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
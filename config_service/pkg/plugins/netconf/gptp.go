@@ -0,0 +1,376 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*GptpNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*GptpNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*GptpNetconfPlugin)(nil)
+)
+
+const nsPtp = "urn:ieee:std:1588:yang:ieee1588-ptp"
+
+// gptpInstanceIndex is the PTP instance carrying gPTP domain 0.
+const gptpInstanceIndex = 0
+
+type ptpInstance = opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance
+type ptpPort = opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_Ports_Port
+
+// GptpNetconfPlugin configures the IEEE 802.1AS time synchronization of a
+// node through the instance and port datasets of ieee1588-ptp. The node role
+// configuration enables the instance; the port roles, when given, set the
+// port states externally instead of the BTCA.
+type GptpNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewGptpNetconfPlugin(logger observability.Logger) *GptpNetconfPlugin {
+	return &GptpNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewGptpNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *GptpNetconfPlugin) Name() string {
+	return "gptp-netconf"
+}
+
+func (p *GptpNetconfPlugin) FeatureName() string {
+	return "gptp"
+}
+
+func (p *GptpNetconfPlugin) OwnedSubtrees() []string {
+	return []string{fmt.Sprintf(`<ptp xmlns="%s"/>`, nsPtp)}
+}
+
+func (p *GptpNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		// Any revision, the datasets written here are common to all.
+		{Name: "ieee1588-ptp.yang"},
+		{Name: "ieee802-dot1as-gptp.yang"},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *GptpNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	return gptpSupportedFields(msg)
+}
+
+func (p *GptpNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	return validateGptpNode(cfg)
+}
+
+func (p *GptpNetconfPlugin) Map(msg proto.Message) (any, error) {
+	instance, err := mapGptp(msg, p.logger)
+	if err != nil {
+		return nil, fmt.Errorf("GptpNetconfPlugin: %w", err)
+	}
+	return instance, nil
+}
+
+func (p *GptpNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*ptpInstance)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for GptpNetconfPlugin: %T", mapped)
+	}
+
+	return ptpInstanceFeature(root, nsPtp), nil
+}
+
+func (p *GptpNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
+
+func gptpSupportedFields(msg proto.Message) []string {
+	switch msg.(type) {
+	case *topology_config.BridgeConfig:
+		return []string{"GptpEnabled"}
+	case *topology_config.EndStationConfig:
+		return []string{"ParticipateInTimeSync"}
+	case *topology_config.PortConfig:
+		return []string{"GptpRole", "GptpPortNumber"}
+	default:
+		return nil
+	}
+}
+
+// timeSyncDisabled reports whether the role configuration of the node turns
+// time synchronization off. Unset means the device keeps its own setting.
+func timeSyncDisabled(cfg *topology_config.NodeConfig) bool {
+	if bridge := cfg.GetBridge(); bridge != nil && bridge.GptpEnabled != nil && !bridge.GetGptpEnabled() {
+		return true
+	}
+	if station := cfg.GetEndStation(); station != nil && station.ParticipateInTimeSync != nil && !station.GetParticipateInTimeSync() {
+		return true
+	}
+	return false
+}
+
+// validateQbvTimeSync rejects gate control lists on nodes without time
+// synchronization, their cycles would not be aligned across the network.
+func validateQbvTimeSync(cfg *topology_config.NodeConfig) error {
+	if !timeSyncDisabled(cfg) {
+		return nil
+	}
+
+	for _, portCfg := range cfg.GetPortConfigs() {
+		if portCfg.GetGcl() != nil {
+			return fmt.Errorf("port %s has a Qbv schedule but time synchronization is disabled", portCfg.GetPortId())
+		}
+	}
+
+	return nil
+}
+
+// validateGptpNode rejects port roles on nodes without time synchronization
+// and PTP port numbers used twice.
+func validateGptpNode(cfg *topology_config.NodeConfig) error {
+	disabled := timeSyncDisabled(cfg)
+	ports := make(map[uint32]string)
+
+	for _, portCfg := range cfg.GetPortConfigs() {
+		if portCfg.GptpRole == nil {
+			continue
+		}
+
+		role := portCfg.GetGptpRole()
+		if disabled && role != topology_config.GptpRole_GPTP_ROLE_DISABLED {
+			return fmt.Errorf("port %s has gPTP role %s but time synchronization is disabled", portCfg.GetPortId(), role)
+		}
+
+		if portCfg.GptpPortNumber == nil {
+			continue
+		}
+
+		number := portCfg.GetGptpPortNumber()
+		if other, dup := ports[number]; dup {
+			return fmt.Errorf("ports %s and %s have the same gPTP port number %d", other, portCfg.GetPortId(), number)
+		}
+		ports[number] = portCfg.GetPortId()
+	}
+
+	return nil
+}
+
+// mapGptp maps a role or port configuration into the gPTP instance.
+func mapGptp(msg proto.Message, logger observability.Logger) (*ptpInstance, error) {
+	instance := &ptpInstance{
+		InstanceIndex: ygot.Uint32(gptpInstanceIndex),
+	}
+
+	switch typed := msg.(type) {
+	case *topology_config.BridgeConfig:
+		if typed.GptpEnabled == nil {
+			return nil, fmt.Errorf("BridgeConfig has no gPTP setting")
+		}
+		logger.Printf("[gPTP] Bridge time synchronization enabled: %t", typed.GetGptpEnabled())
+		instance.DefaultDs = &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_DefaultDs{
+			InstanceEnable: ygot.Bool(typed.GetGptpEnabled()),
+		}
+
+	case *topology_config.EndStationConfig:
+		if typed.ParticipateInTimeSync == nil {
+			return nil, fmt.Errorf("EndStationConfig has no time synchronization setting")
+		}
+		logger.Printf("[gPTP] End station time synchronization enabled: %t", typed.GetParticipateInTimeSync())
+		instance.DefaultDs = &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_DefaultDs{
+			InstanceEnable: ygot.Bool(typed.GetParticipateInTimeSync()),
+		}
+
+	case *topology_config.PortConfig:
+		port, external, err := mapGptpPort(typed)
+		if err != nil {
+			return nil, err
+		}
+		logger.Printf("[gPTP] Port %s is PTP port %d with role %s", typed.GetPortId(), *port.PortIndex, typed.GetGptpRole())
+		instance.Ports = &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_Ports{
+			Port: map[uint16]*ptpPort{*port.PortIndex: port},
+		}
+		if external {
+			instance.DefaultDs = &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_DefaultDs{
+				ExternalPortConfigEnable: ygot.Bool(true),
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid message type: %T", msg)
+	}
+
+	return instance, nil
+}
+
+// mapGptpPort maps the gPTP role of a port. Master, slave and passive ports
+// are set to that state externally, which the caller must enable on the
+// instance; disabled ports are taken out of PTP operation.
+func mapGptpPort(cfg *topology_config.PortConfig) (*ptpPort, bool, error) {
+	if cfg.GptpRole == nil {
+		return nil, false, fmt.Errorf("port %s has a gPTP port number but no role", cfg.GetPortId())
+	}
+	if cfg.GptpPortNumber == nil {
+		return nil, false, fmt.Errorf("port %s has a gPTP role but no port number", cfg.GetPortId())
+	}
+
+	number := cfg.GetGptpPortNumber()
+	if number == 0 || number > math.MaxUint16 {
+		return nil, false, fmt.Errorf("port %s: gPTP port number %d out of range 1-%d", cfg.GetPortId(), number, math.MaxUint16)
+	}
+
+	port := &ptpPort{
+		PortIndex:           ygot.Uint16(uint16(number)),
+		UnderlyingInterface: ygot.String(cfg.GetPortId()),
+		PortDs: &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_Ports_Port_PortDs{
+			PortEnable: ygot.Bool(cfg.GetGptpRole() != topology_config.GptpRole_GPTP_ROLE_DISABLED),
+		},
+	}
+
+	var state opencncModel.E_Ieee1588PtpTt_PortState
+
+	switch cfg.GetGptpRole() {
+	case topology_config.GptpRole_GPTP_ROLE_MASTER:
+		state = opencncModel.Ieee1588PtpTt_PortState_time_transmitter
+	case topology_config.GptpRole_GPTP_ROLE_SLAVE:
+		state = opencncModel.Ieee1588PtpTt_PortState_time_receiver
+	case topology_config.GptpRole_GPTP_ROLE_PASSIVE:
+		state = opencncModel.Ieee1588PtpTt_PortState_passive
+	case topology_config.GptpRole_GPTP_ROLE_DISABLED, topology_config.GptpRole_GPTP_ROLE_UNSPECIFIED:
+		// Left to the BTCA.
+		return port, false, nil
+	default:
+		return nil, false, fmt.Errorf("port %s: unknown gPTP role %d", cfg.GetPortId(), cfg.GetGptpRole())
+	}
+
+	port.ExternalPortConfigPortDs = &opencncModel.Ieee1588PtpTt_Ptp_Instances_Instance_Ports_Port_ExternalPortConfigPortDs{
+		DesiredState: state,
+	}
+
+	return port, true, nil
+}
+
+// ptpInstanceFeature writes the datasets of the instance, anchored in the
+// instance of the PTP module with namespace ns.
+func ptpInstanceFeature(root *ptpInstance, ns string) *plugins.FeatureXML {
+	var buf bytes.Buffer
+
+	if ds := root.DefaultDs; ds != nil {
+		buf.WriteString(`<default-ds>`)
+		if ds.InstanceEnable != nil {
+			buf.WriteString(fmt.Sprintf(`<instance-enable>%t</instance-enable>`, *ds.InstanceEnable))
+		}
+		if ds.ExternalPortConfigEnable != nil {
+			buf.WriteString(fmt.Sprintf(`<external-port-config-enable>%t</external-port-config-enable>`, *ds.ExternalPortConfigEnable))
+		}
+		buf.WriteString(`</default-ds>`)
+	}
+
+	if root.Ports != nil {
+		buf.WriteString(`<ports>`)
+		for _, index := range sortedPortIndexes(root.Ports.Port) {
+			port := root.Ports.Port[index]
+			buf.WriteString(`<port>`)
+			buf.WriteString(fmt.Sprintf(`<port-index>%d</port-index>`, index))
+			if port.UnderlyingInterface != nil {
+				buf.WriteString(fmt.Sprintf(`<underlying-interface>%s</underlying-interface>`, *port.UnderlyingInterface))
+			}
+			if port.PortDs != nil && port.PortDs.PortEnable != nil {
+				buf.WriteString(fmt.Sprintf(`<port-ds><port-enable>%t</port-enable></port-ds>`, *port.PortDs.PortEnable))
+			}
+			if ext := port.ExternalPortConfigPortDs; ext != nil {
+				buf.WriteString(`<external-port-config-port-ds>`)
+				buf.WriteString(fmt.Sprintf(`<desired-state>%s</desired-state>`, ext.DesiredState.String()))
+				buf.WriteString(`</external-port-config-port-ds>`)
+			}
+			buf.WriteString(`</port>`)
+		}
+		buf.WriteString(`</ports>`)
+	}
+
+	return &plugins.FeatureXML{
+		Container: "ptp",
+		XML:       buf.Bytes(),
+		Anchor: []plugins.AnchorStep{
+			{Name: "ptp", Namespace: ns},
+			{Name: "instances"},
+			{Name: "instance", Key: "instance-index", Value: fmt.Sprint(*root.InstanceIndex)},
+		},
+	}
+}
+
+func sortedPortIndexes[V any](m map[uint16]V) []uint16 {
+	indexes := make([]uint16, 0, len(m))
+	for index := range m {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+// pushAnchoredFeature edits the feature, enclosed in its anchor, into the device.
+func pushAnchoredFeature(featurexml *plugins.FeatureXML, target managementSessions.DeviceTarget, logger observability.Logger) error {
+	xml, err := featurexml.Wrap(target.InterfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to build XML: %w", err)
+	}
+
+	if target.Info == nil {
+		return fmt.Errorf("device target info is nil")
+	}
+
+	session, err := target.Connect()
+	if err != nil {
+		return fmt.Errorf("NETCONF session failed: %w", err)
+	}
+	defer managementSessions.CloseSession(session)
+
+	logger.Printf("[%s] XML generated:\n%s", featurexml.Container, xml)
+
+	if err := managementSessions.EditConfig(session, string(xml)); err != nil {
+		return fmt.Errorf("edit-config failed: %w", err)
+	}
+
+	return nil
+}
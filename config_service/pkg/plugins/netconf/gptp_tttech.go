@@ -0,0 +1,110 @@
+package netconf
+
+import (
+	"fmt"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	_ plugins.Plugin        = (*TttechGptpNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*TttechGptpNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*TttechGptpNetconfPlugin)(nil)
+)
+
+const nsPtpTt = "urn:ieee:std:1588:yang:ieee1588-ptp-tt"
+
+// TttechGptpNetconfPlugin configures gPTP on the TTTech devices, which carry
+// the PTP datasets in their copy of the module, ieee1588-ptp-tt.
+type TttechGptpNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewGptpNetconfPlugin_tttech(logger observability.Logger) *TttechGptpNetconfPlugin {
+	return &TttechGptpNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewGptpNetconfPlugin_tttech(logger)
+		},
+	})
+}
+
+func (p *TttechGptpNetconfPlugin) Name() string {
+	return "gptp-netconf"
+}
+
+func (p *TttechGptpNetconfPlugin) FeatureName() string {
+	return "gptp"
+}
+
+func (p *TttechGptpNetconfPlugin) OwnedSubtrees() []string {
+	return []string{fmt.Sprintf(`<ptp xmlns="%s"/>`, nsPtpTt)}
+}
+
+func (p *TttechGptpNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{Name: "ieee1588-ptp-tt.yang", Revision: "2023-08-14"},
+		{Name: "ieee802-dot1as-gptp.yang", Revision: "2024-06-23"},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && yf.Revision == req.Revision {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *TttechGptpNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	return gptpSupportedFields(msg)
+}
+
+func (p *TttechGptpNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	return validateGptpNode(cfg)
+}
+
+func (p *TttechGptpNetconfPlugin) Map(msg proto.Message) (any, error) {
+	instance, err := mapGptp(msg, p.logger)
+	if err != nil {
+		return nil, fmt.Errorf("TttechGptpNetconfPlugin: %w", err)
+	}
+	return instance, nil
+}
+
+func (p *TttechGptpNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*ptpInstance)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for TttechGptpNetconfPlugin: %T", mapped)
+	}
+
+	return ptpInstanceFeature(root, nsPtpTt), nil
+}
+
+func (p *TttechGptpNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
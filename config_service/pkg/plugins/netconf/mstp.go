@@ -0,0 +1,501 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	stp "OpenCNC_config_service/common/structures/stp"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin       = (*MstpNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner = (*MstpNetconfPlugin)(nil)
+)
+
+const nsMstp = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-mstp"
+
+// MstpNetconfPlugin configures the Multiple Spanning Tree Protocol of a bridge:
+// the MST resources and FID to MSTID allocation of ieee802-dot1q-bridge, and
+// the instance priorities and per-port parameters of ieee802-dot1q-mstp.
+//
+// The bridge-wide settings are mapped from BridgeConfig, the per-port
+// enablement and edge settings from each PortConfig.
+type MstpNetconfPlugin struct {
+	logger observability.Logger
+}
+
+// bridgeMstpPayload is a bridge component holding MSTP configuration only.
+type bridgeMstpPayload struct {
+	BridgeName string
+	Component  *opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component
+}
+
+type (
+	mstpMsti     = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp_Msti
+	mstpMstiPort = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp_Msti_Port
+	mstpPort     = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp_Port
+	mstFid       = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_BridgeMst_FidToMstid
+	mstFidRange  = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_BridgeMst_FidToMstidAllocation
+)
+
+func NewMstpNetconfPlugin(logger observability.Logger) *MstpNetconfPlugin {
+	return &MstpNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewMstpNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *MstpNetconfPlugin) Name() string {
+	return "mstp-netconf"
+}
+
+func (p *MstpNetconfPlugin) FeatureName() string {
+	return "mstp"
+}
+
+func (p *MstpNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeBridges}
+}
+
+func (p *MstpNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{
+			Name:     "ieee802-dot1q-bridge.yang",
+			Revision: "2018-03-07",
+		},
+		{
+			// Vendor revisions only, any is accepted.
+			Name: "ieee802-dot1q-mstp.yang",
+		},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *MstpNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	switch msg.(type) {
+	case *topology_config.BridgeConfig:
+		return []string{"Stp", "MstConfig"}
+	case *topology_config.PortConfig:
+		return []string{"StpEnabled", "IsEdgePort"}
+	default:
+		return nil
+	}
+}
+
+func (p *MstpNetconfPlugin) Map(msg proto.Message) (any, error) {
+	component := &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component{
+		Name: ygot.String("br0"),
+	}
+
+	switch typed := msg.(type) {
+	case *topology_config.BridgeConfig:
+		if typed.Stp == nil && typed.MstConfig == nil {
+			return nil, fmt.Errorf("MstpNetconfPlugin: BridgeConfig has no STP config")
+		}
+
+		if cfg := typed.GetStp(); cfg != nil {
+			mstp, err := mapStpConfiguration(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("MstpNetconfPlugin: %w", err)
+			}
+			component.Mstp = mstp
+		}
+
+		if cfg := typed.GetMstConfig(); cfg != nil {
+			mst, err := mapBridgeMst(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("MstpNetconfPlugin: %w", err)
+			}
+			component.BridgeMst = mst
+		}
+
+		p.logger.Printf("[MSTP] Mapping bridge spanning tree config")
+
+	case *topology_config.PortConfig:
+		if typed.GetPortId() == "" {
+			return nil, fmt.Errorf("MstpNetconfPlugin: PortConfig has no port id")
+		}
+
+		if typed.StpEnabled == nil && typed.IsEdgePort == nil {
+			return nil, fmt.Errorf("MstpNetconfPlugin: port %s has no STP config", typed.GetPortId())
+		}
+
+		port := &mstpPort{Name: ygot.String(typed.GetPortId())}
+		if typed.StpEnabled != nil {
+			port.Enabled = ygot.Bool(typed.GetStpEnabled())
+		}
+		if typed.IsEdgePort != nil {
+			port.AdminEdgePort = ygot.Bool(typed.GetIsEdgePort())
+		}
+
+		component.Mstp = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp{
+			Port: map[string]*mstpPort{typed.GetPortId(): port},
+		}
+
+		p.logger.Printf("[MSTP] Mapping spanning tree settings of port %s", typed.GetPortId())
+
+	default:
+		return nil, fmt.Errorf("invalid message type for MstpNetconfPlugin: %T", msg)
+	}
+
+	return &bridgeMstpPayload{
+		BridgeName: "br0",
+		Component:  component,
+	}, nil
+}
+
+func mapStpConfiguration(cfg *stp.StpConfiguration) (*opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp, error) {
+	mstp := &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_Mstp{
+		Msti: make(map[uint32]*mstpMsti),
+	}
+
+	if cfg.StpEnabled != nil {
+		mstp.Enabled = ygot.Bool(cfg.GetStpEnabled())
+	}
+
+	for _, instance := range cfg.GetSpanningTreeInstances() {
+		if instance == nil {
+			continue
+		}
+
+		mstid, err := parseMstid(instance.GetInstanceId())
+		if err != nil {
+			return nil, err
+		}
+
+		if _, dup := mstp.Msti[mstid]; dup {
+			return nil, fmt.Errorf("spanning tree instance %d configured twice", mstid)
+		}
+
+		msti := &mstpMsti{
+			Mstid: ygot.Uint32(mstid),
+			Port:  make(map[string]*mstpMstiPort),
+		}
+
+		if instance.BridgePriority != nil {
+			prio := instance.GetBridgePriority()
+			if prio > 61440 || prio%4096 != 0 {
+				return nil, fmt.Errorf("instance %q: bridge priority %d is not a multiple of 4096 in 0-61440", instance.GetInstanceId(), prio)
+			}
+			msti.BridgePriority = ygot.Uint32(prio)
+		}
+
+		for _, state := range instance.GetPortStates() {
+			if state == nil || (state.PathCost == nil && state.PortPriority == nil) {
+				continue
+			}
+
+			if state.GetPortId() == "" {
+				return nil, fmt.Errorf("instance %q: port settings without port id", instance.GetInstanceId())
+			}
+
+			port := &mstpMstiPort{Name: ygot.String(state.GetPortId())}
+
+			if state.PathCost != nil {
+				cost := state.GetPathCost()
+				if cost < 1 || cost > 200000000 {
+					return nil, fmt.Errorf("instance %q: port %s: path cost %d out of range 1-200000000", instance.GetInstanceId(), state.GetPortId(), cost)
+				}
+				port.PathCost = ygot.Uint32(cost)
+			}
+
+			if state.PortPriority != nil {
+				prio := state.GetPortPriority()
+				if prio > 240 || prio%16 != 0 {
+					return nil, fmt.Errorf("instance %q: port %s: port priority %d is not a multiple of 16 in 0-240", instance.GetInstanceId(), state.GetPortId(), prio)
+				}
+				port.PortPriority = ygot.Uint32(prio)
+			}
+
+			msti.Port[state.GetPortId()] = port
+		}
+
+		mstp.Msti[mstid] = msti
+	}
+
+	return mstp, nil
+}
+
+// parseMstid accepts "cist", "mstN" and plain MSTIDs; the CIST is MSTID 0.
+func parseMstid(instanceID string) (uint32, error) {
+	id := strings.ToLower(strings.TrimSpace(instanceID))
+	if id == "cist" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(strings.TrimPrefix(id, "mst"), 10, 32)
+	if err != nil || n > 4094 {
+		return 0, fmt.Errorf("invalid spanning tree instance id %q", instanceID)
+	}
+
+	return uint32(n), nil
+}
+
+func mapBridgeMst(cfg *stp.BridgeMstConfig) (*opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_BridgeMst, error) {
+	mst := &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_BridgeMst{
+		FidToMstid:           make(map[uint32]*mstFid),
+		FidToMstidAllocation: make(map[string]*mstFidRange),
+	}
+
+	supported := make(map[uint32]struct{})
+	for _, id := range cfg.GetSupportedMstIds() {
+		if id < 1 || id > 4094 {
+			return nil, fmt.Errorf("MSTID %d out of range 1-4094", id)
+		}
+		if _, dup := supported[id]; dup {
+			continue
+		}
+		supported[id] = struct{}{}
+		mst.Mstid = append(mst.Mstid, id)
+	}
+	sort.Slice(mst.Mstid, func(i, j int) bool { return mst.Mstid[i] < mst.Mstid[j] })
+
+	checkMstid := func(mstid uint32) error {
+		if mstid < 1 || mstid > 4094 {
+			return fmt.Errorf("MSTID %d out of range 1-4094", mstid)
+		}
+		if _, ok := supported[mstid]; len(supported) > 0 && !ok {
+			return fmt.Errorf("MSTID %d is not a supported MSTID", mstid)
+		}
+		return nil
+	}
+
+	for _, m := range cfg.GetFidToMstidMappings() {
+		if m == nil {
+			continue
+		}
+
+		if err := checkMstid(m.GetMstId()); err != nil {
+			return nil, fmt.Errorf("FID %d: %w", m.GetFid(), err)
+		}
+
+		if old, dup := mst.FidToMstid[m.GetFid()]; dup && *old.Mstid != m.GetMstId() {
+			return nil, fmt.Errorf("FID %d allocated to MSTIDs %d and %d", m.GetFid(), *old.Mstid, m.GetMstId())
+		}
+
+		mst.FidToMstid[m.GetFid()] = &mstFid{
+			Fid:   ygot.Uint32(m.GetFid()),
+			Mstid: ygot.Uint32(m.GetMstId()),
+		}
+	}
+
+	for _, a := range cfg.GetFidToMstidAllocations() {
+		if a == nil || len(a.GetFids()) == 0 {
+			continue
+		}
+
+		fids := fidRanges(a.GetFids())
+
+		if err := checkMstid(a.GetMstId()); err != nil {
+			return nil, fmt.Errorf("FIDs %s: %w", fids, err)
+		}
+
+		mst.FidToMstidAllocation[fids] = &mstFidRange{
+			Fids:  ygot.String(fids),
+			Mstid: ygot.Uint32(a.GetMstId()),
+		}
+	}
+
+	return mst, nil
+}
+
+// fidRanges formats FIDs as a vid-range-type, e.g. "1-3,7".
+func fidRanges(fids []uint32) string {
+	sorted := append([]uint32(nil), fids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var parts []string
+
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] <= sorted[j]+1 {
+			j++
+		}
+
+		if sorted[i] == sorted[j] {
+			parts = append(parts, strconv.FormatUint(uint64(sorted[i]), 10))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func (p *MstpNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	payload, ok := mapped.(*bridgeMstpPayload)
+	if !ok || payload.Component == nil {
+		return nil, fmt.Errorf("invalid mapped type for MstpNetconfPlugin: %T", mapped)
+	}
+
+	bridgeName := payload.BridgeName
+	if bridgeName == "" {
+		bridgeName = "br0"
+	}
+	componentName := bridgeName
+	if payload.Component.Name != nil {
+		componentName = *payload.Component.Name
+	}
+
+	component := payload.Component
+
+	var buf bytes.Buffer
+
+	// The feature is anchored in the component, bridge-mst and mstp are
+	// its roots.
+	if mst := component.BridgeMst; mst != nil {
+		buf.WriteString(`<bridge-mst xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">`)
+		for _, id := range mst.Mstid {
+			buf.WriteString(fmt.Sprintf(`<mstid>%d</mstid>`, id))
+		}
+		for _, fid := range sortedKeys(mst.FidToMstid) {
+			entry := mst.FidToMstid[fid]
+			buf.WriteString(`<fid-to-mstid>`)
+			buf.WriteString(fmt.Sprintf(`<fid>%d</fid>`, *entry.Fid))
+			buf.WriteString(fmt.Sprintf(`<mstid>%d</mstid>`, *entry.Mstid))
+			buf.WriteString(`</fid-to-mstid>`)
+		}
+		for _, fids := range sortedNames(mst.FidToMstidAllocation) {
+			entry := mst.FidToMstidAllocation[fids]
+			buf.WriteString(`<fid-to-mstid-allocation>`)
+			buf.WriteString(fmt.Sprintf(`<fids>%s</fids>`, *entry.Fids))
+			buf.WriteString(fmt.Sprintf(`<mstid>%d</mstid>`, *entry.Mstid))
+			buf.WriteString(`</fid-to-mstid-allocation>`)
+		}
+		buf.WriteString(`</bridge-mst>`)
+	}
+
+	if mstp := component.Mstp; mstp != nil {
+		buf.WriteString(fmt.Sprintf(`<mstp xmlns="%s">`, nsMstp))
+
+		if mstp.Enabled != nil {
+			buf.WriteString(fmt.Sprintf(`<enabled>%t</enabled>`, *mstp.Enabled))
+		}
+
+		for _, id := range sortedKeys(mstp.Msti) {
+			writeMstiXML(&buf, mstp.Msti[id])
+		}
+
+		for _, name := range sortedNames(mstp.Port) {
+			port := mstp.Port[name]
+			buf.WriteString(`<port>`)
+			buf.WriteString(fmt.Sprintf(`<name>%s</name>`, *port.Name))
+			if port.Enabled != nil {
+				buf.WriteString(fmt.Sprintf(`<enabled>%t</enabled>`, *port.Enabled))
+			}
+			if port.AdminEdgePort != nil {
+				buf.WriteString(fmt.Sprintf(`<admin-edge-port>%t</admin-edge-port>`, *port.AdminEdgePort))
+			}
+			buf.WriteString(`</port>`)
+		}
+
+		buf.WriteString(`</mstp>`)
+	}
+
+	return &plugins.FeatureXML{
+		XML:    buf.Bytes(),
+		Anchor: plugins.BridgeComponentAnchor(bridgeName, componentName),
+	}, nil
+}
+
+func writeMstiXML(buf *bytes.Buffer, msti *mstpMsti) {
+	buf.WriteString(`<msti>`)
+	buf.WriteString(fmt.Sprintf(`<mstid>%d</mstid>`, *msti.Mstid))
+
+	if msti.BridgePriority != nil {
+		buf.WriteString(fmt.Sprintf(`<bridge-priority>%d</bridge-priority>`, *msti.BridgePriority))
+	}
+
+	for _, name := range sortedNames(msti.Port) {
+		port := msti.Port[name]
+		buf.WriteString(`<port>`)
+		buf.WriteString(fmt.Sprintf(`<name>%s</name>`, *port.Name))
+		if port.PathCost != nil {
+			buf.WriteString(fmt.Sprintf(`<path-cost>%d</path-cost>`, *port.PathCost))
+		}
+		if port.PortPriority != nil {
+			buf.WriteString(fmt.Sprintf(`<port-priority>%d</port-priority>`, *port.PortPriority))
+		}
+		buf.WriteString(`</port>`)
+	}
+
+	buf.WriteString(`</msti>`)
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *MstpNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	xml, err := featurexml.Wrap(target.InterfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to build XML: %w", err)
+	}
+
+	if target.Info == nil {
+		return fmt.Errorf("device target info is nil")
+	}
+
+	session, err := target.Connect()
+	if err != nil {
+		return fmt.Errorf("NETCONF session failed: %w", err)
+	}
+	defer managementSessions.CloseSession(session)
+
+	p.logger.Printf("[MSTP] XML generated:\n%s", xml)
+
+	if err := managementSessions.EditConfig(session, string(xml)); err != nil {
+		return fmt.Errorf("edit-config failed: %w", err)
+	}
+
+	return nil
+}
@@ -17,7 +17,10 @@ import (
 	"github.com/openconfig/ygot/ygot"
 )
 
-var _ plugins.Plugin = (*PcpMappingNetconfPlugin)(nil)
+var (
+	_ plugins.Plugin       = (*PcpMappingNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner = (*PcpMappingNetconfPlugin)(nil)
+)
 
 type PcpMappingNetconfPlugin struct {
 	logger observability.Logger
@@ -45,6 +48,10 @@ func (p *PcpMappingNetconfPlugin) FeatureName() string {
 	return "PcpMapping"
 }
 
+func (p *PcpMappingNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
 func (p *PcpMappingNetconfPlugin) SupportedFields(msg proto.Message) []string {
 	if _, ok := msg.(*topology_config.PortConfig); !ok {
 		return nil
@@ -181,11 +188,11 @@ func (p *PcpMappingNetconfPlugin) Push(mapped any, target managementSessions.Dev
 		p.logger.Printf("[PcpMapping] XML generated for interface %s:\n%s", target.InterfaceName, xml)
 	}
 
-	session, err := managementSessions.CreateSession(target.Info.IpAddress, target.Info.UserName, target.Secret)
+	session, err := target.Connect()
 	if err != nil {
 		return fmt.Errorf("NETCONF session failed: %w", err)
 	}
-	defer session.Close()
+	defer managementSessions.CloseSession(session)
 
 	if err := managementSessions.EditConfig(session, xml); err != nil {
 		return fmt.Errorf("edit-config failed: %w", err)
@@ -0,0 +1,356 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*PreemptionNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*PreemptionNetconfPlugin)(nil)
+	_ plugins.PortValidator = (*PreemptionNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*PreemptionNetconfPlugin)(nil)
+)
+
+const nsPreemption = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-preemption"
+
+const (
+	// maxFrameBytes is the largest tagged frame, used for traffic classes
+	// without a configured maximum frame size.
+	maxFrameBytes = 1522
+
+	// frameOverheadBytes adds the preamble, start frame delimiter and
+	// interframe gap to a frame.
+	frameOverheadBytes = 20
+
+	// preemptionHoldBytes is the longest a preemptable frame keeps the
+	// line once an express frame is waiting (802.3br, 123 bytes that
+	// cannot be preempted with their overhead).
+	preemptionHoldBytes = 143
+)
+
+// defaultTrafficClasses is the priority to traffic class mapping of ports
+// with eight traffic classes and no traffic class table (802.1Q Table 8-5).
+var defaultTrafficClasses = [8]uint32{1, 0, 2, 3, 4, 5, 6, 7}
+
+// PreemptionNetconfPlugin sets which priorities of a port are express and
+// which preemptable (802.1Qbu/802.3br) through ieee802-dot1q-preemption.
+type PreemptionNetconfPlugin struct {
+	logger observability.Logger
+}
+
+func NewPreemptionNetconfPlugin(logger observability.Logger) *PreemptionNetconfPlugin {
+	return &PreemptionNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewPreemptionNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *PreemptionNetconfPlugin) Name() string {
+	return "preemption-netconf"
+}
+
+func (p *PreemptionNetconfPlugin) FeatureName() string {
+	return "frame-preemption"
+}
+
+func (p *PreemptionNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
+func (p *PreemptionNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{Name: "ieee802-dot1q-bridge.yang"},
+		// Devices implement vendor revisions, any is accepted.
+		{Name: "ieee802-dot1q-preemption.yang"},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && (req.Revision == "" || yf.Revision == req.Revision) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *PreemptionNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	if _, ok := msg.(*topology_config.PortConfig); !ok {
+		return nil
+	}
+
+	return []string{
+		"FramePreemption",
+	}
+}
+
+// ValidateNode rejects preemptable priorities on bridges with frame
+// preemption disabled.
+func (p *PreemptionNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	bridge := cfg.GetBridge()
+	if bridge == nil || bridge.FramePreemptionEnabled == nil || bridge.GetFramePreemptionEnabled() {
+		return nil
+	}
+
+	for _, portCfg := range cfg.GetPortConfigs() {
+		for _, entry := range portCfg.GetFramePreemption() {
+			if entry.GetStatus() == topology_config.FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE {
+				return fmt.Errorf(
+					"port %s has preemptable priority %d but frame preemption is disabled",
+					portCfg.GetPortId(),
+					entry.GetPcp(),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *PreemptionNetconfPlugin) ValidatePort(cfg *topology_config.PortConfig, port *topology.Port) error {
+	return validatePreemptionPort(cfg, port, p.logger)
+}
+
+func (p *PreemptionNetconfPlugin) Map(msg proto.Message) (any, error) {
+	portCfg, ok := msg.(*topology_config.PortConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid message type for PreemptionNetconfPlugin: %T", msg)
+	}
+
+	statuses, err := preemptionStatuses(portCfg)
+	if err != nil {
+		return nil, fmt.Errorf("PreemptionNetconfPlugin: %w", err)
+	}
+
+	p.logger.Printf("[Preemption] Mapping %d priorities", len(statuses))
+
+	params := &opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort_FramePreemptionParameters{
+		FramePreemptionStatusTable: make(map[uint8]*opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort_FramePreemptionParameters_FramePreemptionStatusTable),
+	}
+
+	for pcp, status := range statuses {
+		entry := &opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort_FramePreemptionParameters_FramePreemptionStatusTable{
+			Priority:              ygot.Uint8(pcp),
+			FramePreemptionStatus: opencncModel.Ieee802Dot1QPreemption_FramePreemptionStatusEnum_express,
+		}
+		if status == topology_config.FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE {
+			entry.FramePreemptionStatus = opencncModel.Ieee802Dot1QPreemption_FramePreemptionStatusEnum_preemptable
+		}
+		params.FramePreemptionStatusTable[pcp] = entry
+	}
+
+	return params, nil
+}
+
+func (p *PreemptionNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	root, ok := mapped.(*opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort_FramePreemptionParameters)
+	if !ok {
+		return nil, fmt.Errorf("invalid mapped type for PreemptionNetconfPlugin: %T", mapped)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(fmt.Sprintf(`<frame-preemption-parameters xmlns="%s">`, nsPreemption))
+
+	for _, pcp := range sortedTrafficClasses(root.FramePreemptionStatusTable) {
+		entry := root.FramePreemptionStatusTable[pcp]
+		buf.WriteString(`<frame-preemption-status-table>`)
+		buf.WriteString(fmt.Sprintf(`<priority>%d</priority>`, *entry.Priority))
+		buf.WriteString(fmt.Sprintf(`<frame-preemption-status>%s</frame-preemption-status>`, entry.FramePreemptionStatus.String()))
+		buf.WriteString(`</frame-preemption-status-table>`)
+	}
+
+	buf.WriteString(`</frame-preemption-parameters>`)
+
+	return &plugins.FeatureXML{
+		Container: "frame-preemption-parameters",
+		XML:       buf.Bytes(),
+		Anchor: plugins.InterfaceAnchor("", plugins.AnchorStep{
+			Name:      "bridge-port",
+			Namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge",
+		}),
+	}, nil
+}
+
+func (p *PreemptionNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
+
+// preemptionStatuses collects the frame preemption status of the port's
+// priorities.
+func preemptionStatuses(portCfg *topology_config.PortConfig) (map[uint8]topology_config.FramePreemptionStatus, error) {
+	statuses := make(map[uint8]topology_config.FramePreemptionStatus)
+
+	for _, entry := range portCfg.GetFramePreemption() {
+		if entry == nil {
+			continue
+		}
+
+		if entry.GetPcp() > 7 {
+			return nil, fmt.Errorf("priority %d out of range 0-7", entry.GetPcp())
+		}
+
+		pcp := uint8(entry.GetPcp())
+		if _, dup := statuses[pcp]; dup {
+			return nil, fmt.Errorf("priority %d has two frame preemption entries", pcp)
+		}
+
+		switch entry.GetStatus() {
+		case topology_config.FramePreemptionStatus_FRAME_PREEMPTION_EXPRESS,
+			topology_config.FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE:
+			statuses[pcp] = entry.GetStatus()
+		default:
+			return nil, fmt.Errorf("priority %d has no frame preemption status", pcp)
+		}
+	}
+
+	return statuses, nil
+}
+
+// preemptableTrafficClasses returns the traffic classes of the port whose
+// priorities are all preemptable. A traffic class mixing express and
+// preemptable priorities is rejected: its queue feeds a single MAC.
+func preemptableTrafficClasses(portCfg *topology_config.PortConfig, statuses map[uint8]topology_config.FramePreemptionStatus) (map[uint32]bool, error) {
+	trafficClasses := defaultTrafficClasses
+	for _, entry := range portCfg.GetTrafficClassTable() {
+		if entry.GetPcp() <= 7 {
+			trafficClasses[entry.GetPcp()] = entry.GetEgressQueueId()
+		}
+	}
+
+	preemptable := make(map[uint32]bool)
+
+	for pcp, tc := range trafficClasses {
+		// Priorities without an entry are express, the default status.
+		isPreemptable := statuses[uint8(pcp)] == topology_config.FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE
+
+		if other, seen := preemptable[tc]; seen && other != isPreemptable {
+			return nil, fmt.Errorf("traffic class %d has express and preemptable priorities", tc)
+		}
+		preemptable[tc] = isPreemptable
+	}
+
+	return preemptable, nil
+}
+
+// validatePreemptionPort checks the frame preemption table of the port and
+// the guard bands of its Qbv schedule against it. A guard band, a run of
+// entries closing every gate, must cover the frames of the traffic classes
+// open just before it: a full frame for express traffic classes, only the
+// part that cannot be preempted for preemptable ones. Ports of unknown speed
+// only have the table checked.
+func validatePreemptionPort(cfg *topology_config.PortConfig, port *topology.Port, logger observability.Logger) error {
+	statuses, err := preemptionStatuses(cfg)
+	if err != nil || len(statuses) == 0 {
+		return err
+	}
+
+	preemptable, err := preemptableTrafficClasses(cfg, statuses)
+	if err != nil {
+		return err
+	}
+
+	entries := cfg.GetGcl().GetEntries()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	speedMbps := port.GetCapabilities().GetPortSpeed()
+	if speedMbps <= 0 {
+		logger.Printf("[Preemption] Port %s has no known speed, guard bands not checked", cfg.GetPortId())
+		return nil
+	}
+
+	maxFrames := make(map[uint32]uint32)
+	for _, queueCfg := range cfg.GetQueueConfigs() {
+		if queueCfg.GetMaxFrameSize() > 0 {
+			maxFrames[queueCfg.GetQueueId()] = queueCfg.GetMaxFrameSize()
+		}
+	}
+
+	gates := func(i int) uint8 {
+		states := entries[i%len(entries)].GetGateStates()
+		if len(states) == 0 {
+			return 0
+		}
+		return states[0]
+	}
+
+	for i := range entries {
+		prev := (i + len(entries) - 1) % len(entries)
+		if gates(i) != 0 || gates(prev) == 0 {
+			// Not the start of a guard band.
+			continue
+		}
+
+		var length uint64
+		for j := i; j < i+len(entries) && gates(j) == 0; j++ {
+			length += entries[j%len(entries)].GetTimeInterval()
+		}
+
+		for tc := uint32(0); tc <= 7; tc++ {
+			if gates(prev)&(1<<tc) == 0 {
+				continue
+			}
+
+			kind, frameBytes := "express", uint64(frameOverheadBytes)
+			if preemptable[tc] {
+				kind, frameBytes = "preemptable", preemptionHoldBytes
+			} else if size, ok := maxFrames[tc]; ok {
+				frameBytes += uint64(size)
+			} else {
+				frameBytes += maxFrameBytes
+			}
+
+			// Nanoseconds to transmit the bytes, rounded up.
+			needed := (frameBytes*8*1000 + uint64(speedMbps) - 1) / uint64(speedMbps)
+
+			if length < needed {
+				return fmt.Errorf(
+					"guard band at gate control entry %d lasts %d ns, %s traffic class %d needs %d ns at %d Mbit/s",
+					entries[i].GetIndex(),
+					length,
+					kind,
+					tc,
+					needed,
+					speedMbps,
+				)
+			}
+		}
+	}
+
+	return nil
+}
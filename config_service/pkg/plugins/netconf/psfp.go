@@ -0,0 +1,619 @@
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"OpenCNC_config_service/common/observability"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	psfp "OpenCNC_config_service/common/structures/psfp"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	opencncModel "OpenCNC_config_service/config_service/opencnc_model"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	_ plugins.Plugin        = (*PsfpNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*PsfpNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*PsfpNetconfPlugin)(nil)
+)
+
+const nsPsfp = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-psfp"
+
+// PsfpNetconfPlugin configures IEEE 802.1Qci per-stream filtering and policing:
+// the stream filters, stream gates and flow meters of a bridge component.
+type PsfpNetconfPlugin struct {
+	logger observability.Logger
+}
+
+// bridgePsfpPayload is a bridge component holding PSFP configuration only.
+type bridgePsfpPayload struct {
+	BridgeName string
+	Component  *opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component
+}
+
+type (
+	psfpFilter = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamFilters_StreamFilterInstanceTable
+	psfpGate   = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates_StreamGateInstanceTable
+	psfpGateOp = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates_StreamGateInstanceTable_AdminControlList_GateControlEntry
+	psfpMeter  = opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_FlowMeters_FlowMeterInstanceTable
+)
+
+func NewPsfpNetconfPlugin(logger observability.Logger) *PsfpNetconfPlugin {
+	return &PsfpNetconfPlugin{logger: observability.NormalizeLogger(logger)}
+}
+
+// plugin registers itself
+func init() {
+	plugins.Register(plugins.PluginFactory{
+		Protocol: topology.ManagementProtocol_NETCONF,
+		New: func(logger observability.Logger) plugins.Plugin {
+			return NewPsfpNetconfPlugin(logger)
+		},
+	})
+}
+
+func (p *PsfpNetconfPlugin) Name() string {
+	return "psfp-netconf"
+}
+
+func (p *PsfpNetconfPlugin) FeatureName() string {
+	return "psfp"
+}
+
+func (p *PsfpNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeBridges}
+}
+
+// SupportedByDevice requires the stream filters and gates module only: the
+// devices advertise it for PSFP, flow meters included.
+func (p *PsfpNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
+	requiredYangs := []devicemodelregistry.YangFile{
+		{
+			Name:     "ieee802-dot1q-stream-filters-gates.yang",
+			Revision: "2020-11-06",
+		},
+	}
+
+	for i := range requiredYangs {
+		req := &requiredYangs[i]
+		found := false
+
+		for _, yf := range model.YangFiles {
+			if yf.Name == req.Name && yf.Revision == req.Revision {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *PsfpNetconfPlugin) SupportedFields(msg proto.Message) []string {
+	switch msg.(type) {
+	case *topology_config.PortConfig, *topology_config.BridgeConfig:
+		return []string{"Psfp"}
+	case *psfp.PsfpConfig:
+		return []string{"StreamFilters", "StreamGates", "FlowMeters"}
+	default:
+		return nil
+	}
+}
+
+// ValidateNode rejects stream filter, stream gate and flow meter instance ids
+// used by more than one of the bridge and its ports. The PSFP configuration
+// of a port is written to the bridge component like the bridge's own, so an
+// id reused elsewhere would replace the instance configured there.
+func (p *PsfpNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	type owned struct {
+		owner string
+		cfg   *psfp.PsfpConfig
+	}
+
+	configs := []owned{{"the bridge", cfg.GetBridge().GetPsfp()}}
+	for _, portCfg := range cfg.GetPortConfigs() {
+		configs = append(configs, owned{"port " + portCfg.GetPortId(), portCfg.GetPsfp()})
+	}
+
+	filters := make(map[uint32]string)
+	gates := make(map[uint32]string)
+	meters := make(map[uint32]string)
+
+	// Duplicates within one configuration are reported by Map.
+	claim := func(ids map[uint32]string, kind string, id uint32, owner string) error {
+		if other, ok := ids[id]; ok && other != owner {
+			return fmt.Errorf("%s %d of %s is already configured by %s", kind, id, owner, other)
+		}
+		ids[id] = owner
+		return nil
+	}
+
+	for _, c := range configs {
+		for _, filter := range c.cfg.GetStreamFilters() {
+			if filter == nil {
+				continue
+			}
+			if err := claim(filters, "stream filter", filter.GetIndex(), c.owner); err != nil {
+				return err
+			}
+		}
+		for _, gate := range c.cfg.GetStreamGates() {
+			if gate == nil {
+				continue
+			}
+			if err := claim(gates, "stream gate", gate.GetInstanceId(), c.owner); err != nil {
+				return err
+			}
+		}
+		for _, meter := range c.cfg.GetFlowMeters() {
+			if meter == nil {
+				continue
+			}
+			if err := claim(meters, "flow meter", meter.GetInstanceId(), c.owner); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *PsfpNetconfPlugin) Map(msg proto.Message) (any, error) {
+	var cfg *psfp.PsfpConfig
+
+	switch typed := msg.(type) {
+	case *psfp.PsfpConfig:
+		cfg = typed
+	case *topology_config.PortConfig:
+		// Bridge-wide like the bridge's own; ValidateNode keeps ids apart.
+		cfg = typed.GetPsfp()
+	case *topology_config.BridgeConfig:
+		cfg = typed.GetPsfp()
+	default:
+		return nil, fmt.Errorf("invalid message type for PsfpNetconfPlugin: %T", msg)
+	}
+
+	if cfg == nil {
+		return nil, fmt.Errorf("PsfpNetconfPlugin: %T has no PSFP config", msg)
+	}
+
+	p.logger.Printf(
+		"[PSFP] Mapping %d stream filters, %d stream gates, %d flow meters",
+		len(cfg.GetStreamFilters()),
+		len(cfg.GetStreamGates()),
+		len(cfg.GetFlowMeters()),
+	)
+
+	component := &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component{
+		Name: ygot.String("br0"),
+	}
+
+	gateIDs := make(map[string]uint32)
+	if len(cfg.GetStreamGates()) > 0 {
+		component.StreamGates = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates{
+			StreamGateInstanceTable: make(map[uint32]*psfpGate),
+		}
+	}
+
+	for _, gate := range cfg.GetStreamGates() {
+		if gate == nil {
+			continue
+		}
+
+		id := gate.GetInstanceId()
+		if _, dup := component.StreamGates.StreamGateInstanceTable[id]; dup {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: duplicate stream gate instance id %d", id)
+		}
+
+		mapped, err := mapStreamGate(gate)
+		if err != nil {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: stream gate %q: %w", gate.GetName(), err)
+		}
+
+		component.StreamGates.StreamGateInstanceTable[id] = mapped
+		if gate.GetName() != "" {
+			gateIDs[gate.GetName()] = id
+		}
+	}
+
+	meterIDs := make(map[string]uint32)
+	if len(cfg.GetFlowMeters()) > 0 {
+		component.FlowMeters = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_FlowMeters{
+			FlowMeterInstanceTable: make(map[uint32]*psfpMeter),
+		}
+	}
+
+	for _, meter := range cfg.GetFlowMeters() {
+		if meter == nil {
+			continue
+		}
+
+		id := meter.GetInstanceId()
+		if _, dup := component.FlowMeters.FlowMeterInstanceTable[id]; dup {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: duplicate flow meter instance id %d", id)
+		}
+
+		mapped, err := mapFlowMeter(meter)
+		if err != nil {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: flow meter %q: %w", meter.GetName(), err)
+		}
+
+		component.FlowMeters.FlowMeterInstanceTable[id] = mapped
+		if meter.GetName() != "" {
+			meterIDs[meter.GetName()] = id
+		}
+	}
+
+	if len(cfg.GetStreamFilters()) > 0 {
+		component.StreamFilters = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamFilters{
+			StreamFilterInstanceTable: make(map[uint32]*psfpFilter),
+		}
+	}
+
+	for _, filter := range cfg.GetStreamFilters() {
+		if filter == nil {
+			continue
+		}
+
+		idx := filter.GetIndex()
+		if _, dup := component.StreamFilters.StreamFilterInstanceTable[idx]; dup {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: duplicate stream filter index %d", idx)
+		}
+
+		mapped, err := mapStreamFilter(filter, gateIDs, meterIDs)
+		if err != nil {
+			return nil, fmt.Errorf("PsfpNetconfPlugin: stream filter %d: %w", idx, err)
+		}
+
+		component.StreamFilters.StreamFilterInstanceTable[idx] = mapped
+	}
+
+	return &bridgePsfpPayload{
+		BridgeName: "br0",
+		Component:  component,
+	}, nil
+}
+
+func mapStreamGate(gate *psfp.StreamGateInstance) (*psfpGate, error) {
+	mapped := &psfpGate{
+		StreamGateInstanceId: ygot.Uint32(gate.GetInstanceId()),
+		GateEnable:           ygot.Bool(true),
+	}
+
+	// A PSFP gate is either open or closed; any bit set opens it.
+	if states := gate.GetAdminGateStates(); len(states) > 0 {
+		mapped.AdminGateStates = opencncModel.Ieee802Dot1QStreamFiltersGates_GateStateValueType_closed
+		for _, b := range states {
+			if b != 0 {
+				mapped.AdminGateStates = opencncModel.Ieee802Dot1QStreamFiltersGates_GateStateValueType_open
+				break
+			}
+		}
+	}
+
+	if len(gate.GetGateControlList()) == 0 {
+		return mapped, nil
+	}
+
+	mapped.AdminBaseTime = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates_StreamGateInstanceTable_AdminBaseTime{
+		Seconds:     ygot.Uint64(gate.GetBaseTimeNs() / 1e9),
+		Nanoseconds: ygot.Uint32(uint32(gate.GetBaseTimeNs() % 1e9)),
+	}
+
+	cycleTime, err := toUint32(gate.GetCycleTimeNs(), "cycle time")
+	if err != nil {
+		return nil, err
+	}
+
+	mapped.AdminCycleTime = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates_StreamGateInstanceTable_AdminCycleTime{
+		Numerator:   ygot.Uint32(cycleTime),
+		Denominator: ygot.Uint32(1000000000),
+	}
+
+	mapped.AdminControlList = &opencncModel.Ieee802Dot1QBridge_Bridges_Bridge_Component_StreamGates_StreamGateInstanceTable_AdminControlList{
+		GateControlEntry: make(map[uint32]*psfpGateOp),
+	}
+
+	for i, entry := range gate.GetGateControlList() {
+		interval, err := toUint32(entry.GetTimeIntervalNs(), "time interval")
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		op := &psfpGateOp{
+			Index:             ygot.Uint32(uint32(i)),
+			TimeIntervalValue: ygot.Uint32(interval),
+			IpvValue:          opencncModel.Ieee802Dot1QPsfp_IpvSpecType_null, // frames keep their priority
+		}
+
+		if entry.PriorityOverride != nil {
+			if entry.GetPriorityOverride() > 7 {
+				return nil, fmt.Errorf("entry %d: priority override %d out of range 0-7", i, entry.GetPriorityOverride())
+			}
+			op.IpvValue = opencncModel.E_Ieee802Dot1QPsfp_IpvSpecType(entry.GetPriorityOverride() + 1)
+		}
+
+		switch entry.GetGateState() {
+		case psfp.GateState_OPEN:
+			op.GateStateValue = opencncModel.Ieee802Dot1QPsfp_GateStateValueType_open
+		case psfp.GateState_CLOSED:
+			op.GateStateValue = opencncModel.Ieee802Dot1QPsfp_GateStateValueType_closed
+		default:
+			return nil, fmt.Errorf("entry %d: gate state is unspecified", i)
+		}
+
+		mapped.AdminControlList.GateControlEntry[uint32(i)] = op
+	}
+
+	mapped.ConfigChange = ygot.Bool(true)
+
+	return mapped, nil
+}
+
+func mapFlowMeter(meter *psfp.FlowMeterInstance) (*psfpMeter, error) {
+	cbs, err := toUint32(meter.GetCbs(), "committed burst size")
+	if err != nil {
+		return nil, err
+	}
+
+	pbs, err := toUint32(meter.GetPbs(), "peak burst size")
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := &psfpMeter{
+		FlowMeterInstanceId:      ygot.Uint32(meter.GetInstanceId()),
+		CommittedInformationRate: ygot.Uint64(meter.GetCir()),
+		CommittedBurstSize:       ygot.Uint32(cbs),
+		ExcessInformationRate:    ygot.Uint64(meter.GetPir()),
+		ExcessBurstSize:          ygot.Uint32(pbs),
+	}
+
+	switch meter.GetExceededAction() {
+	case psfp.ExceededAction_DROP:
+		mapped.DropOnYellow = ygot.Bool(true)
+	case psfp.ExceededAction_MARK:
+		mapped.DropOnYellow = ygot.Bool(false)
+	case psfp.ExceededAction_POLICE:
+		// An 802.1Qci meter only drops or passes yellow frames, DROP and MARK.
+		return nil, fmt.Errorf("exceeded action %s is not supported", meter.GetExceededAction())
+	}
+
+	return mapped, nil
+}
+
+func mapStreamFilter(filter *psfp.StreamFilterInstance, gateIDs, meterIDs map[string]uint32) (*psfpFilter, error) {
+	mapped := &psfpFilter{
+		StreamFilterInstanceId: ygot.Uint32(filter.GetIndex()),
+		PrioritySpec:           opencncModel.Ieee802Dot1QStreamFiltersGates_PrioritySpecType_wildcard,
+	}
+
+	if filter.StreamHandle != nil {
+		mapped.StreamHandle = ygot.Uint32(filter.GetStreamHandle())
+	} else {
+		mapped.Wildcard = opencncModel.YANGEmpty(true)
+	}
+
+	if spec := filter.GetFilterSpec(); spec != nil {
+		if spec.GetMaxSduSize() > 0 {
+			mapped.MaxSduSize = ygot.Uint32(spec.GetMaxSduSize())
+		}
+		mapped.StreamBlockedDueToOversizeFrameEnabled = ygot.Bool(spec.GetBlockOnExceed())
+
+		if match := spec.GetMatch(); match != nil && match.Priority != nil {
+			pcp := match.GetPriority()
+			if pcp > 7 {
+				return nil, fmt.Errorf("priority %d out of range 0-7", pcp)
+			}
+			mapped.PrioritySpec = opencncModel.E_Ieee802Dot1QStreamFiltersGates_PrioritySpecType(pcp + 1)
+		}
+	}
+
+	if name := filter.GetGateInstanceName(); name != "" {
+		id, ok := gateIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown stream gate %q", name)
+		}
+		mapped.StreamGateRef = ygot.Uint32(id)
+	}
+
+	if name := filter.GetMeterInstanceName(); name != "" {
+		id, ok := meterIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown flow meter %q", name)
+		}
+		mapped.FlowMeterRef = []uint32{id}
+	}
+
+	if filter.AtsSchedulerId != nil {
+		mapped.SchedulerRef = ygot.Uint32(filter.GetAtsSchedulerId())
+	}
+
+	return mapped, nil
+}
+
+func toUint32(v uint64, what string) (uint32, error) {
+	if v > math.MaxUint32 {
+		return 0, fmt.Errorf("%s %d exceeds %d", what, v, uint32(math.MaxUint32))
+	}
+	return uint32(v), nil
+}
+
+func (p *PsfpNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, error) {
+	payload, ok := mapped.(*bridgePsfpPayload)
+	if !ok || payload.Component == nil {
+		return nil, fmt.Errorf("invalid mapped type for PsfpNetconfPlugin: %T", mapped)
+	}
+
+	bridgeName := payload.BridgeName
+	if bridgeName == "" {
+		bridgeName = "br0"
+	}
+	componentName := bridgeName
+	if payload.Component.Name != nil {
+		componentName = *payload.Component.Name
+	}
+
+	component := payload.Component
+
+	var buf bytes.Buffer
+
+	// The feature is anchored in the component, its containers are the roots.
+	if component.FlowMeters != nil {
+		buf.WriteString(fmt.Sprintf(`<flow-meters xmlns="%s">`, nsPsfp))
+		for _, id := range sortedKeys(component.FlowMeters.FlowMeterInstanceTable) {
+			writeFlowMeterXML(&buf, component.FlowMeters.FlowMeterInstanceTable[id])
+		}
+		buf.WriteString(`</flow-meters>`)
+	}
+
+	if component.StreamGates != nil {
+		buf.WriteString(fmt.Sprintf(`<stream-gates xmlns="%s">`, nsPsfp))
+		for _, id := range sortedKeys(component.StreamGates.StreamGateInstanceTable) {
+			writeStreamGateXML(&buf, component.StreamGates.StreamGateInstanceTable[id])
+		}
+		buf.WriteString(`</stream-gates>`)
+	}
+
+	if component.StreamFilters != nil {
+		buf.WriteString(fmt.Sprintf(`<stream-filters xmlns="%s">`, nsPsfp))
+		for _, id := range sortedKeys(component.StreamFilters.StreamFilterInstanceTable) {
+			writeStreamFilterXML(&buf, component.StreamFilters.StreamFilterInstanceTable[id])
+		}
+		buf.WriteString(`</stream-filters>`)
+	}
+
+	return &plugins.FeatureXML{
+		Container: "psfp",
+		XML:       buf.Bytes(),
+		Anchor:    plugins.BridgeComponentAnchor(bridgeName, componentName),
+	}, nil
+}
+
+func writeFlowMeterXML(buf *bytes.Buffer, meter *psfpMeter) {
+	buf.WriteString(`<flow-meter-instance-table>`)
+	buf.WriteString(fmt.Sprintf(`<flow-meter-instance-id>%d</flow-meter-instance-id>`, *meter.FlowMeterInstanceId))
+	buf.WriteString(fmt.Sprintf(`<committed-information-rate>%d</committed-information-rate>`, *meter.CommittedInformationRate))
+	buf.WriteString(fmt.Sprintf(`<committed-burst-size>%d</committed-burst-size>`, *meter.CommittedBurstSize))
+	buf.WriteString(fmt.Sprintf(`<excess-information-rate>%d</excess-information-rate>`, *meter.ExcessInformationRate))
+	buf.WriteString(fmt.Sprintf(`<excess-burst-size>%d</excess-burst-size>`, *meter.ExcessBurstSize))
+	if meter.DropOnYellow != nil {
+		buf.WriteString(fmt.Sprintf(`<drop-on-yellow>%t</drop-on-yellow>`, *meter.DropOnYellow))
+	}
+	if meter.MarkAllFramesRedEnable != nil {
+		buf.WriteString(fmt.Sprintf(`<mark-all-frames-red-enable>%t</mark-all-frames-red-enable>`, *meter.MarkAllFramesRedEnable))
+	}
+	buf.WriteString(`</flow-meter-instance-table>`)
+}
+
+func writeStreamGateXML(buf *bytes.Buffer, gate *psfpGate) {
+	buf.WriteString(`<stream-gate-instance-table>`)
+	buf.WriteString(fmt.Sprintf(`<stream-gate-instance-id>%d</stream-gate-instance-id>`, *gate.StreamGateInstanceId))
+
+	if gate.GateEnable != nil {
+		buf.WriteString(fmt.Sprintf(`<gate-enable>%t</gate-enable>`, *gate.GateEnable))
+	}
+
+	if gate.AdminGateStates != opencncModel.Ieee802Dot1QStreamFiltersGates_GateStateValueType_UNSET {
+		buf.WriteString(fmt.Sprintf(`<admin-gate-states>%s</admin-gate-states>`, gate.AdminGateStates.String()))
+	}
+
+	if gate.AdminControlList != nil {
+		buf.WriteString(`<admin-control-list>`)
+		for _, idx := range sortedKeys(gate.AdminControlList.GateControlEntry) {
+			entry := gate.AdminControlList.GateControlEntry[idx]
+			buf.WriteString(`<gate-control-entry>`)
+			buf.WriteString(fmt.Sprintf(`<index>%d</index>`, *entry.Index))
+			buf.WriteString(fmt.Sprintf(`<gate-state-value>%s</gate-state-value>`, entry.GateStateValue.String()))
+			buf.WriteString(fmt.Sprintf(`<ipv-value>%s</ipv-value>`, entry.IpvValue.String()))
+			buf.WriteString(fmt.Sprintf(`<time-interval-value>%d</time-interval-value>`, *entry.TimeIntervalValue))
+			buf.WriteString(`</gate-control-entry>`)
+		}
+		buf.WriteString(`</admin-control-list>`)
+	}
+
+	if gate.AdminCycleTime != nil {
+		buf.WriteString(`<admin-cycle-time>`)
+		buf.WriteString(fmt.Sprintf(`<numerator>%d</numerator>`, *gate.AdminCycleTime.Numerator))
+		buf.WriteString(fmt.Sprintf(`<denominator>%d</denominator>`, *gate.AdminCycleTime.Denominator))
+		buf.WriteString(`</admin-cycle-time>`)
+	}
+
+	if gate.AdminBaseTime != nil {
+		buf.WriteString(`<admin-base-time>`)
+		buf.WriteString(fmt.Sprintf(`<seconds>%d</seconds>`, *gate.AdminBaseTime.Seconds))
+		buf.WriteString(fmt.Sprintf(`<nanoseconds>%d</nanoseconds>`, *gate.AdminBaseTime.Nanoseconds))
+		buf.WriteString(`</admin-base-time>`)
+	}
+
+	if gate.ConfigChange != nil {
+		buf.WriteString(fmt.Sprintf(`<config-change>%t</config-change>`, *gate.ConfigChange))
+	}
+
+	buf.WriteString(`</stream-gate-instance-table>`)
+}
+
+func writeStreamFilterXML(buf *bytes.Buffer, filter *psfpFilter) {
+	buf.WriteString(`<stream-filter-instance-table>`)
+	buf.WriteString(fmt.Sprintf(`<stream-filter-instance-id>%d</stream-filter-instance-id>`, *filter.StreamFilterInstanceId))
+
+	if filter.StreamHandle != nil {
+		buf.WriteString(fmt.Sprintf(`<stream-handle>%d</stream-handle>`, *filter.StreamHandle))
+	} else {
+		buf.WriteString(`<wildcard/>`)
+	}
+
+	buf.WriteString(fmt.Sprintf(`<priority-spec>%s</priority-spec>`, filter.PrioritySpec.String()))
+
+	if filter.MaxSduSize != nil {
+		buf.WriteString(fmt.Sprintf(`<max-sdu-size>%d</max-sdu-size>`, *filter.MaxSduSize))
+	}
+
+	if filter.StreamBlockedDueToOversizeFrameEnabled != nil {
+		buf.WriteString(fmt.Sprintf(`<stream-blocked-due-to-oversize-frame-enabled>%t</stream-blocked-due-to-oversize-frame-enabled>`, *filter.StreamBlockedDueToOversizeFrameEnabled))
+	}
+
+	if filter.StreamGateRef != nil {
+		buf.WriteString(fmt.Sprintf(`<stream-gate-ref>%d</stream-gate-ref>`, *filter.StreamGateRef))
+	}
+
+	for _, ref := range filter.FlowMeterRef {
+		buf.WriteString(fmt.Sprintf(`<flow-meter-ref>%d</flow-meter-ref>`, ref))
+	}
+
+	if filter.SchedulerRef != nil {
+		buf.WriteString(fmt.Sprintf(`<scheduler-ref xmlns="%s">%d</scheduler-ref>`, nsAts, *filter.SchedulerRef))
+	}
+
+	buf.WriteString(`</stream-filter-instance-table>`)
+}
+
+func sortedKeys[V any](m map[uint32]V) []uint32 {
+	keys := make([]uint32, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func (p *PsfpNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarget) error {
+	featurexml, err := p.BuildFeatureXML(mapped)
+	if err != nil {
+		return fmt.Errorf("failed to build feature XML: %w", err)
+	}
+
+	return pushAnchoredFeature(featurexml, target, p.logger)
+}
@@ -19,7 +19,11 @@ import (
 )
 
 // Ensure it implements Plugin interface
-var _ plugins.Plugin = (*OldQbvNetconfPlugin)(nil)
+var (
+	_ plugins.Plugin        = (*OldQbvNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner  = (*OldQbvNetconfPlugin)(nil)
+	_ plugins.NodeValidator = (*OldQbvNetconfPlugin)(nil)
+)
 
 type OldQbvNetconfPlugin struct {
 	logger observability.Logger
@@ -47,6 +51,10 @@ func (p *OldQbvNetconfPlugin) FeatureName() string {
 	return "qbv"
 }
 
+func (p *OldQbvNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
 func (p *OldQbvNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
 	requiredYangs := []devicemodelregistry.YangFile{
 		{Name: "ieee802-dot1q-sched.yang", Revision: "2018-09-10"},
@@ -79,6 +87,11 @@ func (p *OldQbvNetconfPlugin) SupportedFields(msg proto.Message) []string {
 	}
 }
 
+// ValidateNode rejects schedules on nodes where time synchronization is disabled.
+func (p *OldQbvNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	return validateQbvTimeSync(cfg)
+}
+
 func (p *OldQbvNetconfPlugin) Map(msg proto.Message) (any, error) {
 	gcl, ok := msg.(*qbv.GateControlList)
 	if !ok {
@@ -229,11 +242,11 @@ func (p *OldQbvNetconfPlugin) Push(mapped any, target managementSessions.DeviceT
 
 	//p.logger.Printf("[OldQBV] XML generated for interface %s", target.InterfaceName)
 
-	session, err := managementSessions.CreateSession(target.Info.IpAddress, target.Info.UserName, target.Secret)
+	session, err := target.Connect()
 	if err != nil {
 		return fmt.Errorf("NETCONF session failed: %w", err)
 	}
-	defer session.Close()
+	defer managementSessions.CloseSession(session)
 
 	if err := managementSessions.EditConfig(session, xmlStr); err != nil {
 		return fmt.Errorf("edit-config failed: %w", err)
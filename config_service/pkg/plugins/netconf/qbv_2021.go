@@ -3,6 +3,7 @@ package netconf
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"sort"
 
 	"OpenCNC_config_service/common/observability"
@@ -19,7 +20,12 @@ import (
 )
 
 // Ensure it implements the Plugin interface.
-var _ plugins.Plugin = (*QbvNetconfPlugin)(nil)
+var (
+	_ plugins.Plugin            = (*QbvNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner      = (*QbvNetconfPlugin)(nil)
+	_ plugins.CapabilityChecker = (*QbvNetconfPlugin)(nil)
+	_ plugins.NodeValidator     = (*QbvNetconfPlugin)(nil)
+)
 
 type QbvNetconfPlugin struct {
 	logger observability.Logger
@@ -47,6 +53,10 @@ func (p *QbvNetconfPlugin) FeatureName() string {
 	return "qbv"
 }
 
+func (p *QbvNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeInterfaces}
+}
+
 func (p *QbvNetconfPlugin) SupportedFields(msg proto.Message) []string {
 	if _, ok := msg.(*topology_config.PortConfig); !ok {
 		return nil
@@ -88,6 +98,19 @@ func (p *QbvNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceMo
 	return true
 }
 
+// SupportedByCapabilities requires the scheduled-traffic feature, which the
+// gate-parameter-table depends on. Modules only found in the schema list do
+// not tell their features and are accepted.
+func (p *QbvNetconfPlugin) SupportedByCapabilities(caps *managementSessions.Capabilities) bool {
+	sched := caps.Module("ieee802-dot1q-sched")
+	return sched != nil && (sched.Listed || slices.Contains(sched.Features, "scheduled-traffic"))
+}
+
+// ValidateNode rejects schedules on nodes where time synchronization is disabled.
+func (p *QbvNetconfPlugin) ValidateNode(cfg *topology_config.NodeConfig) error {
+	return validateQbvTimeSync(cfg)
+}
+
 func (p *QbvNetconfPlugin) Map(msg proto.Message) (any, error) {
 	gcl, ok := msg.(*qbv.GateControlList)
 	if !ok {
@@ -217,15 +240,11 @@ func (p *QbvNetconfPlugin) Push(mapped any, target managementSessions.DeviceTarg
 		return fmt.Errorf("failed to build XML: %w", err)
 	}
 
-	session, err := managementSessions.CreateSession(
-		target.Info.IpAddress,
-		target.Info.UserName,
-		target.Secret,
-	)
+	session, err := target.Connect()
 	if err != nil {
 		return fmt.Errorf("NETCONF session failed: %w", err)
 	}
-	defer session.Close()
+	defer managementSessions.CloseSession(session)
 
 	if err := managementSessions.EditConfig(session, xmlStr); err != nil {
 		return fmt.Errorf("edit-config failed: %w", err)
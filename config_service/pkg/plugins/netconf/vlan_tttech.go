@@ -19,7 +19,10 @@ import (
 	"github.com/openconfig/ygot/ygot"
 )
 
-var _ plugins.Plugin = (*VlanNetconfPlugin)(nil)
+var (
+	_ plugins.Plugin       = (*VlanNetconfPlugin)(nil)
+	_ plugins.SubtreeOwner = (*VlanNetconfPlugin)(nil)
+)
 
 type VlanNetconfPlugin struct {
 	logger observability.Logger
@@ -53,6 +56,10 @@ func (v *VlanNetconfPlugin) FeatureName() string {
 	return "Vlan"
 }
 
+func (v *VlanNetconfPlugin) OwnedSubtrees() []string {
+	return []string{plugins.SubtreeBridges, plugins.SubtreeInterfaces}
+}
+
 func (v *VlanNetconfPlugin) SupportedByDevice(model *devicemodelregistry.DeviceModel) bool {
 	requiredYangs := []devicemodelregistry.YangFile{{
 		Name:     "ieee802-dot1q-bridge.yang",
@@ -244,27 +251,31 @@ func (v *VlanNetconfPlugin) Push(mapped any, target managementSessions.DeviceTar
 		return fmt.Errorf("failed to build feature XML: %w", err)
 	}
 
-	xml := string(featurexml.XML)
+	var xml string
 	if featurexml.Container == "bridge-port" {
 		if target.Info == nil {
 			return fmt.Errorf("device target info is nil")
 		}
 
 		xml, err = v.wrapXML(featurexml, target)
-		if err != nil {
-			return fmt.Errorf("failed to build XML: %w", err)
-		}
+	} else {
+		var wrapped []byte
+		wrapped, err = featurexml.Wrap(target.InterfaceName)
+		xml = string(wrapped)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build XML: %w", err)
 	}
 
 	if target.Info == nil {
 		return fmt.Errorf("device target info is nil")
 	}
 
-	session, err := managementSessions.CreateSession(target.Info.IpAddress, target.Info.UserName, target.Secret)
+	session, err := target.Connect()
 	if err != nil {
 		return fmt.Errorf("NETCONF session failed: %w", err)
 	}
-	defer session.Close()
+	defer managementSessions.CloseSession(session)
 
 	pushXML := func(xml string, label string) error {
 		if v.logger != nil {
@@ -289,17 +300,13 @@ func (v *VlanNetconfPlugin) BuildFeatureXML(mapped any) (*plugins.FeatureXML, er
 	case *opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort:
 		return v.buildBridgePortFeatureXML(typed)
 	case *bridgeVlanPayload:
-		xml, err := v.buildBridgeVlanXML(typed)
-		if err != nil {
-			return nil, err
-		}
-		return &plugins.FeatureXML{Container: "bridges", XML: xml}, nil
+		return v.buildBridgeVlanFeatureXML(typed)
 	default:
 		return nil, fmt.Errorf("VlanNetconfPlugin: invalid mapped type %T", mapped)
 	}
 }
 
-func (v *VlanNetconfPlugin) buildBridgeVlanXML(payload *bridgeVlanPayload) ([]byte, error) {
+func (v *VlanNetconfPlugin) buildBridgeVlanFeatureXML(payload *bridgeVlanPayload) (*plugins.FeatureXML, error) {
 	if payload == nil || payload.Config == nil {
 		return nil, fmt.Errorf("VlanNetconfPlugin: nil bridge VLAN payload")
 	}
@@ -314,11 +321,6 @@ func (v *VlanNetconfPlugin) buildBridgeVlanXML(payload *bridgeVlanPayload) ([]by
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(`<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">`)
-	buf.WriteString(`<bridge>`)
-	buf.WriteString(fmt.Sprintf(`<name>%s</name>`, bridgeName))
-	buf.WriteString(`<component>`)
-	buf.WriteString(fmt.Sprintf(`<name>%s</name>`, componentName))
 
 	if len(payload.Config.GetVlanRegistrationEntries()) > 0 {
 		buf.WriteString(`<filtering-database>`)
@@ -365,11 +367,11 @@ func (v *VlanNetconfPlugin) buildBridgeVlanXML(payload *bridgeVlanPayload) ([]by
 		buf.WriteString(`</bridge-vlan>`)
 	}
 
-	buf.WriteString(`</component>`)
-	buf.WriteString(`</bridge>`)
-	buf.WriteString(`</bridges>`)
-
-	return buf.Bytes(), nil
+	return &plugins.FeatureXML{
+		Container: "bridge-vlan",
+		XML:       buf.Bytes(),
+		Anchor:    plugins.BridgeComponentAnchor(bridgeName, componentName),
+	}, nil
 }
 
 func (v *VlanNetconfPlugin) buildBridgePortFeatureXML(root *opencncModel.IETFInterfaces_Interfaces_Interface_BridgePort) (*plugins.FeatureXML, error) {
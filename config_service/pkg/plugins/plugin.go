@@ -2,6 +2,8 @@ package plugins
 
 import (
 	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
 	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
 
 	"github.com/golang/protobuf/proto"
@@ -11,13 +13,53 @@ type Plugin interface {
 	Name() string
 	FeatureName() string
 	SupportedByDevice(model *devicemodelregistry.DeviceModel) bool // returns true if the feature is supported by the device model: check is based on yang files names and revisions. it does not guarantee: leaf availability ,RPC support, full subtree support
-	SupportedFields(msg proto.Message) []string                    // returns supported field names for the provided structure; empty means unsupported
+	SupportedFields(msg proto.Message) []string                    // returns supported field names for the provided structure (PortConfig, or BridgeConfig, EndStationConfig, BridgedEndStationConfig for node-scoped features); empty means unsupported
 	Map(msg proto.Message) (any, error)
 	Push(mapped any, target managementSessions.DeviceTarget) error
 	BuildFeatureXML(root any) (*FeatureXML, error)
 }
 
+// CapabilityChecker is implemented by plugins that depend on more than module
+// names and revisions, e.g. YANG features. Backends call it, after
+// SupportedByDevice, when they know the capabilities the device advertised.
+type CapabilityChecker interface {
+	SupportedByCapabilities(caps *managementSessions.Capabilities) bool
+}
+
+// PortValidator is implemented by plugins whose configuration is bounded by
+// the port it applies to, e.g. by its speed. Backends call it before Map with
+// the topology port of the PortConfig, nil if the topology does not know it.
+type PortValidator interface {
+	ValidatePort(cfg *topology_config.PortConfig, port *topology.Port) error
+}
+
+// NodeValidator is implemented by plugins whose configuration depends on the
+// rest of the node, e.g. on its role configuration. Backends call it before
+// mapping any port of the node.
+type NodeValidator interface {
+	ValidateNode(cfg *topology_config.NodeConfig) error
+}
+
+// SubtreeOwner is implemented by plugins that can name the top-level
+// configuration subtrees they write. Backends use them as subtree filters so
+// snapshots only hold configuration some plugin manages.
+type SubtreeOwner interface {
+	OwnedSubtrees() []string // subtree filter elements, e.g. SubtreeInterfaces
+}
+
+// Subtree filters for the top-level containers written by the plugins.
+const (
+	SubtreeInterfaces = `<interfaces xmlns="urn:ietf:params:xml:ns:yang:ietf-interfaces"/>`
+	SubtreeBridges    = `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge"/>`
+)
+
 type FeatureXML struct {
 	Container string
 	XML       []byte
+
+	// Anchor places node-scoped features: the path of the datastore node
+	// their root elements are merged into, e.g. BridgeComponentAnchor, or
+	// TopLevelAnchor for features made of top-level nodes. Nil for features
+	// placed by Container.
+	Anchor []AnchorStep
 }
@@ -1,6 +1,8 @@
 package protocolbackends
 
 import (
+	"context"
+
 	"OpenCNC_config_service/common/structures/topology"
 	topology_config "OpenCNC_config_service/common/structures/topology_config"
 	"OpenCNC_config_service/config_service/pkg/managementSessions"
@@ -14,9 +16,50 @@ type ProtocolBackend interface {
 	AddPlugin(plugin plugins.Plugin)
 	Plugins() []plugins.Plugin
 
-	PrepareSnapshot(msg *topology_config.NodeConfig, node *topology.Node) error
-	Commit(target *topology.Node) error
-	Rollback(target *topology.Node) error
+	// The engine runs these concurrently for different nodes; ctx carries the
+	// per-node deadline of the transaction phase.
+	PrepareSnapshot(ctx context.Context, msg *topology_config.NodeConfig, node *topology.Node) error
+	Commit(ctx context.Context, target *topology.Node) error
+	Rollback(ctx context.Context, target *topology.Node) error
+}
+
+// Confirmer is implemented by backends whose Commit leaves a node in a
+// provisional state that the device reverts on its own unless confirmed.
+// The engine calls Confirm only once every node of the transaction committed;
+// Rollback of an unconfirmed node cancels the pending commit.
+type Confirmer interface {
+	Confirm(ctx context.Context, target *topology.Node) error
+}
+
+// Locker is implemented by backends that can lock a node's configuration
+// datastore. The engine locks every node before preparing a transaction and
+// unlocks them once it committed or rolled back.
+type Locker interface {
+	Lock(ctx context.Context, target *topology.Node) error
+	Unlock(ctx context.Context, target *topology.Node) error
+}
+
+// HealthChecker is implemented by backends that track device reachability,
+// so the engine can fail fast before locking or preparing any node.
+type HealthChecker interface {
+	CheckHealth(target *topology.Node) error
+}
+
+// SnapshotSyncer is implemented by backends that can (re)read a node's
+// configuration from the device into its snapshot set on demand.
+type SnapshotSyncer interface {
+	SyncSnapshot(ctx context.Context, target *topology.Node) error
+}
+
+// SnapshotRestorer is implemented by backends whose snapshots can be exported
+// and pushed back later, so the engine can roll nodes back to any recorded
+// configuration version.
+type SnapshotRestorer interface {
+	// Snapshots returns the Current and LastStable snapshots of a node.
+	Snapshots(node string) (current, lastStable []byte, ok bool)
+	// RestoreSnapshot prepares the node to be committed back to a snapshot
+	// returned by Snapshots, in place of PrepareSnapshot.
+	RestoreSnapshot(ctx context.Context, target *topology.Node, snapshot []byte) error
 }
 
 type Snapshot interface {
@@ -1,11 +1,16 @@
 package protocolbackends
 
 import (
+	"context"
+	"crypto/x509"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 
 	"OpenCNC_config_service/common/observability"
 	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
 	"OpenCNC_config_service/common/structures/topology"
 	topology_config "OpenCNC_config_service/common/structures/topology_config"
 	"OpenCNC_config_service/config_service/pkg/managementSessions"
@@ -13,12 +18,21 @@ import (
 
 	"github.com/beevik/etree"
 	"github.com/golang/protobuf/proto"
+	"github.com/openshift-telco/go-netconf-client/netconf"
 )
 
-var _ ProtocolBackend = (*NetconfBackend)(nil)
+var (
+	_ ProtocolBackend  = (*NetconfBackend)(nil)
+	_ Confirmer        = (*NetconfBackend)(nil)
+	_ SnapshotSyncer   = (*NetconfBackend)(nil)
+	_ Locker           = (*NetconfBackend)(nil)
+	_ HealthChecker    = (*NetconfBackend)(nil)
+	_ SnapshotRestorer = (*NetconfBackend)(nil)
+)
 
 type NetconfSnapshot struct {
-	XML []byte // parsed model, cached payload, metadata...
+	XML  []byte // parsed model, cached payload, metadata...
+	Edit []byte // edit-config payload pushed instead of merging XML, set for restores
 
 }
 
@@ -29,6 +43,14 @@ func (s *NetconfSnapshot) Clone() Snapshot {
 	}
 }
 
+// payload returns the edit-config payload writing the snapshot to a device.
+func (s *NetconfSnapshot) payload() []byte {
+	if s.Edit != nil {
+		return s.Edit
+	}
+	return s.XML
+}
+
 func (s *NetconfSnapshot) Update(feature *plugins.FeatureXML, target managementSessions.DeviceTarget) error {
 
 	if feature == nil {
@@ -45,6 +67,68 @@ func (s *NetconfSnapshot) Update(feature *plugins.FeatureXML, target managementS
 		return fmt.Errorf("failed parsing snapshot XML: %w", err)
 	}
 
+	// Parse new feature subtree.
+	featureDoc := etree.NewDocument()
+
+	if err := featureDoc.ReadFromBytes(feature.XML); err != nil {
+		return fmt.Errorf(
+			"failed parsing feature XML: %w",
+			err,
+		)
+	}
+
+	if featureDoc.Root() == nil {
+		return fmt.Errorf(
+			"feature XML has no root element",
+		)
+	}
+
+	switch {
+	case len(feature.Anchor) > 0:
+		anchor, err := plugins.ResolveAnchor(feature.Anchor, target.InterfaceName)
+		if err != nil {
+			return fmt.Errorf("invalid feature anchor: %w", err)
+		}
+		mergeNodes(anchorElement(doc, anchor), featureDoc.ChildElements())
+	case feature.Anchor != nil:
+		mergeTopLevel(doc, featureDoc.ChildElements())
+	default:
+		if err := updateInterface(doc, featureDoc.Root(), feature.Container, target); err != nil {
+			return err
+		}
+	}
+
+	// Store updated snapshot.
+	doc.Indent(2)
+
+	updatedXML, err := doc.WriteToBytes()
+	if err != nil {
+		return fmt.Errorf(
+			"failed serializing updated snapshot: %w",
+			err,
+		)
+	}
+
+	s.XML = updatedXML
+
+	return nil
+}
+
+// topologyPort returns the port of the node with the id, or name, portID.
+func topologyPort(node *topology.Node, portID string) *topology.Port {
+
+	for _, port := range node.GetPorts() {
+		if port.GetId() == portID || port.GetName() == portID {
+			return port
+		}
+	}
+
+	return nil
+}
+
+// updateInterface replaces the feature subtree of the target interface.
+func updateInterface(doc *etree.Document, feature *etree.Element, container string, target managementSessions.DeviceTarget) error {
+
 	interfaces := doc.FindElement("//interfaces")
 	if interfaces == nil {
 		return fmt.Errorf("snapshot does not contain <interfaces>")
@@ -74,45 +158,142 @@ func (s *NetconfSnapshot) Update(feature *plugins.FeatureXML, target managementS
 	}
 
 	// Remove existing feature subtree.
-	if existing := interfaceElement.FindElement(feature.Container); existing != nil {
+	if existing := interfaceElement.FindElement(container); existing != nil {
 		interfaceElement.RemoveChild(existing)
 	}
 
-	// Parse new feature subtree.
-	featureDoc := etree.NewDocument()
+	// Add the updated feature subtree.
+	interfaceElement.AddChild(feature.Copy())
 
-	if err := featureDoc.ReadFromBytes(feature.XML); err != nil {
-		return fmt.Errorf(
-			"failed parsing feature XML: %w",
-			err,
-		)
+	return nil
+}
+
+// anchorElement returns the snapshot node at the anchor, creating the nodes
+// missing on the way.
+func anchorElement(doc *etree.Document, anchor []plugins.AnchorStep) *etree.Element {
+
+	parent := &doc.Element
+
+	for _, step := range anchor {
+
+		var next *etree.Element
+
+		for _, child := range parent.SelectElements(step.Name) {
+			if step.Key == "" {
+				next = child
+				break
+			}
+			if key := child.SelectElement(step.Key); key != nil && key.Text() == step.Value {
+				next = child
+				break
+			}
+		}
+
+		if next == nil {
+			next = parent.CreateElement(step.Name)
+			if step.Namespace != "" {
+				next.CreateAttr("xmlns", step.Namespace)
+			}
+			if step.Key != "" {
+				next.CreateElement(step.Key).SetText(step.Value)
+			}
+		}
+
+		parent = next
 	}
 
-	if featureDoc.Root() == nil {
-		return fmt.Errorf(
-			"feature XML has no root element",
-		)
+	return parent
+}
+
+// mergeNodes merges the root nodes of an anchored feature into the anchor.
+// Leaves replace the leaves with the same tag, leaf-lists as a whole;
+// containers are merged with mergeContainer.
+func mergeNodes(parent *etree.Element, nodes []*etree.Element) {
+
+	var leaves []*etree.Element
+
+	for _, node := range nodes {
+
+		if len(node.ChildElements()) > 0 {
+			mergeContainer(parent, node)
+			continue
+		}
+
+		for _, old := range parent.SelectElements(node.Tag) {
+			parent.RemoveChild(old)
+		}
+
+		leaves = append(leaves, node)
 	}
 
-	// Add the updated feature subtree.
-	interfaceElement.AddChild(
-		featureDoc.Root().Copy(),
-	)
+	// Added last, so entries of a leaf-list do not replace each other.
+	for _, leaf := range leaves {
+		parent.AddChild(leaf.Copy())
+	}
+}
 
-	// Store updated snapshot.
-	doc.Indent(2)
+// mergeTopLevel merges features anchored with plugins.TopLevelAnchor into the
+// snapshot. A node whose first child is a leaf is taken as a list entry and
+// replaces the entry with the same tag and key; other nodes are merged as
+// containers.
+func mergeTopLevel(doc *etree.Document, nodes []*etree.Element) {
 
-	updatedXML, err := doc.WriteToBytes()
-	if err != nil {
-		return fmt.Errorf(
-			"failed serializing updated snapshot: %w",
-			err,
-		)
+	for _, node := range nodes {
+
+		first := node.ChildElements()
+		if len(first) == 0 || len(first[0].ChildElements()) > 0 {
+			mergeContainer(&doc.Element, node)
+			continue
+		}
+
+		for _, old := range doc.SelectElements(node.Tag) {
+			if sameKey(old, node) {
+				doc.RemoveChild(old)
+			}
+		}
+
+		doc.AddChild(node.Copy())
 	}
+}
 
-	s.XML = updatedXML
+// mergeContainer adds the container to parent, replacing leaves with the same
+// tag and list entries with the same tag and key, the key being taken as the
+// entry's first element. Leaf-lists are replaced as a whole.
+func mergeContainer(parent *etree.Element, container *etree.Element) {
 
-	return nil
+	existing := parent.SelectElement(container.Tag)
+	if existing == nil {
+		parent.AddChild(container.Copy())
+		return
+	}
+
+	entries := container.ChildElements()
+
+	// Remove everything the feature replaces before adding its entries, so
+	// entries of a leaf-list do not replace each other.
+	for _, entry := range entries {
+		for _, old := range existing.SelectElements(entry.Tag) {
+			if sameKey(old, entry) {
+				existing.RemoveChild(old)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		existing.AddChild(entry.Copy())
+	}
+}
+
+func sameKey(a, b *etree.Element) bool {
+
+	ak, bk := a.ChildElements(), b.ChildElements()
+
+	if len(ak) == 0 || len(bk) == 0 {
+		// Leaves, or containers without children.
+		return len(ak) == len(bk)
+	}
+
+	return ak[0].Tag == bk[0].Tag && ak[0].Text() == bk[0].Text()
 }
 
 //-----------------------------------
@@ -120,11 +301,25 @@ func (s *NetconfSnapshot) Update(feature *plugins.FeatureXML, target managementS
 //-----------------------------------
 
 type NetconfBackend struct {
-	name      string
-	protocol  topology.ManagementProtocol
-	plugins   []plugins.Plugin
-	logger    observability.Logger
-	snapshots map[string]*SnapshotSet[*NetconfSnapshot]
+	name     string
+	protocol topology.ManagementProtocol
+	plugins  []plugins.Plugin
+	logger   observability.Logger
+
+	pool            *managementSessions.SessionPool
+	credentials     managementSessions.CredentialProvider
+	hostKeys        *managementSessions.KnownHosts
+	store           storewrapper.Store // device models
+	rootCAs         *x509.CertPool     // CAs of TLS devices, the system roots if nil
+	confirmTimeout  time.Duration
+	filterSnapshots bool // limit snapshots to the subtrees owned by the plugins
+	discoverSchemas bool // complete <hello> modules with the monitoring schema list
+	events          EventEmitter
+
+	mu           sync.Mutex // guards the maps below, and Current and LastStable of the sets, read outside their node's worker
+	snapshots    map[string]*SnapshotSet[*NetconfSnapshot]
+	sessions     map[string]*nodeSession // sessions kept open across calls, by node
+	capabilities map[string]*nodeCapabilities
 }
 
 func NewNetconfBackend(name string, logger observability.Logger, plugins ...plugins.Plugin) *NetconfBackend {
@@ -134,9 +329,56 @@ func NewNetconfBackend(name string, logger observability.Logger, plugins ...plug
 		plugins:   plugins,
 		logger:    observability.NormalizeLogger(logger),
 		snapshots: make(map[string]*SnapshotSet[*NetconfSnapshot]),
+		sessions:  make(map[string]*nodeSession),
+
+		capabilities: make(map[string]*nodeCapabilities),
+
+		pool:            managementSessions.NewSessionPool(logger, managementSessions.PoolConfig{}),
+		credentials:     managementSessions.CredentialChain{managementSessions.EnvCredentials{}},
+		hostKeys:        managementSessions.NewKnownHosts(managementSessions.HostKeyTOFU, managementSessions.NewMemoryHostKeyStore(), logger, nil),
+		confirmTimeout:  managementSessions.DefaultConfirmTimeout,
+		filterSnapshots: true,
 	}
 }
 
+// SetSessionPool replaces the default session pool, e.g. to share one pool
+// between backends or to tune its limits. It must be called before first use.
+func (b *NetconfBackend) SetSessionPool(pool *managementSessions.SessionPool) {
+	b.pool.Close()
+	b.pool = pool
+}
+
+// SetCredentialProvider replaces the default provider, which reads the
+// NETCONF_* environment variables. It must be called before first use.
+func (b *NetconfBackend) SetCredentialProvider(provider managementSessions.CredentialProvider) {
+	b.credentials = provider
+}
+
+// SetKnownHosts replaces the default host key verification, which pins keys
+// on first use in memory only. It must be called before first use.
+func (b *NetconfBackend) SetKnownHosts(hostKeys *managementSessions.KnownHosts) {
+	b.hostKeys = hostKeys
+}
+
+// SetStore sets the k/v store the device models of the nodes are read from,
+// and the snapshots of the nodes persisted to. It must be called before first use.
+func (b *NetconfBackend) SetStore(store storewrapper.Store) {
+	b.store = store
+}
+
+// SetRootCAs sets the CAs that sign the certificates of devices managed over
+// TLS. It must be called before first use.
+func (b *NetconfBackend) SetRootCAs(pool *x509.CertPool) {
+	b.rootCAs = pool
+}
+
+// SetConfirmTimeout sets the confirm-timeout sent with confirmed commits.
+// It must cover the commit phase of the whole transaction, since nodes are
+// only confirmed once every node has committed.
+func (b *NetconfBackend) SetConfirmTimeout(timeout time.Duration) {
+	b.confirmTimeout = timeout
+}
+
 func (b *NetconfBackend) Name() string {
 	return b.name
 }
@@ -153,7 +395,15 @@ func (b *NetconfBackend) Plugins() []plugins.Plugin {
 	return b.plugins
 }
 
-func (b *NetconfBackend) PrepareSnapshot(msg *topology_config.NodeConfig, node *topology.Node) error {
+func (b *NetconfBackend) snapshotSet(node string) (*SnapshotSet[*NetconfSnapshot], bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	set, ok := b.snapshots[node]
+	return set, ok
+}
+
+func (b *NetconfBackend) PrepareSnapshot(ctx context.Context, msg *topology_config.NodeConfig, node *topology.Node) error {
 	logger := b.logger
 
 	if node == nil {
@@ -165,7 +415,19 @@ func (b *NetconfBackend) PrepareSnapshot(msg *topology_config.NodeConfig, node *
 		return fmt.Errorf("PrepareSnapshot: nodeConfig is nil")
 	}
 
-	snapshotSet, ok := b.snapshots[node.Name]
+	//
+	// Bootstrap the snapshot on first use, or resync it if the
+	// device configuration drifted since the last transaction.
+	//
+	if err := b.SyncSnapshot(ctx, node); err != nil {
+		return fmt.Errorf(
+			"failed to sync snapshot for node %s: %w",
+			node.Name,
+			err,
+		)
+	}
+
+	snapshotSet, ok := b.snapshotSet(node.Name)
 	if !ok {
 		return fmt.Errorf("no snapshot exists for node %s", node.Name)
 	}
@@ -182,7 +444,11 @@ func (b *NetconfBackend) PrepareSnapshot(msg *topology_config.NodeConfig, node *
 
 	modelName := node.DeviceInfo.GetDeviceModel()
 
-	nodeDeviceModel, err := storewrapper.GetDeviceModel(modelName)
+	if b.store == nil {
+		return fmt.Errorf("no store to retrieve device model %q from", modelName)
+	}
+
+	nodeDeviceModel, err := storewrapper.GetDeviceModel(ctx, b.store, modelName)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to retrieve device model %q: %w",
@@ -191,30 +457,53 @@ func (b *NetconfBackend) PrepareSnapshot(msg *topology_config.NodeConfig, node *
 		)
 	}
 
+	deviceModel, caps := b.runtimeDeviceModel(ctx, node, nodeDeviceModel)
+
+	creds, err := b.resolveCredentials(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range b.plugins {
+		validator, ok := plugin.(plugins.NodeValidator)
+		if !ok || !supportedByDevice(plugin, deviceModel, caps) {
+			continue
+		}
+
+		if err := validator.ValidateNode(nodeConfig); err != nil {
+			return fmt.Errorf("%s: node %s: %w", plugin.Name(), node.Name, err)
+		}
+	}
+
 	for _, portConfig := range nodeConfig.PortConfigs {
 
 		if portConfig == nil {
 			continue
 		}
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		logger.Printf("======================================================")
 		logger.Printf("Processing port %q", portConfig.PortId)
 
 		target := managementSessions.DeviceTarget{
 			InterfaceName: portConfig.PortId,
 			Logger:        logger,
-			Secret:        "",
+			Credentials:   creds,
+			HostKey:       b.hostKeys.Callback(node.Name),
+			RootCAs:       b.rootCAs,
 			Info:          node.ManagementInfo,
 		}
 
 		used := make(map[string]struct{})
 
 		src := reflect.ValueOf(portConfig).Elem()
-		srcType := src.Type()
 
 		for _, plugin := range b.plugins {
 
-			if !plugin.SupportedByDevice(nodeDeviceModel) {
+			if !supportedByDevice(plugin, deviceModel, caps) {
 				logger.Printf(
 					"Skipping plugin %s: unsupported by device model %s",
 					plugin.Name(),
@@ -239,174 +528,304 @@ func (b *NetconfBackend) PrepareSnapshot(msg *topology_config.NodeConfig, node *
 				fields,
 			)
 
-			var input proto.Message
+			if validator, ok := plugin.(plugins.PortValidator); ok {
+				if err := validator.ValidatePort(portConfig, topologyPort(node, portConfig.PortId)); err != nil {
+					return fmt.Errorf(
+						"%s: port %s: %w",
+						plugin.Name(),
+						portConfig.PortId,
+						err,
+					)
+				}
+			}
 
-			if len(fields) == 1 {
+			input := pluginInput(
+				src,
+				fields,
+				&topology_config.PortConfig{PortId: portConfig.PortId},
+				used,
+				logger,
+			)
+			if input == nil {
+				continue
+			}
 
-				f := src.FieldByName(fields[0])
+			if err := applyFeature(plugin, input, snapshotSet.Working, target, logger); err != nil {
+				return err
+			}
+		}
 
-				if !f.IsValid() || f.IsNil() {
-					logger.Printf(
-						"  -> field %q is not valid, skipping",
-						fields[0],
-					)
-					continue
-				}
+		// The port id selects the port, it is not a feature.
+		used["PortId"] = struct{}{}
 
-				fieldMsg, ok := f.Interface().(proto.Message)
-				if !ok {
-					return fmt.Errorf(
-						"field %q does not implement proto.Message",
-						fields[0],
-					)
-				}
+		reportUnused(src, used, logger)
 
-				logger.Printf(
-					"  -> mapping field %q (%T)",
-					fields[0],
-					fieldMsg,
-				)
+		logger.Printf(
+			"Finished processing port %q",
+			portConfig.PortId,
+		)
+
+		logger.Printf("======================================================")
+	}
 
-				input = fieldMsg
-				used[fields[0]] = struct{}{}
+	//
+	// Node-scoped features, mapped once per node after the ports.
+	//
+	target := managementSessions.DeviceTarget{
+		Logger:      logger,
+		Credentials: creds,
+		HostKey:     b.hostKeys.Callback(node.Name),
+		RootCAs:     b.rootCAs,
+		Info:        node.ManagementInfo,
+	}
 
-			} else {
+	for _, cfg := range []proto.Message{
+		nodeConfig.GetBridge(),
+		nodeConfig.GetEndStation(),
+		nodeConfig.GetBridgedEndStation(),
+	} {
+		if reflect.ValueOf(cfg).IsNil() {
+			continue
+		}
 
-				dst := &topology_config.PortConfig{}
-				dstVal := reflect.ValueOf(dst).Elem()
+		if err := b.prepareNodeScoped(ctx, cfg, snapshotSet.Working, target, deviceModel, caps); err != nil {
+			return err
+		}
+	}
 
-				found := false
-				var mappedFields []string
+	logger.Printf(
+		"Snapshot prepared successfully for node %s",
+		node.Name,
+	)
 
-				for _, name := range fields {
+	return nil
+}
 
-					sf := src.FieldByName(name)
+// prepareNodeScoped maps a role configuration of the node, BridgeConfig,
+// EndStationConfig or BridgedEndStationConfig, through the plugins supporting
+// its fields. Their features are anchored anywhere in the datastore, there is
+// no target interface.
+func (b *NetconfBackend) prepareNodeScoped(
+	ctx context.Context,
+	cfg proto.Message,
+	working *NetconfSnapshot,
+	target managementSessions.DeviceTarget,
+	deviceModel *devicemodelregistry.DeviceModel,
+	caps *managementSessions.Capabilities,
+) error {
+	logger := b.logger
 
-					if !sf.IsValid() {
-						logger.Printf(
-							"  -> field %q does not exist",
-							name,
-						)
-						continue
-					}
+	src := reflect.ValueOf(cfg).Elem()
+	kind := src.Type().Name()
 
-					switch sf.Kind() {
-					case reflect.Pointer, reflect.Slice, reflect.Map:
-						if sf.IsNil() {
-							continue
-						}
-					}
+	logger.Printf("======================================================")
+	logger.Printf("Processing %s", kind)
 
-					dstVal.FieldByName(name).Set(sf)
+	used := make(map[string]struct{})
 
-					used[name] = struct{}{}
-					mappedFields = append(mappedFields, name)
-					found = true
-				}
+	for _, plugin := range b.plugins {
 
-				if !found {
-					logger.Printf(
-						"  -> none of the supported fields are present",
-					)
-					continue
-				}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-				logger.Printf(
-					"  -> mapping PortConfig with fields %v",
-					mappedFields,
-				)
+		if !supportedByDevice(plugin, deviceModel, caps) {
+			continue
+		}
 
-				input = dst
-			}
+		fields := plugin.SupportedFields(cfg)
+		if len(fields) == 0 {
+			continue
+		}
 
-			logger.Printf("  -> calling Map()")
+		logger.Printf(
+			"Plugin %-20s : supports %v",
+			plugin.Name(),
+			fields,
+		)
 
-			mapped, err := plugin.Map(input)
-			if err != nil {
-				return fmt.Errorf(
-					"%s: %w",
-					plugin.Name(),
-					err,
-				)
-			}
+		dst := reflect.New(src.Type()).Interface().(proto.Message)
 
-			logger.Printf(
-				"  <- Map() returned %T",
-				mapped,
-			)
+		input := pluginInput(src, fields, dst, used, logger)
+		if input == nil {
+			continue
+		}
 
-			logger.Printf("  -> building feature XML")
+		if err := applyFeature(plugin, input, working, target, logger); err != nil {
+			return err
+		}
+	}
 
-			featureXML, err := plugin.BuildFeatureXML(mapped)
+	reportUnused(src, used, logger)
 
-			if err != nil {
-				return fmt.Errorf("%s: %w", plugin.Name(), err)
-			}
+	logger.Printf("Finished processing %s", kind)
+	logger.Printf("======================================================")
 
-			if err := snapshotSet.Working.Update(
-				featureXML,
-				target,
-			); err != nil {
-				return fmt.Errorf("failed to update snapshot: %w", err)
-			}
+	return nil
+}
+
+// reportUnused logs the populated fields of src that no plugin handled.
+func reportUnused(src reflect.Value, used map[string]struct{}, logger observability.Logger) {
+
+	srcType := src.Type()
+
+	var unused []string
+
+	for i := 0; i < src.NumField(); i++ {
 
-			logger.Printf("  -> snapshot update successful")
+		field := srcType.Field(i)
+
+		if !field.IsExported() {
+			// Protobuf internals.
+			continue
 		}
 
-		var unused []string
+		if _, ok := used[field.Name]; ok {
+			continue
+		}
 
-		for i := 0; i < src.NumField(); i++ {
+		if src.Field(i).IsZero() {
+			continue
+		}
 
-			field := srcType.Field(i)
+		unused = append(unused, field.Name)
+	}
 
-			if _, ok := used[field.Name]; ok {
-				continue
-			}
+	if len(unused) == 0 {
+		logger.Printf("All populated fields were handled.")
+	} else {
+		logger.Printf(
+			"Unused populated fields: %v",
+			unused,
+		)
+	}
+}
 
-			v := src.Field(i)
+// pluginInput selects the message passed to Map for the fields a plugin
+// supports in src. A single message field is passed as is; otherwise the
+// populated fields are copied into dst, a message of src's type. Fields
+// passed on are recorded in used. Returns nil when none of the fields are set.
+func pluginInput(src reflect.Value, fields []string, dst proto.Message, used map[string]struct{}, logger observability.Logger) proto.Message {
 
-			switch v.Kind() {
-			case reflect.Pointer, reflect.Slice, reflect.Map:
-				if v.IsNil() {
-					continue
-				}
-			}
+	if len(fields) == 1 {
 
-			unused = append(unused, field.Name)
+		f := src.FieldByName(fields[0])
+
+		if !f.IsValid() || f.IsNil() {
+			logger.Printf(
+				"  -> field %q is not valid, skipping",
+				fields[0],
+			)
+			return nil
 		}
 
-		if len(unused) == 0 {
-			logger.Printf("All populated fields were handled.")
-		} else {
+		// Repeated fields are passed in dst, as below.
+		if fieldMsg, ok := f.Interface().(proto.Message); ok {
+
 			logger.Printf(
-				"Unused populated fields: %v",
-				unused,
+				"  -> mapping field %q (%T)",
+				fields[0],
+				fieldMsg,
 			)
+
+			used[fields[0]] = struct{}{}
+
+			return fieldMsg
 		}
+	}
 
+	dstVal := reflect.ValueOf(dst).Elem()
+
+	var mappedFields []string
+
+	for _, name := range fields {
+
+		sf := src.FieldByName(name)
+
+		if !sf.IsValid() {
+			logger.Printf(
+				"  -> field %q does not exist",
+				name,
+			)
+			continue
+		}
+
+		switch sf.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			if sf.IsNil() {
+				continue
+			}
+		}
+
+		dstVal.FieldByName(name).Set(sf)
+
+		used[name] = struct{}{}
+		mappedFields = append(mappedFields, name)
+	}
+
+	if len(mappedFields) == 0 {
 		logger.Printf(
-			"Finished processing port %q",
-			portConfig.PortId,
+			"  -> none of the supported fields are present",
 		)
+		return nil
+	}
 
-		logger.Printf("======================================================")
+	logger.Printf(
+		"  -> mapping %T with fields %v",
+		dst,
+		mappedFields,
+	)
+
+	return dst
+}
+
+// applyFeature maps input through the plugin and updates the snapshot with
+// the resulting feature.
+func applyFeature(plugin plugins.Plugin, input proto.Message, snapshot *NetconfSnapshot, target managementSessions.DeviceTarget, logger observability.Logger) error {
+
+	logger.Printf("  -> calling Map()")
+
+	mapped, err := plugin.Map(input)
+	if err != nil {
+		return fmt.Errorf(
+			"%s: %w",
+			plugin.Name(),
+			err,
+		)
 	}
 
 	logger.Printf(
-		"Snapshot prepared successfully for node %s",
-		node.Name,
+		"  <- Map() returned %T",
+		mapped,
 	)
 
+	logger.Printf("  -> building feature XML")
+
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", plugin.Name(), err)
+	}
+
+	if err := snapshot.Update(
+		featureXML,
+		target,
+	); err != nil {
+		return fmt.Errorf("failed to update snapshot: %w", err)
+	}
+
+	logger.Printf("  -> snapshot update successful")
+
 	return nil
 }
 
-func (b *NetconfBackend) Commit(target *topology.Node) error {
+func (b *NetconfBackend) Commit(ctx context.Context, target *topology.Node) error {
 
 	if target == nil {
 		return fmt.Errorf("Commit: node is nil")
 	}
 
-	snapshotSet, ok := b.snapshots[target.Name]
+	snapshotSet, ok := b.snapshotSet(target.Name)
 	if !ok {
 		return fmt.Errorf(
 			"no snapshot exists for node %s",
@@ -429,22 +848,37 @@ func (b *NetconfBackend) Commit(target *topology.Node) error {
 	//
 	// Push working configuration
 	//
-	if err := b.pushSnapshot(
+	pending, err := b.pushSnapshot(
+		ctx,
 		snapshotSet.Working,
 		target,
-	); err != nil {
+		true,
+	)
+	if err != nil {
 		return fmt.Errorf(
 			"commit failed: %w",
 			err,
 		)
 	}
 
+	if pending {
+		b.logger.Printf(
+			"Commit on node %s awaits confirmation (timeout %v)",
+			target.Name,
+			b.confirmTimeout,
+		)
+	}
+
 	//
 	// Snapshot promotion
 	//
+	b.mu.Lock()
 	snapshotSet.LastStable = snapshotSet.Current
 	snapshotSet.Current = snapshotSet.Working
 	snapshotSet.Working = nil
+	b.mu.Unlock()
+
+	b.persistSnapshots(ctx, target.Name, snapshotSet)
 
 	b.logger.Printf(
 		"Commit successful for node %s",
@@ -454,13 +888,13 @@ func (b *NetconfBackend) Commit(target *topology.Node) error {
 	return nil
 }
 
-func (b *NetconfBackend) Rollback(target *topology.Node) error {
+func (b *NetconfBackend) Rollback(ctx context.Context, target *topology.Node) error {
 
 	if target == nil {
 		return fmt.Errorf("Rollback: node is nil")
 	}
 
-	snapshotSet, ok := b.snapshots[target.Name]
+	snapshotSet, ok := b.snapshotSet(target.Name)
 	if !ok {
 		return fmt.Errorf(
 			"no snapshot exists for node %s",
@@ -483,21 +917,55 @@ func (b *NetconfBackend) Rollback(target *topology.Node) error {
 	//
 	// Restore device configuration
 	//
-	if err := b.pushSnapshot(
-		snapshotSet.LastStable,
-		target,
-	); err != nil {
-		return fmt.Errorf(
-			"rollback failed: %w",
-			err,
-		)
+	if ns := b.pendingSession(target.Name); ns != nil {
+
+		// The commit was never confirmed: the device restores its previous
+		// configuration itself once the commit is cancelled or the session ends.
+		if err := managementSessions.CancelCommit(ctx, ns.session); err != nil {
+			b.logger.Printf(
+				"cancel-commit on node %s: %v, closing session to revert",
+				target.Name,
+				err,
+			)
+			b.dropSession(target.Name, ns)
+		} else {
+			ns.pendingConfirm = false
+			b.releaseSession(target.Name, ns)
+		}
+
+	} else {
+
+		// Merging LastStable would keep whatever the failed transaction
+		// added: replace its subtrees, as RestoreSnapshot does.
+		var current []byte
+		if snapshotSet.Current != nil {
+			current = snapshotSet.Current.XML
+		}
+
+		edit, err := replaceEdit(current, snapshotSet.LastStable.XML)
+		if err != nil {
+			return fmt.Errorf("failed building rollback of node %s: %w", target.Name, err)
+		}
+
+		restore := &NetconfSnapshot{XML: snapshotSet.LastStable.XML, Edit: edit}
+
+		if _, err := b.pushSnapshot(ctx, restore, target, false); err != nil {
+			return fmt.Errorf(
+				"rollback failed: %w",
+				err,
+			)
+		}
 	}
 
 	//
 	// Restore runtime state
 	//
+	b.mu.Lock()
 	snapshotSet.Current = snapshotSet.LastStable.Clone().(*NetconfSnapshot)
 	snapshotSet.Working = nil
+	b.mu.Unlock()
+
+	b.persistSnapshots(ctx, target.Name, snapshotSet)
 
 	b.logger.Printf(
 		"Rollback successful for node %s",
@@ -507,33 +975,118 @@ func (b *NetconfBackend) Rollback(target *topology.Node) error {
 	return nil
 }
 
-func (b *NetconfBackend) pushSnapshot(snapshot *NetconfSnapshot, node *topology.Node) error {
+// Confirm sends the confirming commit for a node committed with a confirmed
+// commit. Nodes without a pending commit are already final.
+func (b *NetconfBackend) Confirm(ctx context.Context, target *topology.Node) error {
+
+	if target == nil {
+		return fmt.Errorf("Confirm: node is nil")
+	}
+
+	ns := b.pendingSession(target.Name)
+	if ns == nil {
+		return nil
+	}
+
+	if err := managementSessions.CommitCandidate(ctx, ns.session); err != nil {
+		// Closing the session makes the device revert the commit.
+		b.dropSession(target.Name, ns)
+		return fmt.Errorf("confirming commit failed: %w", err)
+	}
+
+	ns.pendingConfirm = false
+	b.releaseSession(target.Name, ns)
+
+	b.logger.Printf(
+		"Commit confirmed for node %s",
+		target.Name,
+	)
+
+	return nil
+}
+
+// pushSnapshot writes the snapshot to the node, through the session holding
+// the node's lock if there is one.
+//
+// Devices with :candidate get it through edit-config on <candidate>, <validate>
+// and <commit>. When confirmed is set and the device also supports
+// :confirmed-commit, the commit is a confirmed commit and pushSnapshot reports
+// it as pending: its session is kept open until Confirm, since the device
+// reverts the commit when it closes.
+func (b *NetconfBackend) pushSnapshot(ctx context.Context, snapshot *NetconfSnapshot, node *topology.Node, confirmed bool) (bool, error) {
 
 	if snapshot == nil {
-		return fmt.Errorf("snapshot is nil")
+		return false, fmt.Errorf("snapshot is nil")
 	}
 
 	if len(snapshot.XML) == 0 {
-		return fmt.Errorf("snapshot XML is empty")
+		return false, fmt.Errorf("snapshot XML is empty")
 	}
 
-	session, err := managementSessions.CreateSession(
-		node.ManagementInfo.IpAddress,
-		node.ManagementInfo.UserName,
-		"",
-	)
+	ns, err := b.acquireSession(ctx, node)
+	if err != nil {
+		return false, err
+	}
 
+	pending, err := b.push(ctx, ns, snapshot, confirmed)
 	if err != nil {
-		return fmt.Errorf("NETCONF session failed: %w", err)
+		// The session state is unknown after a failed push: do not reuse it.
+		b.dropSession(node.Name, ns)
+		return false, fmt.Errorf("failed pushing snapshot: %w", err)
 	}
 
-	defer session.Close()
+	ns.pendingConfirm = pending
+	b.releaseSession(node.Name, ns)
 
-	if err := managementSessions.EditConfig(
-		session,
-		string(snapshot.XML),
-	); err != nil {
-		return fmt.Errorf("failed pushing snapshot: %w", err)
+	return pending, nil
+}
+
+func (b *NetconfBackend) push(ctx context.Context, ns *nodeSession, snapshot *NetconfSnapshot, confirmed bool) (bool, error) {
+
+	if !managementSessions.HasCapability(ns.session, managementSessions.CapabilityCandidate) {
+		return false, managementSessions.EditConfigContext(
+			ctx,
+			ns.session,
+			string(snapshot.payload()),
+		)
+	}
+
+	pending := confirmed && managementSessions.SupportsConfirmedCommit(ns.session)
+
+	if err := b.commitCandidate(ctx, ns.session, snapshot, pending); err != nil {
+		return false, err
+	}
+
+	return pending, nil
+}
+
+// commitCandidate stages the snapshot in <candidate>, validates it and commits it.
+func (b *NetconfBackend) commitCandidate(ctx context.Context, session *netconf.Session, snapshot *NetconfSnapshot, confirmed bool) error {
+
+	// Start from <running> so stale candidate edits are not committed along.
+	if err := managementSessions.DiscardChanges(ctx, session); err != nil {
+		return err
+	}
+
+	err := managementSessions.EditCandidateContext(ctx, session, string(snapshot.payload()))
+
+	if err == nil {
+		err = managementSessions.ValidateCandidate(ctx, session)
+	}
+
+	if err == nil {
+		if confirmed {
+			err = managementSessions.ConfirmedCommit(ctx, session, b.confirmTimeout)
+		} else {
+			err = managementSessions.CommitCandidate(ctx, session)
+		}
+	}
+
+	if err != nil {
+		if discardErr := managementSessions.DiscardChanges(context.WithoutCancel(ctx), session); discardErr != nil {
+			b.logger.Printf("discard-changes after failed commit: %v", discardErr)
+		}
+		return err
 	}
 
 	return nil
@@ -0,0 +1,262 @@
+package protocolbackends
+
+import (
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+	"bytes"
+	"testing"
+)
+
+func TestNetconfSnapshotUpdate_MergesFeatures(t *testing.T) {
+	component := plugins.BridgeComponentAnchor("br0", "c0")
+
+	tests := []struct {
+		name     string
+		snapshot string
+		features []plugins.FeatureXML
+		want     string
+	}{
+		{
+			name: "keyed list entry replaced by key",
+			snapshot: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-filters>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+          <stream-gate-ref>1</stream-gate-ref>
+        </stream-filter-instance-table>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>2</stream-filter-instance-id>
+          <stream-gate-ref>2</stream-gate-ref>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </component>
+  </bridge>
+</bridges>`,
+			features: []plugins.FeatureXML{{
+				Anchor: component,
+				XML: []byte(`<stream-filters>
+  <stream-filter-instance-table>
+    <stream-filter-instance-id>1</stream-filter-instance-id>
+    <stream-gate-ref>7</stream-gate-ref>
+  </stream-filter-instance-table>
+</stream-filters>`),
+			}},
+			want: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-filters>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>2</stream-filter-instance-id>
+          <stream-gate-ref>2</stream-gate-ref>
+        </stream-filter-instance-table>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+          <stream-gate-ref>7</stream-gate-ref>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </component>
+  </bridge>
+</bridges>`,
+		},
+		{
+			name: "anchored leaf-list replaced as a whole",
+			snapshot: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <traffic-class>0</traffic-class>
+      <traffic-class>5</traffic-class>
+    </component>
+  </bridge>
+</bridges>`,
+			features: []plugins.FeatureXML{{
+				Anchor: component,
+				XML:    []byte(`<traffic-class>1</traffic-class><traffic-class>2</traffic-class>`),
+			}},
+			want: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <traffic-class>1</traffic-class>
+      <traffic-class>2</traffic-class>
+    </component>
+  </bridge>
+</bridges>`,
+		},
+		{
+			name: "leaf-list in a container replaced as a whole",
+			snapshot: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-gates>
+        <supported-ipv>0</supported-ipv>
+        <supported-ipv>5</supported-ipv>
+      </stream-gates>
+    </component>
+  </bridge>
+</bridges>`,
+			features: []plugins.FeatureXML{{
+				Anchor: component,
+				XML: []byte(`<stream-gates>
+  <supported-ipv>1</supported-ipv>
+  <supported-ipv>2</supported-ipv>
+</stream-gates>`),
+			}},
+			want: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-gates>
+        <supported-ipv>1</supported-ipv>
+        <supported-ipv>2</supported-ipv>
+      </stream-gates>
+    </component>
+  </bridge>
+</bridges>`,
+		},
+		{
+			name: "two features merged into the same component",
+			snapshot: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <type>c-vlan-component</type>
+    </component>
+  </bridge>
+</bridges>`,
+			features: []plugins.FeatureXML{
+				{
+					Anchor: component,
+					XML: []byte(`<stream-filters>
+  <stream-filter-instance-table>
+    <stream-filter-instance-id>1</stream-filter-instance-id>
+  </stream-filter-instance-table>
+</stream-filters>`),
+				},
+				{
+					Anchor: component,
+					XML: []byte(`<stream-gates>
+  <stream-gate-instance-table>
+    <stream-gate-instance-id>1</stream-gate-instance-id>
+  </stream-gate-instance-table>
+</stream-gates>`),
+				},
+			},
+			want: `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <type>c-vlan-component</type>
+      <stream-filters>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+        </stream-filter-instance-table>
+      </stream-filters>
+      <stream-gates>
+        <stream-gate-instance-table>
+          <stream-gate-instance-id>1</stream-gate-instance-id>
+        </stream-gate-instance-table>
+      </stream-gates>
+    </component>
+  </bridge>
+</bridges>`,
+		},
+		{
+			name: "top-level list entry replaced by key",
+			snapshot: `<stream-identity xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification">
+  <index>1</index>
+  <handle>1</handle>
+</stream-identity>
+<stream-identity xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification">
+  <index>2</index>
+  <handle>2</handle>
+</stream-identity>`,
+			features: []plugins.FeatureXML{{
+				Anchor: plugins.TopLevelAnchor(),
+				XML: []byte(`<stream-identity xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification">
+  <index>1</index>
+  <handle>9</handle>
+</stream-identity>`),
+			}},
+			want: `<stream-identity xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification">
+  <index>2</index>
+  <handle>2</handle>
+</stream-identity>
+<stream-identity xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1cb-stream-identification">
+  <index>1</index>
+  <handle>9</handle>
+</stream-identity>`,
+		},
+		{
+			name: "missing anchor created",
+			snapshot: `<interfaces xmlns="urn:ietf:params:xml:ns:yang:ietf-interfaces">
+  <interface>
+    <name>sw0p1</name>
+  </interface>
+</interfaces>`,
+			features: []plugins.FeatureXML{{
+				Anchor: component,
+				XML: []byte(`<stream-filters>
+  <stream-filter-instance-table>
+    <stream-filter-instance-id>1</stream-filter-instance-id>
+  </stream-filter-instance-table>
+</stream-filters>`),
+			}},
+			want: `<interfaces xmlns="urn:ietf:params:xml:ns:yang:ietf-interfaces">
+  <interface>
+    <name>sw0p1</name>
+  </interface>
+</interfaces>
+<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-filters>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </component>
+  </bridge>
+</bridges>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := &NetconfSnapshot{XML: []byte(tt.snapshot)}
+
+			for i := range tt.features {
+				if err := snapshot.Update(&tt.features[i], managementSessions.DeviceTarget{InterfaceName: "sw0p1"}); err != nil {
+					t.Fatalf("update %d failed: %v", i, err)
+				}
+			}
+
+			got, err := normalizeXML(snapshot.XML)
+			if err != nil {
+				t.Fatalf("normalize snapshot: %v", err)
+			}
+			want, err := normalizeXML([]byte(tt.want))
+			if err != nil {
+				t.Fatalf("normalize want: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected:\n%s\ngot:\n%s", want, got)
+			}
+		})
+	}
+}
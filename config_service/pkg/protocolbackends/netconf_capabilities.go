@@ -0,0 +1,158 @@
+package protocolbackends
+
+import (
+	"context"
+	"strings"
+
+	devicemodelregistry "OpenCNC_config_service/common/structures/devicemodelregistry"
+	observabilityv1 "OpenCNC_config_service/common/structures/logging"
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+// EventEmitter publishes domain events; *observability.Client implements it.
+type EventEmitter interface {
+	Event(ctx context.Context, severity observabilityv1.Severity, domain string, action string, result observabilityv1.DomainResult, subjectType string, subjectID string, summary string) error
+}
+
+// nodeCapabilities caches what a node runs, as learnt on its latest session.
+type nodeCapabilities struct {
+	caps     *managementSessions.Capabilities
+	reported bool // mismatches with the registered device model were reported
+}
+
+// SetEventEmitter sets where warnings about devices are published, e.g. a
+// device model that disagrees with the device. It must be called before first use.
+func (b *NetconfBackend) SetEventEmitter(events EventEmitter) {
+	b.events = events
+}
+
+// SetSchemaDiscovery selects whether the modules advertised in the <hello> are
+// completed with the ietf-netconf-monitoring schema list, which also holds the
+// YANG 1.1 modules. It must be called before first use.
+func (b *NetconfBackend) SetSchemaDiscovery(enabled bool) {
+	b.discoverSchemas = enabled
+}
+
+// Capabilities returns what the node runs, as learnt on its latest session,
+// or nil if no session was opened yet.
+func (b *NetconfBackend) Capabilities(node string) *managementSessions.Capabilities {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if nc, ok := b.capabilities[node]; ok {
+		return nc.caps
+	}
+	return nil
+}
+
+// learnCapabilities caches the capabilities the session's device advertised,
+// unless they were read on that session already.
+func (b *NetconfBackend) learnCapabilities(ctx context.Context, node string, session *netconf.Session) {
+
+	b.mu.Lock()
+	nc, ok := b.capabilities[node]
+	b.mu.Unlock()
+
+	if ok && nc.caps.SessionID == session.SessionID {
+		return
+	}
+
+	caps := managementSessions.ParseCapabilities(session)
+
+	if b.discoverSchemas && caps.HasModule("ietf-netconf-monitoring", "") {
+		schemas, err := managementSessions.ListSchemas(ctx, session)
+		if err != nil {
+			b.logger.Printf("Listing schemas of node %s failed: %v", node, err)
+		} else {
+			caps.AddSchemas(schemas)
+		}
+	}
+
+	b.mu.Lock()
+	b.capabilities[node] = &nodeCapabilities{caps: caps}
+	b.mu.Unlock()
+
+	b.logger.Printf(
+		"Node %s runs %d modules (session %d)",
+		node,
+		len(caps.Modules),
+		session.SessionID,
+	)
+}
+
+// runtimeDeviceModel returns the device model plugins are checked against:
+// the modules the node runs when known, the registered model otherwise. Where
+// the two disagree, a warning event is emitted once per learnt capabilities.
+func (b *NetconfBackend) runtimeDeviceModel(ctx context.Context, node *topology.Node, registered *devicemodelregistry.DeviceModel) (*devicemodelregistry.DeviceModel, *managementSessions.Capabilities) {
+
+	b.mu.Lock()
+	nc, ok := b.capabilities[node.Name]
+	report := ok && !nc.reported
+	if report {
+		nc.reported = true
+	}
+	b.mu.Unlock()
+
+	if !ok {
+		return registered, nil
+	}
+
+	if report {
+		b.reportMismatches(ctx, node.Name, registered, nc.caps)
+	}
+
+	if nc.caps.Partial {
+		// Modules missing from the <hello> may still be implemented.
+		return registered, nil
+	}
+
+	return nc.caps.DeviceModel(registered.GetName()), nc.caps
+}
+
+func (b *NetconfBackend) reportMismatches(ctx context.Context, node string, registered *devicemodelregistry.DeviceModel, caps *managementSessions.Capabilities) {
+
+	mismatches := caps.Mismatches(registered)
+	if len(mismatches) == 0 {
+		return
+	}
+
+	summary := "device model " + registered.GetName() + " does not match the device: " + strings.Join(mismatches, "; ")
+
+	b.logger.Printf("Node %s: %s", node, summary)
+
+	if b.events == nil {
+		return
+	}
+
+	_ = b.events.Event(
+		ctx,
+		observabilityv1.Severity_SEVERITY_WARN,
+		"device.capabilities",
+		"model-mismatch",
+		observabilityv1.DomainResult_DOMAIN_RESULT_ACCEPTED,
+		"node",
+		node,
+		summary,
+	)
+}
+
+// supportedByDevice checks the plugin against the device model and, when the
+// node's capabilities are known, against them.
+func supportedByDevice(plugin plugins.Plugin, model *devicemodelregistry.DeviceModel, caps *managementSessions.Capabilities) bool {
+
+	if !plugin.SupportedByDevice(model) {
+		return false
+	}
+
+	checker, ok := plugin.(plugins.CapabilityChecker)
+	if !ok || caps == nil {
+		return true
+	}
+
+	return checker.SupportedByCapabilities(caps)
+}
@@ -0,0 +1,228 @@
+package protocolbackends
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
+
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+// nodeSession is a NETCONF session leased from the session pool. The backend
+// keeps it across calls for one node while it holds the node's datastore lock,
+// or while a confirmed commit issued on it awaits confirmation; the device
+// drops both when it closes. Otherwise it goes back to the pool after each call.
+type nodeSession struct {
+	lease          *managementSessions.PooledSession
+	session        *netconf.Session
+	locked         []string // datastores locked by this session, in locking order
+	pendingConfirm bool
+}
+
+func (s *nodeSession) idle() bool {
+	return len(s.locked) == 0 && !s.pendingConfirm
+}
+
+// acquireSession returns the session kept for the node, or leases one from
+// the pool. Every acquired session must be handed back with releaseSession or
+// dropSession.
+func (b *NetconfBackend) acquireSession(ctx context.Context, node *topology.Node) (*nodeSession, error) {
+
+	b.mu.Lock()
+	ns, ok := b.sessions[node.Name]
+	b.mu.Unlock()
+
+	if ok {
+		return ns, nil
+	}
+
+	if node.ManagementInfo == nil {
+		return nil, fmt.Errorf("node %s has no management info", node.Name)
+	}
+
+	info := node.ManagementInfo
+
+	lease, err := b.pool.Acquire(ctx, node.Name, func(ctx context.Context) (*netconf.Session, error) {
+		creds, err := b.resolveCredentials(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		return managementSessions.Connect(ctx, info, creds, managementSessions.Verification{
+			HostKeyCallback: b.hostKeys.Callback(node.Name),
+			RootCAs:         b.rootCAs,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NETCONF session failed: %w", err)
+	}
+
+	b.learnCapabilities(ctx, node.Name, lease.Session)
+
+	return &nodeSession{lease: lease, session: lease.Session}, nil
+}
+
+// resolveCredentials looks up the node's credentials. Provider errors never
+// carry secrets, but the credentials themselves must not be logged.
+func (b *NetconfBackend) resolveCredentials(ctx context.Context, node *topology.Node) (managementSessions.Credentials, error) {
+
+	creds, err := b.credentials.Credentials(ctx, node)
+	if err != nil {
+		return managementSessions.Credentials{}, fmt.Errorf("credentials for node %s: %w", node.Name, err)
+	}
+
+	if creds.Username == "" {
+		creds.Username = node.GetManagementInfo().GetUserName()
+	}
+
+	return creds, nil
+}
+
+// releaseSession keeps the session for the node while it holds a lock or a
+// pending commit, and returns it to the pool otherwise.
+func (b *NetconfBackend) releaseSession(node string, ns *nodeSession) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !ns.idle() {
+		b.sessions[node] = ns
+		return
+	}
+
+	if b.sessions[node] == ns {
+		delete(b.sessions, node)
+	}
+	ns.lease.Release()
+}
+
+// dropSession closes the session, giving up its lock and reverting its
+// unconfirmed commit, if any.
+func (b *NetconfBackend) dropSession(node string, ns *nodeSession) {
+
+	b.mu.Lock()
+	if b.sessions[node] == ns {
+		delete(b.sessions, node)
+	}
+	b.mu.Unlock()
+
+	ns.lease.Discard()
+}
+
+// pendingSession returns the session holding the node's unconfirmed commit, if any.
+func (b *NetconfBackend) pendingSession(node string) *nodeSession {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ns, ok := b.sessions[node]; ok && ns.pendingConfirm {
+		return ns
+	}
+	return nil
+}
+
+// CheckHealth fails fast while the session pool is backing off from a node
+// that could not be reached.
+func (b *NetconfBackend) CheckHealth(target *topology.Node) error {
+
+	if target == nil {
+		return fmt.Errorf("CheckHealth: node is nil")
+	}
+
+	return b.pool.Health(target.Name).Err()
+}
+
+// Lock takes <lock> on the datastores the node is configured through, see
+// managementSessions.LockTargets. The locking session is kept and used for every request to the node until Unlock.
+// A lock held by someone else fails with *managementSessions.LockDeniedError.
+func (b *NetconfBackend) Lock(ctx context.Context, target *topology.Node) error {
+
+	if target == nil {
+		return fmt.Errorf("Lock: node is nil")
+	}
+
+	ns, err := b.acquireSession(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	if len(ns.locked) > 0 {
+		return nil
+	}
+
+	datastores := managementSessions.LockTargets(ns.session)
+
+	for i, datastore := range datastores {
+		if err := managementSessions.Lock(ctx, ns.session, datastore); err != nil {
+			if i > 0 && b.unlockDatastores(ctx, ns, datastores[:i]) != nil {
+				// Closing the session releases the locks as well.
+				b.dropSession(target.Name, ns)
+			} else {
+				b.releaseSession(target.Name, ns)
+			}
+			return fmt.Errorf("lock failed: %w", err)
+		}
+	}
+
+	ns.locked = datastores
+	b.releaseSession(target.Name, ns)
+
+	b.logger.Printf(
+		"Locked %s datastores of node %s (session %d)",
+		strings.Join(datastores, ", "),
+		target.Name,
+		ns.session.SessionID,
+	)
+
+	return nil
+}
+
+// Unlock releases the node's datastore locks and returns the locking session to
+// the pool, unless it still holds an unconfirmed commit.
+func (b *NetconfBackend) Unlock(ctx context.Context, target *topology.Node) error {
+
+	if target == nil {
+		return fmt.Errorf("Unlock: node is nil")
+	}
+
+	b.mu.Lock()
+	ns, ok := b.sessions[target.Name]
+	b.mu.Unlock()
+
+	if !ok || len(ns.locked) == 0 {
+		return nil
+	}
+
+	datastores := ns.locked
+	ns.locked = nil
+
+	if err := b.unlockDatastores(ctx, ns, datastores); err != nil {
+		// Closing the session releases the locks as well.
+		b.dropSession(target.Name, ns)
+		return fmt.Errorf("unlock failed: %w", err)
+	}
+
+	b.releaseSession(target.Name, ns)
+
+	b.logger.Printf(
+		"Unlocked %s datastores of node %s",
+		strings.Join(datastores, ", "),
+		target.Name,
+	)
+
+	return nil
+}
+
+// unlockDatastores unlocks the datastores in the reverse of locking order.
+func (b *NetconfBackend) unlockDatastores(ctx context.Context, ns *nodeSession, datastores []string) error {
+
+	for i := len(datastores) - 1; i >= 0; i-- {
+		if err := managementSessions.Unlock(ctx, ns.session, datastores[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
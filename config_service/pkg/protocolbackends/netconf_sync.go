@@ -0,0 +1,372 @@
+package protocolbackends
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/common/structures/transaction"
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
+	"OpenCNC_config_service/config_service/pkg/plugins"
+
+	"github.com/beevik/etree"
+)
+
+// SetSnapshotFilter selects whether snapshots hold only the subtrees owned by
+// the registered plugins (the default) or the full running configuration.
+func (b *NetconfBackend) SetSnapshotFilter(enabled bool) {
+	b.filterSnapshots = enabled
+}
+
+// SyncSnapshot reads the node's running configuration.
+//
+// The first sync seeds Current and LastStable. Later syncs replace Current
+// when the device configuration drifted from it; LastStable is kept as the
+// rollback target. It must not run concurrently with a transaction on the node.
+func (b *NetconfBackend) SyncSnapshot(ctx context.Context, target *topology.Node) error {
+
+	if target == nil {
+		return fmt.Errorf("SyncSnapshot: node is nil")
+	}
+
+	running, err := b.fetchRunning(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+
+	snapshotSet, ok := b.snapshots[target.Name]
+	if !ok {
+		snapshotSet = &SnapshotSet[*NetconfSnapshot]{
+			Current:    running,
+			LastStable: running.Clone().(*NetconfSnapshot),
+		}
+		b.snapshots[target.Name] = snapshotSet
+		b.mu.Unlock()
+
+		b.logger.Printf(
+			"Snapshot initialised from running config for node %s",
+			target.Name,
+		)
+		b.persistSnapshots(ctx, target.Name, snapshotSet)
+		return nil
+	}
+
+	if snapshotSet.Current != nil && sameConfiguration(snapshotSet.Current.XML, running.XML) {
+		b.mu.Unlock()
+		return nil
+	}
+
+	snapshotSet.Current = running
+	b.mu.Unlock()
+
+	b.logger.Printf(
+		"Running config of node %s drifted from its snapshot, resyncing",
+		target.Name,
+	)
+
+	b.persistSnapshots(ctx, target.Name, snapshotSet)
+
+	return nil
+}
+
+// LoadSnapshots restores the snapshot sets persisted in the store, so a
+// restarted service can still roll nodes back to their last stable
+// configuration. Nodes with a snapshot set already are left as they are.
+func (b *NetconfBackend) LoadSnapshots(ctx context.Context) error {
+
+	if b.store == nil {
+		return fmt.Errorf("no store to load snapshots from")
+	}
+
+	persisted, err := storewrapper.GetNodeSnapshots(ctx, b.store, b.name)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, snapshots := range persisted {
+		if _, ok := b.snapshots[snapshots.GetNodeId()]; ok {
+			continue
+		}
+
+		snapshotSet := &SnapshotSet[*NetconfSnapshot]{
+			Current: &NetconfSnapshot{XML: snapshots.GetCurrent()},
+		}
+		if snapshots.LastStable != nil {
+			snapshotSet.LastStable = &NetconfSnapshot{XML: snapshots.GetLastStable()}
+		}
+
+		b.snapshots[snapshots.GetNodeId()] = snapshotSet
+	}
+
+	b.logger.Printf("Loaded snapshots of %d node(s) from store", len(persisted))
+
+	return nil
+}
+
+// Snapshots returns the Current and LastStable snapshots of a node.
+func (b *NetconfBackend) Snapshots(node string) ([]byte, []byte, bool) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshotSet, ok := b.snapshots[node]
+	if !ok || snapshotSet.Current == nil {
+		return nil, nil, false
+	}
+
+	var lastStable []byte
+	if snapshotSet.LastStable != nil {
+		lastStable = append([]byte(nil), snapshotSet.LastStable.XML...)
+	}
+
+	return append([]byte(nil), snapshotSet.Current.XML...), lastStable, true
+}
+
+// RestoreSnapshot makes a snapshot the node ran before its working snapshot,
+// committed like one prepared by PrepareSnapshot.
+func (b *NetconfBackend) RestoreSnapshot(ctx context.Context, target *topology.Node, snapshot []byte) error {
+
+	if target == nil {
+		return fmt.Errorf("RestoreSnapshot: node is nil")
+	}
+
+	if len(snapshot) == 0 {
+		return fmt.Errorf("RestoreSnapshot: snapshot of node %s is empty", target.Name)
+	}
+
+	// Current must match the device: it becomes LastStable on commit.
+	if err := b.SyncSnapshot(ctx, target); err != nil {
+		return fmt.Errorf(
+			"failed to sync snapshot for node %s: %w",
+			target.Name,
+			err,
+		)
+	}
+
+	snapshotSet, ok := b.snapshotSet(target.Name)
+	if !ok {
+		return fmt.Errorf("no snapshot exists for node %s", target.Name)
+	}
+
+	// Merging the snapshot would keep whatever was added since: replace its
+	// subtrees instead, and remove those the node did not run then.
+	edit, err := replaceEdit(snapshotSet.Current.XML, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed building restore of node %s: %w", target.Name, err)
+	}
+
+	snapshotSet.Working = &NetconfSnapshot{
+		XML:  append([]byte(nil), snapshot...),
+		Edit: edit,
+	}
+
+	return nil
+}
+
+// netconfBaseNs is the namespace of the edit-config operation attribute (RFC 6241 section 7.2).
+const netconfBaseNs = "urn:ietf:params:xml:ns:netconf:base:1.0"
+
+// replaceEdit returns the edit-config payload turning the configuration of
+// the current snapshot into the target one: every top-level subtree of target
+// with operation "replace", and every top-level subtree only in current with
+// operation "remove".
+func replaceEdit(current, target []byte) ([]byte, error) {
+
+	targetDoc := etree.NewDocument()
+	if err := targetDoc.ReadFromBytes(target); err != nil {
+		return nil, fmt.Errorf("failed parsing target snapshot: %w", err)
+	}
+
+	currentDoc := etree.NewDocument()
+	if len(current) > 0 {
+		if err := currentDoc.ReadFromBytes(current); err != nil {
+			return nil, fmt.Errorf("failed parsing current snapshot: %w", err)
+		}
+	}
+
+	edit := etree.NewDocument()
+	kept := make(map[string]struct{})
+
+	for _, subtree := range targetDoc.ChildElements() {
+		kept[subtree.NamespaceURI()+" "+subtree.Tag] = struct{}{}
+
+		replaced := subtree.Copy()
+		replaced.CreateAttr("xmlns:nc", netconfBaseNs)
+		replaced.CreateAttr("nc:operation", "replace")
+		edit.AddChild(replaced)
+	}
+
+	for _, subtree := range currentDoc.ChildElements() {
+		if _, ok := kept[subtree.NamespaceURI()+" "+subtree.Tag]; ok {
+			continue
+		}
+
+		removed := edit.CreateElement(subtree.Tag)
+		removed.CreateAttr("xmlns", subtree.NamespaceURI())
+		removed.CreateAttr("xmlns:nc", netconfBaseNs)
+		removed.CreateAttr("nc:operation", "remove")
+	}
+
+	edit.Indent(2)
+
+	return edit.WriteToBytes()
+}
+
+// persistSnapshots writes the Current and LastStable snapshots of a node to
+// the store, if there is one. The snapshots in memory stay authoritative, so
+// a failed write is only logged.
+func (b *NetconfBackend) persistSnapshots(ctx context.Context, node string, snapshotSet *SnapshotSet[*NetconfSnapshot]) {
+
+	if b.store == nil {
+		return
+	}
+
+	snapshots := &transaction.NodeSnapshots{NodeId: node}
+
+	b.mu.Lock()
+	if snapshotSet.Current != nil {
+		snapshots.Current = snapshotSet.Current.XML
+	}
+	if snapshotSet.LastStable != nil {
+		snapshots.LastStable = snapshotSet.LastStable.XML
+	}
+	b.mu.Unlock()
+
+	if err := storewrapper.StoreNodeSnapshots(context.WithoutCancel(ctx), b.store, b.name, snapshots); err != nil {
+		b.logger.Printf("node %s: %v", node, err)
+	}
+}
+
+// fetchRunning reads the node's running configuration into a snapshot.
+func (b *NetconfBackend) fetchRunning(ctx context.Context, node *topology.Node) (*NetconfSnapshot, error) {
+
+	ns, err := b.acquireSession(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := managementSessions.GetRunningDataContext(ctx, ns.session, b.snapshotFilter())
+	if err != nil {
+		b.dropSession(node.Name, ns)
+		return nil, fmt.Errorf("failed reading running config: %w", err)
+	}
+
+	b.releaseSession(node.Name, ns)
+
+	normalized, err := normalizeXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing running config: %w", err)
+	}
+
+	return &NetconfSnapshot{XML: normalized}, nil
+}
+
+// snapshotFilter returns a subtree filter selecting every subtree owned by the
+// registered plugins, or "" to read the full configuration.
+func (b *NetconfBackend) snapshotFilter() string {
+
+	if !b.filterSnapshots {
+		return ""
+	}
+
+	seen := make(map[string]struct{})
+
+	var filter strings.Builder
+
+	for _, plugin := range b.plugins {
+
+		owner, ok := plugin.(plugins.SubtreeOwner)
+		if !ok {
+			// A plugin without declared subtrees may write anywhere.
+			return ""
+		}
+
+		for _, subtree := range owner.OwnedSubtrees() {
+			if _, dup := seen[subtree]; dup {
+				continue
+			}
+			seen[subtree] = struct{}{}
+			filter.WriteString(subtree)
+		}
+	}
+
+	return filter.String()
+}
+
+// sameConfiguration reports whether two snapshots hold the same configuration.
+// Snapshots built by NetconfSnapshot.Update and read from the device differ in
+// indentation, namespace prefixes and the order of list entries, so their
+// trees are compared instead of their bytes. Unparsable snapshots differ.
+func sameConfiguration(a, b []byte) bool {
+
+	aDoc := etree.NewDocument()
+	if err := aDoc.ReadFromBytes(a); err != nil {
+		return false
+	}
+
+	bDoc := etree.NewDocument()
+	if err := bDoc.ReadFromBytes(b); err != nil {
+		return false
+	}
+
+	return sameElements(aDoc.ChildElements(), bDoc.ChildElements())
+}
+
+// sameElements matches every element of a with a distinct equal element of b,
+// in any order.
+func sameElements(a, b []*etree.Element) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+
+next:
+	for _, x := range a {
+		for i, y := range b {
+			if !matched[i] && sameElement(x, y) {
+				matched[i] = true
+				continue next
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func sameElement(a, b *etree.Element) bool {
+
+	if a.Tag != b.Tag || a.NamespaceURI() != b.NamespaceURI() {
+		return false
+	}
+
+	if strings.TrimSpace(a.Text()) != strings.TrimSpace(b.Text()) {
+		return false
+	}
+
+	return sameElements(a.ChildElements(), b.ChildElements())
+}
+
+// normalizeXML re-indents the XML the same way NetconfSnapshot.Update does,
+// so snapshots read from the device are stored like the ones it builds.
+func normalizeXML(data []byte) ([]byte, error) {
+
+	doc := etree.NewDocument()
+
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+
+	doc.Indent(2)
+
+	return doc.WriteToBytes()
+}
@@ -0,0 +1,411 @@
+package protocolbackends
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
+	"OpenCNC_config_service/common/structures/topology"
+	"OpenCNC_config_service/config_service/pkg/managementSessions"
+
+	"github.com/beevik/etree"
+	"github.com/openshift-telco/go-netconf-client/netconf"
+)
+
+const (
+	restoreTarget = `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-filters xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-stream-filters-gates">
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </component>
+  </bridge>
+</bridges>
+`
+
+	// restoreTarget with a stream filter and a PTP instance added since.
+	restoreCurrent = `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <bridge>
+    <name>br0</name>
+    <component>
+      <name>c0</name>
+      <stream-filters xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-stream-filters-gates">
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+        </stream-filter-instance-table>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>2</stream-filter-instance-id>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </component>
+  </bridge>
+</bridges>
+<ptp xmlns="urn:ieee:std:1588:yang:ieee1588-ptp"/>
+`
+)
+
+// applyEdit applies the top-level operations of an edit-config payload to a
+// datastore, as a NETCONF server would: replace swaps the subtree, remove
+// deletes it.
+func applyEdit(t *testing.T, datastore, edit []byte) []byte {
+	t.Helper()
+
+	store := etree.NewDocument()
+	if err := store.ReadFromBytes(datastore); err != nil {
+		t.Fatalf("parse datastore: %v", err)
+	}
+	payload := etree.NewDocument()
+	if err := payload.ReadFromBytes(edit); err != nil {
+		t.Fatalf("parse edit: %v", err)
+	}
+
+	for _, subtree := range payload.ChildElements() {
+		operation := subtree.SelectAttrValue("nc:operation", "merge")
+
+		for _, existing := range store.ChildElements() {
+			if existing.Tag == subtree.Tag && existing.NamespaceURI() == subtree.NamespaceURI() {
+				store.RemoveChild(existing)
+			}
+		}
+
+		switch operation {
+		case "replace":
+			applied := subtree.Copy()
+			applied.RemoveAttr("xmlns:nc")
+			applied.RemoveAttr("nc:operation")
+			store.AddChild(applied)
+		case "remove":
+		default:
+			t.Fatalf("unexpected operation %q", operation)
+		}
+	}
+
+	store.Indent(2)
+	out, err := store.WriteToBytes()
+	if err != nil {
+		t.Fatalf("write datastore: %v", err)
+	}
+	return out
+}
+
+func TestReplaceEdit_RemovesEntriesAddedSinceTarget(t *testing.T) {
+	edit, err := replaceEdit([]byte(restoreCurrent), []byte(restoreTarget))
+	if err != nil {
+		t.Fatalf("replaceEdit failed: %v", err)
+	}
+
+	restored := applyEdit(t, []byte(restoreCurrent), edit)
+
+	if bytes.Contains(restored, []byte("<stream-filter-instance-id>2<")) {
+		t.Fatalf("stream filter 2 added after the target is still configured:\n%s", restored)
+	}
+	if bytes.Contains(restored, []byte("<ptp")) {
+		t.Fatalf("ptp added after the target is still configured:\n%s", restored)
+	}
+
+	want, err := normalizeXML([]byte(restoreTarget))
+	if err != nil {
+		t.Fatalf("normalize target: %v", err)
+	}
+	if !bytes.Equal(restored, want) {
+		t.Fatalf("expected the target configuration, got:\n%s", restored)
+	}
+}
+
+const (
+	syncRunning = `<bridges xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge"><bridge><name>br0</name><component><name>c0</name>` +
+		`<stream-filters xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-stream-filters-gates">` +
+		`<stream-filter-instance-table><stream-filter-instance-id>1</stream-filter-instance-id></stream-filter-instance-table>` +
+		`<stream-filter-instance-table><stream-filter-instance-id>2</stream-filter-instance-id></stream-filter-instance-table>` +
+		`</stream-filters></component></bridge></bridges>`
+
+	// syncRunning as NetconfSnapshot.Update builds it: indented, with a
+	// namespace prefix and the replaced entry moved last.
+	syncLocal = `<q:bridges xmlns:q="urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge">
+  <q:bridge>
+    <q:name>br0</q:name>
+    <q:component>
+      <q:name>c0</q:name>
+      <stream-filters xmlns="urn:ieee:std:802.1Q:yang:ieee802-dot1q-stream-filters-gates">
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>2</stream-filter-instance-id>
+        </stream-filter-instance-table>
+        <stream-filter-instance-table>
+          <stream-filter-instance-id>1</stream-filter-instance-id>
+        </stream-filter-instance-table>
+      </stream-filters>
+    </q:component>
+  </q:bridge>
+</q:bridges>
+`
+)
+
+// fakeDevice is an in-memory NETCONF server answering get-config with its
+// running configuration and every other RPC with <ok/>.
+type fakeDevice struct {
+	replies chan []byte
+	closed  chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	running string
+	edits   [][]byte // config of the edit-config RPCs received
+	locks   []string // lock and unlock RPCs received, e.g. "lock running"
+}
+
+var (
+	messageID  = regexp.MustCompile(`message-id="([^"]+)"`)
+	editConfig = regexp.MustCompile(`(?s)<config>(.*)</config>`)
+	lockTarget = regexp.MustCompile(`<(lock|unlock)><target><(\w+)`)
+)
+
+func newFakeDevice(running string, capabilities ...string) *fakeDevice {
+	d := &fakeDevice{
+		replies: make(chan []byte, 16),
+		closed:  make(chan struct{}),
+		running: running,
+	}
+	hello := `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>urn:ietf:params:netconf:base:1.0</capability>`
+	for _, capability := range capabilities {
+		hello += `<capability>` + capability + `</capability>`
+	}
+	d.replies <- []byte(hello + `</capabilities><session-id>7</session-id></hello>`)
+	return d
+}
+
+func (d *fakeDevice) setRunning(running string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running = running
+}
+
+func (d *fakeDevice) Send(data []byte) error {
+	select {
+	case <-d.closed:
+		return errors.New("transport closed")
+	default:
+	}
+
+	m := messageID.FindSubmatch(data)
+	if m == nil {
+		return nil
+	}
+
+	body := "<ok/>"
+	if bytes.Contains(data, []byte("get-config")) {
+		d.mu.Lock()
+		body = "<data>" + d.running + "</data>"
+		d.mu.Unlock()
+	}
+	if c := editConfig.FindSubmatch(data); c != nil {
+		d.mu.Lock()
+		d.edits = append(d.edits, c[1])
+		d.mu.Unlock()
+	}
+	if l := lockTarget.FindSubmatch(data); l != nil {
+		d.mu.Lock()
+		d.locks = append(d.locks, string(l[1])+" "+string(l[2]))
+		d.mu.Unlock()
+	}
+
+	d.replies <- []byte(fmt.Sprintf(
+		`<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" message-id="%s">%s</rpc-reply>`, m[1], body))
+	return nil
+}
+
+func (d *fakeDevice) Receive() ([]byte, error) {
+	select {
+	case reply := <-d.replies:
+		return reply, nil
+	case <-d.closed:
+		return nil, errors.New("transport closed")
+	}
+}
+
+func (d *fakeDevice) Close() error {
+	d.once.Do(func() { close(d.closed) })
+	return nil
+}
+
+func (d *fakeDevice) SetVersion(string) {}
+
+// newSyncBackend returns a backend whose session pool holds an idle session
+// to device, so it never dials the node.
+func newSyncBackend(t *testing.T, device *fakeDevice) (*NetconfBackend, *topology.Node, storewrapper.Store) {
+	t.Helper()
+
+	node := &topology.Node{Name: "bridge-1", ManagementInfo: &topology.ManagementInfo{}}
+
+	pool := managementSessions.NewSessionPool(nil, managementSessions.PoolConfig{})
+	t.Cleanup(pool.Close)
+
+	lease, err := pool.Acquire(context.Background(), node.Name, func(ctx context.Context) (*netconf.Session, error) {
+		return managementSessions.NewSession(device)
+	})
+	if err != nil {
+		t.Fatalf("open session: %v", err)
+	}
+	lease.Release()
+
+	store := storewrapper.NewMemoryStore()
+
+	backend := NewNetconfBackend("netconf", nil)
+	backend.SetSessionPool(pool)
+	backend.SetStore(store)
+
+	return backend, node, store
+}
+
+func persistedSnapshots(t *testing.T, store storewrapper.Store) int {
+	t.Helper()
+
+	persisted, err := storewrapper.GetNodeSnapshots(context.Background(), store, "netconf")
+	if err != nil {
+		t.Fatalf("read persisted snapshots: %v", err)
+	}
+	return len(persisted)
+}
+
+func TestSyncSnapshot_FirstSyncSeedsSnapshots(t *testing.T) {
+	backend, node, store := newSyncBackend(t, newFakeDevice(syncRunning))
+
+	if err := backend.SyncSnapshot(context.Background(), node); err != nil {
+		t.Fatalf("SyncSnapshot failed: %v", err)
+	}
+
+	current, lastStable, ok := backend.Snapshots(node.Name)
+	if !ok {
+		t.Fatalf("expected snapshots of %s", node.Name)
+	}
+
+	want, err := normalizeXML([]byte(syncRunning))
+	if err != nil {
+		t.Fatalf("normalize running: %v", err)
+	}
+	if !bytes.Equal(current, want) || !bytes.Equal(lastStable, want) {
+		t.Fatalf("expected both snapshots to be the running config, got current:\n%s\nlast stable:\n%s", current, lastStable)
+	}
+	if n := persistedSnapshots(t, store); n != 1 {
+		t.Fatalf("expected the snapshots persisted, got %d", n)
+	}
+}
+
+func TestSyncSnapshot_KeepsCurrentWithoutDrift(t *testing.T) {
+	backend, node, store := newSyncBackend(t, newFakeDevice(syncRunning))
+
+	backend.snapshots[node.Name] = &SnapshotSet[*NetconfSnapshot]{
+		Current:    &NetconfSnapshot{XML: []byte(syncLocal)},
+		LastStable: &NetconfSnapshot{XML: []byte(syncLocal)},
+	}
+
+	if err := backend.SyncSnapshot(context.Background(), node); err != nil {
+		t.Fatalf("SyncSnapshot failed: %v", err)
+	}
+
+	current, _, _ := backend.Snapshots(node.Name)
+	if string(current) != syncLocal {
+		t.Fatalf("expected current kept, got:\n%s", current)
+	}
+	if n := persistedSnapshots(t, store); n != 0 {
+		t.Fatalf("expected nothing persisted without drift, got %d", n)
+	}
+}
+
+func TestSyncSnapshot_ReplacesCurrentOnDrift(t *testing.T) {
+	device := newFakeDevice(syncRunning)
+	backend, node, store := newSyncBackend(t, device)
+
+	backend.snapshots[node.Name] = &SnapshotSet[*NetconfSnapshot]{
+		Current:    &NetconfSnapshot{XML: []byte(syncLocal)},
+		LastStable: &NetconfSnapshot{XML: []byte(syncLocal)},
+	}
+
+	drifted := strings.Replace(syncRunning, "<stream-filter-instance-id>2<", "<stream-filter-instance-id>3<", 1)
+	device.setRunning(drifted)
+
+	if err := backend.SyncSnapshot(context.Background(), node); err != nil {
+		t.Fatalf("SyncSnapshot failed: %v", err)
+	}
+
+	current, lastStable, _ := backend.Snapshots(node.Name)
+
+	want, err := normalizeXML([]byte(drifted))
+	if err != nil {
+		t.Fatalf("normalize running: %v", err)
+	}
+	if !bytes.Equal(current, want) {
+		t.Fatalf("expected current to be the drifted running config, got:\n%s", current)
+	}
+	if string(lastStable) != syncLocal {
+		t.Fatalf("expected last stable kept, got:\n%s", lastStable)
+	}
+	if n := persistedSnapshots(t, store); n != 1 {
+		t.Fatalf("expected the drifted snapshot persisted, got %d", n)
+	}
+}
+
+func TestRollback_RemovesEntriesAddedSinceLastStable(t *testing.T) {
+	device := newFakeDevice(restoreCurrent)
+	backend, node, _ := newSyncBackend(t, device)
+
+	backend.snapshots[node.Name] = &SnapshotSet[*NetconfSnapshot]{
+		Current:    &NetconfSnapshot{XML: []byte(restoreCurrent)},
+		LastStable: &NetconfSnapshot{XML: []byte(restoreTarget)},
+	}
+
+	if err := backend.Rollback(context.Background(), node); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	device.mu.Lock()
+	edits := device.edits
+	device.mu.Unlock()
+
+	if len(edits) != 1 {
+		t.Fatalf("expected one edit-config, got %d", len(edits))
+	}
+
+	restored := applyEdit(t, []byte(restoreCurrent), edits[0])
+
+	want, err := normalizeXML([]byte(restoreTarget))
+	if err != nil {
+		t.Fatalf("normalize target: %v", err)
+	}
+	if !bytes.Equal(restored, want) {
+		t.Fatalf("expected the last stable configuration, got:\n%s", restored)
+	}
+}
+
+func TestLock_LocksRunningAndCandidate(t *testing.T) {
+	device := newFakeDevice(syncRunning, managementSessions.CapabilityCandidate)
+	backend, node, _ := newSyncBackend(t, device)
+
+	if err := backend.Lock(context.Background(), node); err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	if err := backend.Unlock(context.Background(), node); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	device.mu.Lock()
+	locks := device.locks
+	device.mu.Unlock()
+
+	want := []string{"lock running", "lock candidate", "unlock candidate", "unlock running"}
+	if !slices.Equal(locks, want) {
+		t.Fatalf("expected %q, got %q", want, locks)
+	}
+}
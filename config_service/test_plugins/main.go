@@ -2,6 +2,16 @@
 
 package main
 
+import managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+
+// labHostKeys pins the host key of the lab switch on first use, for this run only.
+var labHostKeys = managementSessions.NewKnownHosts(
+	managementSessions.HostKeyTOFU,
+	managementSessions.NewMemoryHostKeyStore(),
+	nil,
+	nil,
+)
+
 func main() {
 	//TestNetconfProtocol()
 	// Run all plugin tests here
@@ -9,5 +19,21 @@ func main() {
 	//TestPriorityPlugin_tttech()
 	//TestQbvPlugin()
 	// TestQbvPlugin_tttech()
+	//TestPsfpPlugin()
+	//TestPsfpPlugin_tttech()
+	//TestCbsPlugin()
+	//TestCbsPlugin_tttech()
+	//TestFrerPlugin()
+	//TestFrerPlugin_tttech()
+	//TestMstpPlugin()
+	//TestMstpPlugin_tttech()
+	//TestGptpPlugin()
+	//TestGptpPlugin_tttech()
+	//TestPreemptionPlugin()
+	//TestPreemptionPlugin_tttech()
+	//TestAdminStatePlugin()
+	//TestAdminStatePlugin_tttech()
+	//TestAtsPlugin()
+	//TestAtsPlugin_tttech()
 
 }
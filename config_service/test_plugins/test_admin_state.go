@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// testAdminStatePortConfig takes sw0p3 down by disabling both directions.
+func testAdminStatePortConfig() *topology_config.PortConfig {
+	return &topology_config.PortConfig{
+		PortId:         "sw0p3",
+		IngressEnabled: ygot.Bool(false),
+		EgressEnabled:  ygot.Bool(false),
+	}
+}
+
+func TestAdminStatePlugin() {
+	logger := log.New(log.Writer(), "[TEST-ADMIN-STATE] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewAdminStateNetconfPlugin(logger)
+
+	// A single direction cannot be disabled
+	oneWay := testAdminStatePortConfig()
+	oneWay.EgressEnabled = ygot.Bool(true)
+	if _, err := plugin.Map(proto.Message(oneWay)); err == nil {
+		logger.Fatalf("Map accepted a port disabled in one direction only")
+	}
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testAdminStatePortConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestAdminStatePlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-ADMIN-STATE] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewAdminStateNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testAdminStatePortConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
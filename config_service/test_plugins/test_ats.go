@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ats "OpenCNC_config_service/common/structures/ats"
+	psfp "OpenCNC_config_service/common/structures/psfp"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// testAtsBridgeConfig shapes streams 1 and 2 with their own token bucket, in
+// a group bounding their residence time to 100 µs.
+func testAtsBridgeConfig() *topology_config.BridgeConfig {
+	return &topology_config.BridgeConfig{
+		Ats: &ats.AtsConfig{
+			SchedulerGroups: []*ats.AtsSchedulerGroup{
+				{GroupId: 1, MaxResidenceTimeNs: 100_000},
+			},
+			Schedulers: []*ats.AtsScheduler{
+				{SchedulerId: 1, GroupId: 1, CommittedInformationRate: 10_000_000, CommittedBurstSize: 12_176}, // 10 Mbit/s, one 1522 byte frame
+				{SchedulerId: 2, GroupId: 1, CommittedInformationRate: 5_000_000, CommittedBurstSize: 12_176},
+			},
+		},
+		Psfp: &psfp.PsfpConfig{
+			StreamFilters: []*psfp.StreamFilterInstance{
+				{Index: 1, StreamHandle: ygot.Uint32(1), AtsSchedulerId: ygot.Uint32(1)},
+				{Index: 2, StreamHandle: ygot.Uint32(2), AtsSchedulerId: ygot.Uint32(2)},
+			},
+		},
+	}
+}
+
+func TestAtsPlugin() {
+	logger := log.New(log.Writer(), "[TEST-ATS] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewAtsNetconfPlugin(logger)
+
+	// Stream filters must reference configured schedulers
+	bridgeCfg := testAtsBridgeConfig()
+	bridgeCfg.Psfp.StreamFilters[1].AtsSchedulerId = ygot.Uint32(3)
+	if err := plugin.ValidateNode(&topology_config.NodeConfig{NodeId: "sw", Bridge: bridgeCfg}); err == nil {
+		logger.Fatalf("ValidateNode accepted an unknown ATS scheduler")
+	}
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testAtsBridgeConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestAtsPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-ATS] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewAtsNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testAtsBridgeConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	qav "OpenCNC_config_service/common/structures/qav"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// testCbsPortConfig shapes traffic classes 5 and 6 of sw0p3.
+func testCbsPortConfig() *topology_config.PortConfig {
+	cbs := topology_config.SchedulingModel_CREDIT_BASED_SHAPER
+
+	return &topology_config.PortConfig{
+		PortId: "sw0p3",
+		QueueConfigs: []*topology_config.QueueConfig{
+			{
+				QueueId:    5,
+				Scheduling: &cbs,
+				Cbs: []*qav.CbsQueueConfig{{
+					EgressQueueId:     5,
+					AdminState:        qav.AdminState_ENABLED,
+					AdminIdleSlopeBps: 200_000_000, // 200 Mbit/s
+					SendSlopeBps:      800_000_000,
+					HiCreditBytes:     3_000,
+					LoCreditBytes:     1_500,
+				}},
+			},
+			{
+				QueueId:    6,
+				Scheduling: &cbs,
+				Cbs: []*qav.CbsQueueConfig{{
+					EgressQueueId:     6,
+					AdminState:        qav.AdminState_ENABLED,
+					AdminIdleSlopeBps: 100_000_000, // 100 Mbit/s
+				}},
+			},
+		},
+	}
+}
+
+func TestCbsPlugin() {
+	logger := log.New(log.Writer(), "[TEST-CBS] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewCbsNetconfPlugin(logger)
+
+	portCfg := testCbsPortConfig()
+
+	// 300 Mbit/s fit a gigabit port, not a 100 Mbit/s one
+	port := &topology.Port{Id: "sw0p3", Capabilities: &topology.InterfaceCapabilities{PortSpeed: 1000}}
+	if err := plugin.ValidatePort(portCfg, port); err != nil {
+		logger.Fatalf("ValidatePort failed: %v", err)
+	}
+	port.Capabilities.PortSpeed = 100
+	if err := plugin.ValidatePort(portCfg, port); err == nil {
+		logger.Fatalf("ValidatePort accepted idle slopes above the port speed")
+	}
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(portCfg))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestCbsPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-CBS] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewCbsNetconfPlugin_rtaw(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testCbsPortConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
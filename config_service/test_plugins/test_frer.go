@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	frer "OpenCNC_config_service/common/structures/frer"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// testFrerBridgeConfig replicates stream 1, received on sw0p3, onto the two
+// ring ports sw0p1 and sw0p2, and eliminates the duplicates of stream 2
+// arriving from the ring before forwarding it on sw0p3.
+func testFrerBridgeConfig() *topology_config.BridgeConfig {
+	return &topology_config.BridgeConfig{
+		Frer: &frer.FrerBridgeConfig{
+			StreamIdentities: []*frer.StreamIdentity{
+				{
+					Index:               1,
+					Stream:              &frer.StreamID{Handle: 1, Name: "talker-a"},
+					Type:                frer.StreamIdentificationType_NULL_STREAM,
+					MacAddress:          "01:1b:19:00:00:01",
+					VlanId:              10,
+					InFacingOutputPorts: []string{"sw0p1", "sw0p2"},
+				},
+				{
+					Index:                2,
+					Stream:               &frer.StreamID{Handle: 2, Name: "talker-b"},
+					Type:                 frer.StreamIdentificationType_SOURCE_MAC_VLAN,
+					MacAddress:           "00:1b:19:00:00:02",
+					VlanId:               10,
+					Tagging:              frer.VlanTagging_TAGGED,
+					OutFacingOutputPorts: []string{"sw0p3"},
+				},
+			},
+			SequenceGenerations: []*frer.SequenceGeneration{
+				{Index: 1, StreamHandles: []uint32{1}},
+			},
+			SequenceRecoveries: []*frer.SequenceRecovery{
+				{
+					Index:                1,
+					StreamHandles:        []uint32{2},
+					PortIds:              []string{"sw0p3"},
+					DirectionOutFacing:   true,
+					Algorithm:            frer.RecoveryAlgorithm_VECTOR,
+					Parameters:           &frer.FrerRecoveryAlgorithm{SequenceHistoryLength: 32, HoldOffTimeNs: 2_000_000},
+					LatentErrorDetection: true,
+				},
+			},
+			SequenceIdentifications: []*frer.SequenceIdentification{
+				{PortId: "sw0p1", DirectionOutFacing: true, StreamHandles: []uint32{1}, Active: true},
+				{PortId: "sw0p2", DirectionOutFacing: true, StreamHandles: []uint32{1}, Active: true},
+				{PortId: "sw0p3", DirectionOutFacing: true, StreamHandles: []uint32{2}},
+			},
+		},
+	}
+}
+
+func TestFrerPlugin() {
+	logger := log.New(log.Writer(), "[TEST-FRER] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewFrerNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testFrerBridgeConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestFrerPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-FRER] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewFrerNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testFrerBridgeConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
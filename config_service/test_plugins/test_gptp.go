@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	qbv "OpenCNC_config_service/common/structures/qbv"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// testGptpNodeConfig enables gPTP on the bridge, with sw0p1 towards the
+// grandmaster and sw0p3 serving time to an end station.
+func testGptpNodeConfig() *topology_config.NodeConfig {
+	slave := topology_config.GptpRole_GPTP_ROLE_SLAVE
+	master := topology_config.GptpRole_GPTP_ROLE_MASTER
+
+	return &topology_config.NodeConfig{
+		NodeId: "lab-switch",
+		Bridge: &topology_config.BridgeConfig{
+			GptpEnabled: ygot.Bool(true),
+		},
+		PortConfigs: []*topology_config.PortConfig{
+			{PortId: "sw0p1", GptpRole: &slave, GptpPortNumber: ygot.Uint32(1)},
+			{PortId: "sw0p3", GptpRole: &master, GptpPortNumber: ygot.Uint32(3)},
+		},
+	}
+}
+
+// testGptpMessages returns the messages the backend passes to the plugin.
+func testGptpMessages(nodeCfg *topology_config.NodeConfig) []proto.Message {
+	msgs := []proto.Message{nodeCfg.GetBridge()}
+	for _, portCfg := range nodeCfg.GetPortConfigs() {
+		msgs = append(msgs, portCfg)
+	}
+	return msgs
+}
+
+func TestGptpPlugin() {
+	logger := log.New(log.Writer(), "[TEST-GPTP] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewGptpNetconfPlugin(logger)
+
+	nodeCfg := testGptpNodeConfig()
+
+	if err := plugin.ValidateNode(nodeCfg); err != nil {
+		logger.Fatalf("ValidateNode failed: %v", err)
+	}
+
+	// A schedule needs time synchronization
+	unsynced := proto.Clone(nodeCfg).(*topology_config.NodeConfig)
+	unsynced.Bridge.GptpEnabled = ygot.Bool(false)
+	unsynced.PortConfigs = []*topology_config.PortConfig{{PortId: "sw0p3", Gcl: &qbv.GateControlList{}}}
+	if err := netconf.NewQbvNetconfPlugin(logger).ValidateNode(unsynced); err == nil {
+		logger.Fatalf("ValidateNode accepted a Qbv schedule without time synchronization")
+	}
+
+	for _, msg := range testGptpMessages(nodeCfg) {
+
+		// Map the message to YGOT structure
+		mapped, err := plugin.Map(msg)
+		if err != nil {
+			logger.Fatalf("Map failed: %v", err)
+		}
+
+		// Build XML
+		featureXML, err := plugin.BuildFeatureXML(mapped)
+		if err != nil {
+			logger.Fatalf("BuildXML failed: %v", err)
+		}
+
+		fmt.Println("===== GENERATED XML =====")
+		fmt.Println(string(featureXML.XML))
+		fmt.Println("=========================")
+	}
+}
+
+func TestGptpPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-GPTP] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewGptpNetconfPlugin_tttech(logger)
+
+	for _, msg := range testGptpMessages(testGptpNodeConfig()) {
+
+		// Map the message to YGOT structure
+		mapped, err := plugin.Map(msg)
+		if err != nil {
+			logger.Fatalf("Map failed: %v", err)
+		}
+
+		// Push the config
+		err = plugin.Push(mapped, target)
+		if err != nil {
+			logger.Fatalf("Push failed: %v", err)
+		}
+	}
+}
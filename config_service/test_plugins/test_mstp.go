@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	stp "OpenCNC_config_service/common/structures/stp"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// testMstpBridgeConfig makes the bridge root of MSTI 1, which carries FIDs 10
+// to 12, with sw0p1 preferred over sw0p2 towards the root of the CIST.
+func testMstpBridgeConfig() *topology_config.BridgeConfig {
+	return &topology_config.BridgeConfig{
+		Stp: &stp.StpConfiguration{
+			StpEnabled: ygot.Bool(true),
+			SpanningTreeInstances: []*stp.SpanningTreeInstance{
+				{
+					InstanceId:     "cist",
+					BridgePriority: ygot.Uint32(32768),
+					PortStates: []*stp.StpPortState{
+						{PortId: "sw0p1", PathCost: ygot.Uint32(20000)},
+						{PortId: "sw0p2", PathCost: ygot.Uint32(200000), PortPriority: ygot.Uint32(160)},
+					},
+				},
+				{
+					InstanceId:     "mst1",
+					BridgePriority: ygot.Uint32(4096),
+				},
+			},
+		},
+		MstConfig: &stp.BridgeMstConfig{
+			SupportedMstIds: []uint32{1},
+			FidToMstidAllocations: []*stp.FidRangeToMstidAllocation{
+				{Fids: []uint32{10, 11, 12}, MstId: 1},
+			},
+		},
+	}
+}
+
+// testMstpPortConfig makes sw0p3, facing an end station, an edge port.
+func testMstpPortConfig() *topology_config.PortConfig {
+	return &topology_config.PortConfig{
+		PortId:     "sw0p3",
+		StpEnabled: ygot.Bool(true),
+		IsEdgePort: ygot.Bool(true),
+	}
+}
+
+func TestMstpPlugin() {
+	logger := log.New(log.Writer(), "[TEST-MSTP] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewMstpNetconfPlugin(logger)
+
+	for _, msg := range []proto.Message{testMstpBridgeConfig(), testMstpPortConfig()} {
+
+		// Map the message to YGOT structure
+		mapped, err := plugin.Map(msg)
+		if err != nil {
+			logger.Fatalf("Map failed: %v", err)
+		}
+
+		// Build XML
+		featureXML, err := plugin.BuildFeatureXML(mapped)
+		if err != nil {
+			logger.Fatalf("BuildXML failed: %v", err)
+		}
+
+		fmt.Println("===== GENERATED XML =====")
+		fmt.Println(string(featureXML.XML))
+		fmt.Println("=========================")
+	}
+}
+
+func TestMstpPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-MSTP] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewMstpNetconfPlugin(logger)
+
+	for _, msg := range []proto.Message{testMstpBridgeConfig(), testMstpPortConfig()} {
+
+		// Map the message to YGOT structure
+		mapped, err := plugin.Map(msg)
+		if err != nil {
+			logger.Fatalf("Map failed: %v", err)
+		}
+
+		// Push the config
+		err = plugin.Push(mapped, target)
+		if err != nil {
+			logger.Fatalf("Push failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	qbv "OpenCNC_config_service/common/structures/qbv"
+	"OpenCNC_config_service/common/structures/topology"
+	topology_config "OpenCNC_config_service/common/structures/topology_config"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// testPreemptionPortConfig keeps priority 7 express on sw0p3 and makes the
+// others preemptable, so the guard band before the express window only has
+// to cover the part of a preemptable frame that cannot be preempted.
+func testPreemptionPortConfig() *topology_config.PortConfig {
+	portCfg := &topology_config.PortConfig{
+		PortId: "sw0p3",
+		Gcl: &qbv.GateControlList{
+			ScheduleId: "express-window",
+			CycleTime:  300_000,
+			AdminState: qbv.AdminState_ENABLED,
+			Entries: []*qbv.GateControlEntry{
+				{Index: 0, TimeInterval: 100_000, GateStates: []byte{0x80}, Description: "express window"},
+				{Index: 1, TimeInterval: 198_800, GateStates: []byte{0x7f}, Description: "preemptable traffic"},
+				{Index: 2, TimeInterval: 1_200, GateStates: []byte{0x00}, Description: "guard band"},
+			},
+		},
+	}
+
+	for pcp := uint32(0); pcp <= 7; pcp++ {
+		status := topology_config.FramePreemptionStatus_FRAME_PREEMPTION_PREEMPTABLE
+		if pcp == 7 {
+			status = topology_config.FramePreemptionStatus_FRAME_PREEMPTION_EXPRESS
+		}
+		portCfg.FramePreemption = append(portCfg.FramePreemption, &topology_config.FramePreemptionEntry{
+			Pcp:    pcp,
+			Status: status,
+		})
+	}
+
+	return portCfg
+}
+
+func TestPreemptionPlugin() {
+	logger := log.New(log.Writer(), "[TEST-PREEMPTION] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewPreemptionNetconfPlugin(logger)
+
+	portCfg := testPreemptionPortConfig()
+
+	// 1.2 µs cover a held preemptable frame on a gigabit port, not a full frame
+	port := &topology.Port{Id: "sw0p3", Capabilities: &topology.InterfaceCapabilities{PortSpeed: 1000}}
+	if err := plugin.ValidatePort(portCfg, port); err != nil {
+		logger.Fatalf("ValidatePort failed: %v", err)
+	}
+	express := proto.Clone(portCfg).(*topology_config.PortConfig)
+	express.FramePreemption[6].Status = topology_config.FramePreemptionStatus_FRAME_PREEMPTION_EXPRESS
+	if err := plugin.ValidatePort(express, port); err == nil {
+		logger.Fatalf("ValidatePort accepted a guard band shorter than an express frame")
+	}
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(portCfg))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestPreemptionPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-PREEMPTION] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewPreemptionNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testPreemptionPortConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
@@ -18,7 +18,7 @@ func TestPriorityPlugin_tttech() {
 	target := managementSessions.DeviceTarget{
 		InterfaceName: "sw0p3",
 		Logger:        logger,
-		Secret:        "", // password is empty
+		HostKey:       labHostKeys.Callback("lab-switch"),
 		Info: &topology.ManagementInfo{
 			IpAddress:      "192.168.0.1", // IP address
 			UserName:       "root",        // username
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
 
+	storewrapper "OpenCNC_config_service/common/store-wrapper"
 	"OpenCNC_config_service/common/structures/qbv"
 	"OpenCNC_config_service/common/structures/topology"
 	topology_config "OpenCNC_config_service/common/structures/topology_config"
@@ -33,6 +35,15 @@ func TestNetconfProtocol() {
 		netconfPlugins...,
 	)
 	//backend := protocolbackends.NewNetconfBackend("netconf", plugin_qbv, plugin_pcp)
+
+	// device models are read from the local etcd
+	store, err := storewrapper.NewEtcdStore(storewrapper.DefaultConfig())
+	if err != nil {
+		logger.Fatalf("Store failed: %v", err)
+	}
+	defer store.Close()
+	backend.SetStore(store)
+
 	operation := &engine.Operation{
 		Node:    target,
 		Config:  nodecfg,
@@ -43,7 +54,7 @@ func TestNetconfProtocol() {
 		Operations: []engine.Operation{*operation},
 	}
 
-	tx.Commit()
+	tx.Commit(context.Background())
 }
 
 var target = &topology.Node{
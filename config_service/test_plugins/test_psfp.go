@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	psfp "OpenCNC_config_service/common/structures/psfp"
+	"OpenCNC_config_service/common/structures/topology"
+	managementSessions "OpenCNC_config_service/config_service/pkg/managementSessions"
+	netconf "OpenCNC_config_service/config_service/pkg/plugins/netconf"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// testPsfpConfig filters stream 1 through a gate and a meter, and any stream
+// with priority 3 through the meter only.
+func testPsfpConfig() *psfp.PsfpConfig {
+	return &psfp.PsfpConfig{
+		StreamGates: []*psfp.StreamGateInstance{
+			{
+				Name:            "gate-1",
+				InstanceId:      1,
+				AdminGateStates: []byte{0x01}, // open
+				BaseTimeNs:      0,
+				CycleTimeNs:     1_000_000, // 1ms
+				GateControlList: []*psfp.GateControlEntry{
+					{TimeIntervalNs: 500_000, GateState: psfp.GateState_OPEN, PriorityOverride: proto.Uint32(5)},
+					{TimeIntervalNs: 500_000, GateState: psfp.GateState_CLOSED, PriorityOverride: proto.Uint32(5)},
+				},
+			},
+		},
+		FlowMeters: []*psfp.FlowMeterInstance{
+			{
+				Name:           "meter-1",
+				InstanceId:     1,
+				Cir:            10_000_000, // 10 Mbit/s
+				Cbs:            3_000,
+				Pir:            20_000_000,
+				Pbs:            6_000,
+				ExceededAction: psfp.ExceededAction_DROP,
+			},
+		},
+		StreamFilters: []*psfp.StreamFilterInstance{
+			{
+				Index:             1,
+				StreamHandle:      ygot.Uint32(1),
+				FilterSpec:        &psfp.FilterSpecification{MaxSduSize: 1522, BlockOnExceed: true},
+				GateInstanceName:  "gate-1",
+				MeterInstanceName: "meter-1",
+			},
+			{
+				Index: 2,
+				FilterSpec: &psfp.FilterSpecification{
+					Match: &psfp.MatchCriteria{Priority: ygot.Uint32(3)},
+				},
+				MeterInstanceName: "meter-1",
+			},
+		},
+	}
+}
+
+func TestPsfpPlugin() {
+	logger := log.New(log.Writer(), "[TEST-PSFP] ", log.LstdFlags)
+
+	// Create plugin
+	plugin := netconf.NewPsfpNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testPsfpConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Build XML
+	featureXML, err := plugin.BuildFeatureXML(mapped)
+	if err != nil {
+		logger.Fatalf("BuildXML failed: %v", err)
+	}
+
+	fmt.Println("===== GENERATED XML =====")
+	fmt.Println(string(featureXML.XML))
+	fmt.Println("=========================")
+}
+
+func TestPsfpPlugin_tttech() {
+	logger := log.New(log.Writer(), "[TEST-tttech-PSFP] ", log.LstdFlags)
+
+	// device target
+	target := managementSessions.DeviceTarget{
+		InterfaceName: "sw0p3",
+		Logger:        logger,
+		HostKey:       labHostKeys.Callback("lab-switch"),
+		Info: &topology.ManagementInfo{
+			IpAddress:      "192.168.0.1", // IP address
+			UserName:       "root",        // username
+			ManagementPort: 830,           // default NETCONF port
+			Protocol:       topology.ManagementProtocol_NETCONF,
+		},
+	}
+
+	// Create plugin
+	plugin := netconf.NewPsfpNetconfPlugin(logger)
+
+	// Map the message to YGOT structure
+	mapped, err := plugin.Map(proto.Message(testPsfpConfig()))
+	if err != nil {
+		logger.Fatalf("Map failed: %v", err)
+	}
+
+	// Push the config
+	err = plugin.Push(mapped, target)
+	if err != nil {
+		logger.Fatalf("Push failed: %v", err)
+	}
+}
@@ -18,7 +18,7 @@ func TestVlanPlugin_tttech() {
 	target := managementSessions.DeviceTarget{
 		InterfaceName: "sw0p3",
 		Logger:        logger,
-		Secret:        "",
+		HostKey:       labHostKeys.Callback("lab-switch"),
 		Info: &topology.ManagementInfo{
 			IpAddress:      "192.168.0.1",
 			UserName:       "root",